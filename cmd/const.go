@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"io"
+	"os"
+
 	"github.com/bruin-data/bruin/pkg/git"
 	"github.com/bruin-data/bruin/pkg/glossary"
 	"github.com/bruin-data/bruin/pkg/pipeline"
@@ -19,6 +22,10 @@ var (
 	warningPrinter = color.New(color.FgYellow, color.Bold)
 	successPrinter = color.New(color.FgGreen, color.Bold)
 
+	// jsonOutput is the original stdout, the commands with the JSON output print their logs to stderr and only the
+	// JSON here so that it can be parsed
+	jsonOutput io.Writer = os.Stdout
+
 	assetsDirectoryNames = []string{"tasks", "assets"}
 
 	builderConfig = pipeline.BuilderConfig{
@@ -3,10 +3,18 @@ package cmd
 import (
 	"fmt"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 
+	"github.com/bruin-data/bruin/pkg/catalog"
+	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/bruin-data/bruin/pkg/glossary"
+	"github.com/bruin-data/bruin/pkg/jinja"
+	"github.com/bruin-data/bruin/pkg/path"
+	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/telemetry"
 	"github.com/pkg/errors"
+	"github.com/spf13/afero"
 	"github.com/urfave/cli/v2"
 )
 
@@ -20,6 +28,9 @@ func Docs() *cli.Command {
 				Usage: "Open the documentation in your default web browser",
 			},
 		},
+		Subcommands: []*cli.Command{
+			BuildDocs(),
+		},
 		Action: func(c *cli.Context) error {
 			const docsURL = "https://bruin-data.github.io/bruin/"
 			openFlag := c.Bool("open")
@@ -40,6 +51,82 @@ func Docs() *cli.Command {
 	}
 }
 
+func BuildDocs() *cli.Command {
+	return &cli.Command{
+		Name:      "build",
+		Usage:     "generate a static HTML catalog of all the pipelines in the repository",
+		ArgsUsage: "[path to the repository]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "the folder to write the catalog to",
+				Value:   "catalog",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			inputPath := c.Args().Get(0)
+			if inputPath == "" {
+				inputPath = "."
+			}
+
+			repoRoot, err := git.FindRepoFromPath(inputPath)
+			if err != nil {
+				errorPrinter.Printf("Failed to find the git repository root: %v\n", err)
+				return cli.Exit("", 1)
+			}
+
+			pipelinePaths, err := path.GetPipelinePaths(inputPath, pipelineDefinitionFiles)
+			if err != nil {
+				errorPrinter.Printf("Failed to find the pipelines in '%s': %v\n", inputPath, err)
+				return cli.Exit("", 1)
+			}
+
+			pipelines := make([]*pipeline.Pipeline, 0, len(pipelinePaths))
+			assetCount := 0
+			for _, pipelinePath := range pipelinePaths {
+				foundPipeline, err := DefaultPipelineBuilder.CreatePipelineFromPath(pipelinePath, true)
+				if err != nil {
+					errorPrinter.Printf("Failed to build the pipeline at '%s': %v\n", pipelinePath, err)
+					return cli.Exit("", 1)
+				}
+
+				pipelines = append(pipelines, foundPipeline)
+				assetCount += len(foundPipeline.Assets)
+			}
+
+			glossaryReader := &glossary.GlossaryReader{
+				RepoFinder: &git.RepoFinder{},
+				FileNames:  []string{"glossary.yml", "glossary.yaml"},
+			}
+			foundGlossary, err := glossaryReader.GetGlossary(repoRoot.Path)
+			if err != nil {
+				errorPrinter.Printf("Failed to read the glossary: %v\n", err)
+				return cli.Exit("", 1)
+			}
+
+			macros, err := jinja.LoadMacroLibrary(filepath.Join(repoRoot.Path, jinja.MacrosFolder))
+			if err != nil {
+				errorPrinter.Printf("Failed to load the macros: %v\n", err)
+				return cli.Exit("", 1)
+			}
+
+			renderer := jinja.NewRendererWithYesterday("your-pipeline-name", "your-run-id").WithMacros(macros)
+			outputDir := c.String("output")
+			err = catalog.NewGenerator(afero.NewOsFs(), renderer).Generate(outputDir, pipelines, foundGlossary)
+			if err != nil {
+				errorPrinter.Printf("Failed to generate the catalog: %v\n", err)
+				return cli.Exit("", 1)
+			}
+
+			successPrinter.Printf("Generated the catalog of %d assets across %d pipelines at '%s'.\n", assetCount, len(pipelines), filepath.Join(outputDir, "index.html"))
+			return nil
+		},
+		Before: telemetry.BeforeCommand,
+		After:  telemetry.AfterCommand,
+	}
+}
+
 func openBrowser(url string) error {
 	var err error
 	switch runtime.GOOS {
@@ -197,7 +197,7 @@ func prepareAssetQuery(c *cli.Context, fs afero.Fs) (interface{}, string, error)
 			pipelineInfo.Asset.Type)
 	}
 
-	queryStr, err := extractQueryFromAsset(pipelineInfo.Asset, fs)
+	queryStr, err := extractQueryFromAsset(pipelineInfo.Pipeline, pipelineInfo.Asset, fs)
 	if err != nil {
 		return nil, "", err
 	}
@@ -210,16 +210,21 @@ func prepareAssetQuery(c *cli.Context, fs afero.Fs) (interface{}, string, error)
 	return conn, queryStr, nil
 }
 
-func extractQueryFromAsset(asset *pipeline.Asset, fs afero.Fs) (string, error) {
+func extractQueryFromAsset(p *pipeline.Pipeline, asset *pipeline.Asset, fs afero.Fs) (string, error) {
+	macros, err := loadMacrosForPath(asset.ExecutableFile.Path)
+	if err != nil {
+		return "", err
+	}
+
 	startDate := time.Now()
 	endDate := time.Now()
 	extractor := &query.WholeFileExtractor{
 		Fs:       fs,
-		Renderer: jinja.NewRendererWithStartEndDates(&startDate, &endDate, "your-pipeline-name", "your-run-id"),
+		Renderer: jinja.NewRendererWithStartEndDates(&startDate, &endDate, "your-pipeline-name", "your-run-id").WithMacros(macros),
 	}
 
 	// Extract the query from the asset
-	queries, err := extractor.ExtractQueriesFromString(asset.ExecutableFile.Content)
+	queries, err := extractor.CloneForAsset(context.Background(), p, asset).ExtractQueriesFromString(asset.ExecutableFile.Content)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to extract query")
 	}
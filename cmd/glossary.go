@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/glossary"
+	"github.com/bruin-data/bruin/pkg/path"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/telemetry"
+	"github.com/urfave/cli/v2"
+)
+
+func Glossary() *cli.Command {
+	return &cli.Command{
+		Name:      "glossary",
+		Usage:     "list the entities in the glossary, and the asset columns that use each of their attributes across the pipelines",
+		ArgsUsage: "[path to the project]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "the output type, possible values are: plain, json",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			defer RecoverFromPanic()
+
+			output := c.String("output")
+			inputPath := c.Args().Get(0)
+			if inputPath == "" {
+				inputPath = "."
+			}
+
+			foundGlossary, err := DefaultGlossaryReader.GetGlossary(inputPath)
+			if err != nil {
+				printError(err, output, "Failed to read the glossary")
+				return cli.Exit("", 1)
+			}
+
+			pipelinePaths, err := path.GetPipelinePaths(inputPath, pipelineDefinitionFiles)
+			if err != nil {
+				printError(err, output, "Failed to find the pipelines")
+				return cli.Exit("", 1)
+			}
+
+			pipelines := make([]*pipeline.Pipeline, 0, len(pipelinePaths))
+			for _, pipelinePath := range pipelinePaths {
+				p, err := DefaultPipelineBuilder.CreatePipelineFromPath(pipelinePath, true)
+				if err != nil {
+					printError(err, output, fmt.Sprintf("Failed to build the pipeline at '%s'", pipelinePath))
+					return cli.Exit("", 1)
+				}
+
+				pipelines = append(pipelines, p)
+			}
+
+			entities := glossaryEntityUsages(foundGlossary, pipelines)
+			if output == "json" {
+				res, err := json.MarshalIndent(struct {
+					Entities []*glossaryEntity `json:"entities"`
+				}{
+					Entities: entities,
+				}, "", "  ")
+				if err != nil {
+					printError(err, output, "Failed to marshal the glossary")
+					return cli.Exit("", 1)
+				}
+
+				fmt.Println(string(res))
+				return nil
+			}
+
+			printGlossary(entities)
+			return nil
+		},
+		Before: telemetry.BeforeCommand,
+		After:  telemetry.AfterCommand,
+	}
+}
+
+type glossaryEntity struct {
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Attributes  []*glossaryAttribute `json:"attributes"`
+}
+
+type glossaryAttribute struct {
+	Name          string                    `json:"name"`
+	Type          string                    `json:"type"`
+	Description   string                    `json:"description"`
+	PII           bool                      `json:"pii"`
+	Checks        []string                  `json:"checks"`
+	AllowedValues []interface{}             `json:"allowed_values"`
+	UsedBy        []*glossaryAttributeUsage `json:"used_by"`
+}
+
+type glossaryAttributeUsage struct {
+	Pipeline string `json:"pipeline"`
+	Asset    string `json:"asset"`
+	Column   string `json:"column"`
+}
+
+// glossaryEntityUsages returns the entities of the glossary sorted by their names, along with the asset columns that
+// extend each of their attributes.
+func glossaryEntityUsages(g *glossary.Glossary, pipelines []*pipeline.Pipeline) []*glossaryEntity {
+	usages := make(map[string][]*glossaryAttributeUsage)
+	for _, p := range pipelines {
+		for _, asset := range p.Assets {
+			for _, column := range asset.Columns {
+				if column.EntityAttribute == nil {
+					continue
+				}
+
+				key := column.EntityAttribute.Entity + "." + column.EntityAttribute.Attribute
+				usages[key] = append(usages[key], &glossaryAttributeUsage{
+					Pipeline: p.Name,
+					Asset:    asset.Name,
+					Column:   column.Name,
+				})
+			}
+		}
+	}
+
+	entities := make([]*glossaryEntity, 0, len(g.Entities))
+	for _, entity := range g.Entities {
+		result := &glossaryEntity{
+			Name:        entity.Name,
+			Description: strings.TrimSpace(entity.Description),
+			Attributes:  make([]*glossaryAttribute, 0, len(entity.Attributes)),
+		}
+
+		for _, attr := range entity.Attributes {
+			checks := make([]string, 0, len(attr.Checks))
+			for _, check := range attr.Checks {
+				checks = append(checks, check.Name)
+			}
+
+			usedBy := usages[entity.Name+"."+attr.Name]
+			if usedBy == nil {
+				usedBy = make([]*glossaryAttributeUsage, 0)
+			}
+			sort.SliceStable(usedBy, func(i, j int) bool {
+				if usedBy[i].Pipeline != usedBy[j].Pipeline {
+					return usedBy[i].Pipeline < usedBy[j].Pipeline
+				}
+				return usedBy[i].Asset < usedBy[j].Asset
+			})
+
+			result.Attributes = append(result.Attributes, &glossaryAttribute{
+				Name:          attr.Name,
+				Type:          attr.Type,
+				Description:   strings.TrimSpace(attr.Description),
+				PII:           attr.PII,
+				Checks:        checks,
+				AllowedValues: attr.AllowedValues,
+				UsedBy:        usedBy,
+			})
+		}
+
+		sort.Slice(result.Attributes, func(i, j int) bool {
+			return result.Attributes[i].Name < result.Attributes[j].Name
+		})
+		entities = append(entities, result)
+	}
+
+	sort.Slice(entities, func(i, j int) bool {
+		return entities[i].Name < entities[j].Name
+	})
+
+	return entities
+}
+
+func printGlossary(entities []*glossaryEntity) {
+	if len(entities) == 0 {
+		infoPrinter.Println("No entities found in the glossary.")
+		return
+	}
+
+	for _, entity := range entities {
+		infoPrinter.Printf("\n%s\n", entity.Name)
+		for _, attr := range entity.Attributes {
+			details := make([]string, 0)
+			if attr.Type != "" {
+				details = append(details, attr.Type)
+			}
+			if attr.PII {
+				details = append(details, "pii")
+			}
+			if len(attr.Checks) > 0 {
+				details = append(details, "checks: "+strings.Join(attr.Checks, ", "))
+			}
+			if len(attr.AllowedValues) > 0 {
+				values := make([]string, len(attr.AllowedValues))
+				for i, v := range attr.AllowedValues {
+					values[i] = fmt.Sprintf("%v", v)
+				}
+				details = append(details, "allowed values: "+strings.Join(values, ", "))
+			}
+
+			name := attr.Name
+			if len(details) > 0 {
+				name += " (" + strings.Join(details, "; ") + ")"
+			}
+			fmt.Printf("  %s\n", name)
+
+			if len(attr.UsedBy) == 0 {
+				fmt.Println("    not used by any asset")
+				continue
+			}
+
+			for _, usage := range attr.UsedBy {
+				fmt.Printf("    - %s: %s.%s\n", usage.Pipeline, usage.Asset, usage.Column)
+			}
+		}
+	}
+}
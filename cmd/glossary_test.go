@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/glossary"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlossaryEntityUsages(t *testing.T) {
+	t.Parallel()
+
+	g := &glossary.Glossary{
+		Entities: []*glossary.Entity{
+			{
+				Name: "Order",
+				Attributes: map[string]*glossary.Attribute{
+					"ID": {Name: "ID", Type: "integer"},
+				},
+			},
+			{
+				Name:        "Customer",
+				Description: "a customer\n",
+				Attributes: map[string]*glossary.Attribute{
+					"ID":    {Name: "ID", Type: "integer", Checks: []glossary.Check{{Name: "not_null"}, {Name: "unique"}}},
+					"Email": {Name: "Email", Type: "string", PII: true},
+				},
+			},
+		},
+	}
+
+	customerID := &pipeline.EntityAttribute{Entity: "Customer", Attribute: "ID"}
+	pipelines := []*pipeline.Pipeline{
+		{
+			Name: "sales",
+			Assets: []*pipeline.Asset{
+				{Name: "mart.orders", Columns: []pipeline.Column{{Name: "customer_id", EntityAttribute: customerID}, {Name: "total"}}},
+			},
+		},
+		{
+			Name: "crm",
+			Assets: []*pipeline.Asset{
+				{Name: "raw.customers", Columns: []pipeline.Column{{Name: "id", EntityAttribute: customerID}}},
+			},
+		},
+	}
+
+	entities := glossaryEntityUsages(g, pipelines)
+
+	assert.Equal(t, []*glossaryEntity{
+		{
+			Name:        "Customer",
+			Description: "a customer",
+			Attributes: []*glossaryAttribute{
+				{Name: "Email", Type: "string", PII: true, Checks: []string{}, UsedBy: []*glossaryAttributeUsage{}},
+				{
+					Name:   "ID",
+					Type:   "integer",
+					Checks: []string{"not_null", "unique"},
+					UsedBy: []*glossaryAttributeUsage{
+						{Pipeline: "crm", Asset: "raw.customers", Column: "id"},
+						{Pipeline: "sales", Asset: "mart.orders", Column: "customer_id"},
+					},
+				},
+			},
+		},
+		{
+			Name: "Order",
+			Attributes: []*glossaryAttribute{
+				{Name: "ID", Type: "integer", Checks: []string{}, UsedBy: []*glossaryAttributeUsage{}},
+			},
+		},
+	}, entities)
+}
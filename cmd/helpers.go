@@ -21,14 +21,14 @@ type ErrorResponses struct {
 	Error []string `json:"error"`
 }
 
-func switchEnvironment(env string, force bool, cm *config.Config, stdin io.ReadCloser) error {
+func switchEnvironment(env string, force bool, cm *config.Config, stdin io.ReadCloser, output io.Writer) error {
 	if env == "" {
 		return nil
 	}
 
 	err := cm.SelectEnvironment(env)
 	if err != nil {
-		errorPrinter.Printf("Failed to use the environment '%s': %v\n", env, err)
+		errorPrinter.Fprintf(output, "Failed to use the environment '%s': %v\n", env, err)
 		return cli.Exit("", 1)
 	}
 
@@ -42,7 +42,7 @@ func switchEnvironment(env string, force bool, cm *config.Config, stdin io.ReadC
 
 		_, err := prompt.Run()
 		if err != nil {
-			fmt.Printf("The operation is cancelled.\n")
+			fmt.Fprintf(output, "The operation is cancelled.\n")
 			return cli.Exit("", 1)
 		}
 	}
@@ -77,10 +77,10 @@ func printErrorJSON(err error) {
 	}
 	js, err := marshal[ErrorResponse](errResponse)
 	if err != nil {
-		fmt.Println(err)
+		fmt.Fprintln(jsonOutput, err)
 		return
 	}
-	fmt.Println(string(js))
+	fmt.Fprintln(jsonOutput, string(js))
 }
 
 func printErrors(errs []error, output string, message string) {
@@ -94,10 +94,10 @@ func printErrors(errs []error, output string, message string) {
 			Error: errorList,
 		})
 		if err != nil {
-			fmt.Println(err)
+			fmt.Fprintln(jsonOutput, err)
 			return
 		}
-		fmt.Println(string(js))
+		fmt.Fprintln(jsonOutput, string(js))
 	} else {
 		errorPrinter.Printf("%s: %v\n", message, fmt.Sprint(errs))
 	}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/bruin-data/bruin/pkg/path"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/telemetry"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+func Impact() *cli.Command {
+	return &cli.Command{
+		Name:      "impact",
+		Usage:     "list the assets impacted by the changes since a git ref, i.e. the changed assets and their downstream",
+		ArgsUsage: "[path to the project or pipeline]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "changed-since",
+				Usage:    "the git ref to compare the working tree with, e.g. origin/main",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "the output type, possible values are: plain, json",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			inputPath := c.Args().Get(0)
+			if inputPath == "" {
+				inputPath = "."
+			}
+
+			r := ImpactCommand{
+				infoPrinter:  infoPrinter,
+				errorPrinter: errorPrinter,
+			}
+
+			return r.Run(inputPath, c.String("changed-since"), c.String("output"))
+		},
+		Before: telemetry.BeforeCommand,
+		After:  telemetry.AfterCommand,
+	}
+}
+
+type ImpactCommand struct {
+	infoPrinter  printer
+	errorPrinter printer
+}
+
+type pipelineImpact struct {
+	Pipeline string                    `json:"pipeline"`
+	Path     string                    `json:"path"`
+	Assets   []*pipeline.ImpactedAsset `json:"assets"`
+}
+
+func (r *ImpactCommand) Run(inputPath, changedSince, output string) error {
+	repoRoot, err := git.FindRepoFromPath(inputPath)
+	if err != nil {
+		printError(err, output, "Failed to find the git repository root")
+		return cli.Exit("", 1)
+	}
+
+	changedFiles, err := git.ChangedFiles(repoRoot.Path, changedSince)
+	if err != nil {
+		printError(err, output, "Failed to find the changed files")
+		return cli.Exit("", 1)
+	}
+
+	pipelinePaths, err := path.GetPipelinePaths(inputPath, pipelineDefinitionFiles)
+	if err != nil {
+		printError(err, output, "Failed to find the pipelines")
+		return cli.Exit("", 1)
+	}
+
+	impacts := make([]*pipelineImpact, 0, len(pipelinePaths))
+	for _, pipelinePath := range pipelinePaths {
+		p, err := DefaultPipelineBuilder.CreatePipelineFromPath(pipelinePath, true)
+		if err != nil {
+			printError(err, output, fmt.Sprintf("Failed to build the pipeline at '%s'", pipelinePath))
+			return cli.Exit("", 1)
+		}
+
+		impacted := p.ImpactedAssets(changedFiles)
+		if len(impacted) == 0 {
+			continue
+		}
+
+		relativePath, err := filepath.Rel(repoRoot.Path, pipelinePath)
+		if err != nil {
+			relativePath = pipelinePath
+		}
+
+		impacts = append(impacts, &pipelineImpact{
+			Pipeline: p.Name,
+			Path:     relativePath,
+			Assets:   impacted,
+		})
+	}
+
+	if output == "json" {
+		res, err := json.MarshalIndent(struct {
+			ChangedSince string            `json:"changed_since"`
+			Pipelines    []*pipelineImpact `json:"pipelines"`
+		}{
+			ChangedSince: changedSince,
+			Pipelines:    impacts,
+		}, "", "  ")
+		if err != nil {
+			printError(err, output, "Failed to marshal the impacted assets")
+			return cli.Exit("", 1)
+		}
+
+		fmt.Println(string(res))
+		return nil
+	}
+
+	if len(impacts) == 0 {
+		r.infoPrinter.Printf("No assets are impacted by the changes since '%s'.\n", changedSince)
+		return nil
+	}
+
+	for _, impact := range impacts {
+		r.infoPrinter.Printf("\nPipeline: %s\n", impact.Pipeline)
+		for _, asset := range impact.Assets {
+			fmt.Printf("  - %s (%s)\n", asset.Name, asset.Reason)
+		}
+	}
+
+	return nil
+}
+
+// findImpactedAssets returns the assets of the pipeline that are impacted by the changes since the given git ref.
+func findImpactedAssets(p *pipeline.Pipeline, repoPath, changedSince string) ([]*pipeline.ImpactedAsset, error) {
+	changedFiles, err := git.ChangedFiles(repoPath, changedSince)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find the changed files")
+	}
+
+	return p.ImpactedAssets(changedFiles), nil
+}
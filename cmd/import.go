@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	path2 "path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/connection"
+	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/telemetry"
+	"github.com/fatih/color"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v2"
+)
+
+// sourceAssetTypes maps the connection types to the asset types used to represent existing tables.
+var sourceAssetTypes = map[string]pipeline.AssetType{
+	"google_cloud_platform": pipeline.AssetTypeBigquerySource,
+	"snowflake":             pipeline.AssetTypeSnowflakeSource,
+	"postgres":              pipeline.AssetTypePostgresSource,
+	"redshift":              pipeline.AssetTypeRedshiftSource,
+	"mssql":                 pipeline.AssetTypeMsSQLSource,
+	"synapse":               pipeline.AssetTypeSynapseSource,
+	"databricks":            pipeline.AssetTypeDatabricksSource,
+	"duckdb":                pipeline.AssetTypeDuckDBSource,
+}
+
+func Import(isDebug *bool) *cli.Command {
+	return &cli.Command{
+		Name:  "import",
+		Usage: "generate asset definitions from existing resources",
+		Subcommands: []*cli.Command{
+			ImportDatabase(isDebug),
+		},
+		Before: telemetry.BeforeCommand,
+		After:  telemetry.AfterCommand,
+	}
+}
+
+func ImportDatabase(isDebug *bool) *cli.Command {
+	return &cli.Command{
+		Name:      "database",
+		Usage:     "create source assets for the tables in a database schema",
+		ArgsUsage: "[path to the pipeline]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "connection",
+				Aliases:  []string{"c"},
+				Usage:    "the name of the connection to import the tables from",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "schema",
+				Aliases:  []string{"s"},
+				Usage:    "the schema or dataset to import the tables from",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:    "environment",
+				Aliases: []string{"e", "env"},
+				Usage:   "the environment to use",
+			},
+			&cli.BoolFlag{
+				Name:  "add-checks",
+				Usage: "add not_null and unique checks to the primary key columns",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			defer RecoverFromPanic()
+
+			pipelinePath := c.Args().Get(0)
+			if pipelinePath == "" {
+				errorPrinter.Println("Please provide the path to the pipeline to import the assets into.")
+				return cli.Exit("", 1)
+			}
+
+			logger := makeLogger(*isDebug)
+			fs := afero.NewOsFs()
+
+			foundPipeline, err := DefaultPipelineBuilder.CreatePipelineFromPath(pipelinePath, false)
+			if err != nil {
+				errorPrinter.Printf("Failed to read the pipeline at '%s': %v\n", pipelinePath, err)
+				return cli.Exit("", 1)
+			}
+
+			repoRoot, err := git.FindRepoFromPath(pipelinePath)
+			if err != nil {
+				errorPrinter.Printf("Failed to find the git repository root: %v\n", err)
+				return cli.Exit("", 1)
+			}
+
+			configFilePath := path2.Join(repoRoot.Path, ".bruin.yml")
+			cm, err := config.LoadOrCreate(fs, configFilePath)
+			if err != nil {
+				errorPrinter.Printf("Failed to load the config file at '%s': %v\n", configFilePath, err)
+				return cli.Exit("", 1)
+			}
+
+			err = switchEnvironment(c.String("environment"), false, cm, os.Stdin, color.Output)
+			if err != nil {
+				return err
+			}
+
+			connName := c.String("connection")
+			connType, ok := cm.SelectedEnvironment.Connections.ConnectionsSummaryList()[connName]
+			if !ok {
+				errorPrinter.Printf("Connection '%s' does not exist in the '%s' environment.\n", connName, cm.SelectedEnvironmentName)
+				return cli.Exit("", 1)
+			}
+
+			assetType, ok := sourceAssetTypes[connType]
+			if !ok {
+				errorPrinter.Printf("Importing tables from '%s' connections is not supported.\n", connType)
+				return cli.Exit("", 1)
+			}
+
+			manager, errs := connection.NewManagerFromConfig(cm)
+			if len(errs) > 0 {
+				printErrors(errs, "", "Failed to register connections")
+				return cli.Exit("", 1)
+			}
+
+			conn, err := manager.GetConnection(connName)
+			if err != nil {
+				errorPrinter.Printf("Failed to get the connection '%s': %v\n", connName, err)
+				return cli.Exit("", 1)
+			}
+
+			logger.Debugf("importing the tables in schema '%s' from connection '%s'", c.String("schema"), connName)
+
+			importer := &DatabaseImporter{
+				fs:        fs,
+				assetType: assetType,
+				addChecks: c.Bool("add-checks"),
+			}
+			created, err := importer.Import(c.Context, conn, foundPipeline, c.String("schema"))
+			if err != nil {
+				errorPrinter.Printf("Failed to import the tables: %v\n", err)
+				return cli.Exit("", 1)
+			}
+
+			for _, asset := range created {
+				infoPrinter.Printf("Created asset '%s' at '%s'\n", asset.Name, asset.ExecutableFile.Path)
+			}
+			successPrinter.Printf("\nImported %d tables from schema '%s'.\n", len(created), c.String("schema"))
+
+			return nil
+		},
+	}
+}
+
+// DatabaseImporter creates source assets for the tables in a database schema.
+type DatabaseImporter struct {
+	fs        afero.Fs
+	assetType pipeline.AssetType
+	addChecks bool
+}
+
+// Import lists the tables in the given schema and persists an asset definition for each of them under the "assets"
+// folder of the pipeline. Tables that already have an asset in the pipeline, or whose asset file already exists, are skipped.
+// The asset files are named after the lowercased table names, the import fails if two tables map to the same file.
+func (i *DatabaseImporter) Import(ctx context.Context, conn interface{}, p *pipeline.Pipeline, schemaName string) ([]*pipeline.Asset, error) {
+	lister, ok := conn.(ansisql.TableLister)
+	if !ok {
+		return nil, errors.New("the connection does not support listing tables")
+	}
+
+	schemaFetcher, ok := conn.(ansisql.TableSchemaFetcher)
+	if !ok {
+		return nil, errors.New("the connection does not support fetching table schemas")
+	}
+
+	pkFetcher, _ := conn.(ansisql.PrimaryKeyFetcher)
+
+	tables, err := lister.GetTables(ctx, schemaName)
+	if err != nil {
+		return nil, err
+	}
+
+	assetsFolder := filepath.Join(filepath.Dir(p.DefinitionFile.Path), "assets", schemaName)
+	assetPaths := make(map[string]string, len(tables))
+	tablesByPath := make(map[string]string, len(tables))
+	for _, table := range tables {
+		assetPath := filepath.Join(assetsFolder, strings.ToLower(table)+".asset.yml")
+		if other, ok := tablesByPath[assetPath]; ok {
+			return nil, errors.Errorf("the tables '%s' and '%s' would both be imported into '%s', please rename one of them", other, table, assetPath)
+		}
+
+		tablesByPath[assetPath] = table
+		assetPaths[table] = assetPath
+	}
+
+	created := make([]*pipeline.Asset, 0, len(tables))
+	for _, table := range tables {
+		assetName := fmt.Sprintf("%s.%s", schemaName, table)
+		if p.GetAssetByName(assetName) != nil {
+			continue
+		}
+
+		assetPath := assetPaths[table]
+		exists, err := afero.Exists(i.fs, assetPath)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			continue
+		}
+
+		dbColumns, err := schemaFetcher.GetTableColumns(ctx, assetName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch the columns of table '%s'", assetName)
+		}
+
+		primaryKeys := make([]string, 0)
+		if pkFetcher != nil {
+			primaryKeys, err = pkFetcher.GetPrimaryKeyColumns(ctx, assetName)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to fetch the primary key of table '%s'", assetName)
+			}
+		}
+
+		asset := &pipeline.Asset{
+			Name: assetName,
+			Type: i.assetType,
+			ExecutableFile: pipeline.ExecutableFile{
+				Name: filepath.Base(assetPath),
+				Path: assetPath,
+			},
+			Columns: i.buildColumns(assetName, dbColumns, primaryKeys),
+		}
+
+		if err := i.fs.MkdirAll(assetsFolder, 0o755); err != nil {
+			return nil, errors.Wrapf(err, "failed to create the folder '%s'", assetsFolder)
+		}
+
+		if err := asset.Persist(i.fs); err != nil {
+			return nil, errors.Wrapf(err, "failed to persist the asset '%s'", assetName)
+		}
+
+		created = append(created, asset)
+	}
+
+	return created, nil
+}
+
+func (i *DatabaseImporter) buildColumns(assetName string, dbColumns []*ansisql.DBColumn, primaryKeys []string) []pipeline.Column {
+	isPrimaryKey := make(map[string]bool, len(primaryKeys))
+	for _, pk := range primaryKeys {
+		isPrimaryKey[strings.ToLower(pk)] = true
+	}
+
+	columns := make([]pipeline.Column, 0, len(dbColumns))
+	for _, dbCol := range dbColumns {
+		col := pipeline.Column{
+			Name:        dbCol.Name,
+			Type:        dbCol.Type,
+			Description: dbCol.Description,
+			PrimaryKey:  isPrimaryKey[strings.ToLower(dbCol.Name)],
+		}
+
+		if col.PrimaryKey && i.addChecks {
+			col.Checks = []pipeline.ColumnCheck{
+				pipeline.NewColumnCheck(assetName, col.Name, "not_null", pipeline.ColumnCheckValue{}, nil),
+			}
+
+			// the individual columns of a composite primary key are not unique on their own
+			if len(primaryKeys) == 1 {
+				col.Checks = append(col.Checks, pipeline.NewColumnCheck(assetName, col.Name, "unique", pipeline.ColumnCheckValue{}, nil))
+			}
+		}
+
+		columns = append(columns, col)
+	}
+
+	return columns
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	duck "github.com/bruin-data/bruin/pkg/duckdb"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseImporter_Import(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, err := duck.NewClient(duck.Config{Path: filepath.Join(t.TempDir(), "import.db")})
+	require.NoError(t, err)
+
+	err = client.RunQueryWithoutResult(ctx, &query.Query{Query: `
+CREATE SCHEMA raw;
+CREATE TABLE raw.users (id INTEGER PRIMARY KEY, name VARCHAR, created_at TIMESTAMP);
+COMMENT ON COLUMN raw.users.name IS 'the full name of the user';
+CREATE TABLE raw.events (user_id INTEGER, event_id INTEGER, payload VARCHAR, PRIMARY KEY (user_id, event_id));
+CREATE TABLE raw.existing (id INTEGER);
+`})
+	require.NoError(t, err)
+
+	fs := afero.NewMemMapFs()
+	p := &pipeline.Pipeline{
+		DefinitionFile: pipeline.DefinitionFile{Path: "/project/pipeline/pipeline.yml"},
+		Assets:         []*pipeline.Asset{{Name: "raw.existing"}},
+	}
+
+	importer := &DatabaseImporter{fs: fs, assetType: pipeline.AssetTypeDuckDBSource, addChecks: true}
+	created, err := importer.Import(ctx, client, p, "raw")
+	require.NoError(t, err)
+	require.Len(t, created, 2)
+
+	assert.Equal(t, "raw.events", created[0].Name)
+	assert.Equal(t, "raw.users", created[1].Name)
+
+	users := created[1]
+	assert.Equal(t, pipeline.AssetTypeDuckDBSource, users.Type)
+	assert.Equal(t, "/project/pipeline/assets/raw/users.asset.yml", users.ExecutableFile.Path)
+	require.Len(t, users.Columns, 3)
+	assert.Equal(t, "id", users.Columns[0].Name)
+	assert.Equal(t, "INTEGER", users.Columns[0].Type)
+	assert.True(t, users.Columns[0].PrimaryKey)
+	assert.True(t, users.Columns[0].HasCheck("not_null"))
+	assert.True(t, users.Columns[0].HasCheck("unique"))
+	assert.Equal(t, "the full name of the user", users.Columns[1].Description)
+	assert.Empty(t, users.Columns[1].Checks)
+
+	events := created[0]
+	assert.True(t, events.Columns[0].HasCheck("not_null"))
+	assert.False(t, events.Columns[0].HasCheck("unique"))
+
+	content, err := afero.ReadFile(fs, users.ExecutableFile.Path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "name: raw.users\ntype: duckdb.source\n")
+	assert.Contains(t, string(content), "primary_key: true")
+
+	// running the import again should not overwrite the existing files
+	created, err = importer.Import(ctx, client, p, "raw")
+	require.NoError(t, err)
+	assert.Empty(t, created)
+}
+
+type caseSensitiveTables struct {
+	tables []string
+}
+
+func (c *caseSensitiveTables) GetTables(ctx context.Context, schemaName string) ([]string, error) {
+	return c.tables, nil
+}
+
+func (c *caseSensitiveTables) GetTableColumns(ctx context.Context, tableName string) ([]*ansisql.DBColumn, error) {
+	return []*ansisql.DBColumn{{Name: "id", Type: "integer"}}, nil
+}
+
+func TestDatabaseImporter_Import_CaseSensitiveNames(t *testing.T) {
+	t.Parallel()
+
+	p := &pipeline.Pipeline{
+		DefinitionFile: pipeline.DefinitionFile{Path: "/project/pipeline/pipeline.yml"},
+	}
+
+	fs := afero.NewMemMapFs()
+	importer := &DatabaseImporter{fs: fs, assetType: pipeline.AssetTypePostgresSource}
+	created, err := importer.Import(context.Background(), &caseSensitiveTables{tables: []string{"Customers"}}, p, "raw")
+	require.NoError(t, err)
+	require.Len(t, created, 1)
+	assert.Equal(t, "raw.Customers", created[0].Name)
+	assert.Equal(t, "/project/pipeline/assets/raw/customers.asset.yml", created[0].ExecutableFile.Path)
+
+	fs = afero.NewMemMapFs()
+	importer = &DatabaseImporter{fs: fs, assetType: pipeline.AssetTypePostgresSource}
+	_, err = importer.Import(context.Background(), &caseSensitiveTables{tables: []string{"Customers", "customers"}}, p, "raw")
+	require.EqualError(t, err, "the tables 'Customers' and 'customers' would both be imported into '/project/pipeline/assets/raw/customers.asset.yml', please rename one of them")
+
+	files, err := afero.Glob(fs, "/project/pipeline/assets/raw/*")
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
@@ -113,6 +113,27 @@ func ConnectionSchemas() *cli.Command {
 	}
 }
 
+// newSQLParserForPath creates a SQL parser that caches its results in the repository the given path belongs to.
+func newSQLParserForPath(inputPath string) (*sqlparser.SQLParser, error) {
+	parserConfig := sqlparser.Config{}
+	if repoRoot, err := git.FindRepoFromPath(inputPath); err == nil {
+		parserConfig.CacheDir = sqlParserCacheDir(repoRoot.Path)
+	}
+
+	return sqlparser.NewSQLParserWithConfig(false, parserConfig)
+}
+
+// sqlParserCacheDir returns the SQL parser cache folder of the repository, or an empty string if the folder is not in
+// .gitignore yet. Only `bruin run` adds the folder to .gitignore, the other commands must not modify the repository.
+func sqlParserCacheDir(repoPath string) string {
+	ignored, err := git.IsPatternInGitignore(afero.NewOsFs(), repoPath, sqlparser.CacheFolder)
+	if err != nil || !ignored {
+		return ""
+	}
+
+	return filepath.Join(repoPath, sqlparser.CacheFolder)
+}
+
 type ParseCommand struct {
 	builder      taskCreator
 	errorPrinter *color2.Color
@@ -126,7 +147,7 @@ func (r *ParseCommand) ParsePipeline(assetPath string, lineage bool, slimRespons
 	if lineage {
 		lineageWg.Go(func() {
 			var err error
-			sqlParser, err = sqlparser.NewSQLParser(false)
+			sqlParser, err = newSQLParserForPath(assetPath)
 			if err != nil {
 				printErrorJSON(err)
 				panic(err)
@@ -238,7 +259,7 @@ func (r *ParseCommand) Run(assetPath string, lineage bool) error {
 	if lineage {
 		lineageWg.Go(func() {
 			var err error
-			sqlParser, err = sqlparser.NewSQLParser(false)
+			sqlParser, err = newSQLParserForPath(assetPath)
 			if err != nil {
 				printErrorJSON(err)
 				panic(err)
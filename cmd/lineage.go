@@ -1,17 +1,29 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/connection"
+	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/bruin-data/bruin/pkg/lineage"
 	"github.com/bruin-data/bruin/pkg/path"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/telemetry"
 	"github.com/pkg/errors"
+	"github.com/spf13/afero"
 	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
 )
 
-func Lineage() *cli.Command {
+func Lineage(isDebug *bool) *cli.Command {
 	return &cli.Command{
 		Name:      "lineage",
 		Usage:     "dump the lineage for a given asset",
@@ -26,12 +38,52 @@ func Lineage() *cli.Command {
 				Aliases: []string{"o"},
 				Usage:   "the output type, possible values are: plain, json",
 			},
+			&cli.StringFlag{
+				Name:  "pipeline",
+				Usage: "export the lineage graph of the whole pipeline at the given path instead of a single asset",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "the format of the exported pipeline graph, possible values are: " + strings.Join(lineage.SupportedFormats, ", "),
+				Value: lineage.FormatDOT,
+			},
+			&cli.StringFlag{
+				Name:  "tag",
+				Usage: "only include the assets with the given tag in the pipeline graph",
+			},
+			&cli.StringFlag{
+				Name:  "asset",
+				Usage: "only include the given asset and its upstream and downstream assets in the pipeline graph",
+			},
+			&cli.IntFlag{
+				Name:  "depth",
+				Usage: "the maximum number of hops from the asset given with --asset, 0 means no limit",
+			},
+			&cli.BoolFlag{
+				Name:  "include-external",
+				Usage: "include the external uri dependencies in the pipeline graph",
+			},
+			&cli.BoolFlag{
+				Name:  "column-lineage",
+				Usage: "include the columns and the column-level dependencies in the pipeline graph",
+			},
 		},
 		Action: func(c *cli.Context) error {
 			r := LineageCommand{
 				builder:      DefaultPipelineBuilder,
 				infoPrinter:  infoPrinter,
 				errorPrinter: errorPrinter,
+				logger:       makeLogger(*isDebug),
+			}
+
+			if c.IsSet("pipeline") {
+				return r.ExportPipeline(c.String("pipeline"), c.String("format"), lineage.Options{
+					Tag:             c.String("tag"),
+					Asset:           c.String("asset"),
+					Depth:           c.Int("depth"),
+					IncludeExternal: c.Bool("include-external"),
+					IncludeColumns:  c.Bool("column-lineage"),
+				})
 			}
 
 			return r.Run(c.Args().Get(0), c.Bool("full"), c.String("output"))
@@ -51,6 +103,7 @@ type LineageCommand struct {
 	builder      taskCreator
 	infoPrinter  printer
 	errorPrinter printer
+	logger       *zap.SugaredLogger
 }
 
 func (r *LineageCommand) Run(assetPath string, fullLineage bool, output string) error {
@@ -107,6 +160,139 @@ func (r *LineageCommand) Run(assetPath string, fullLineage bool, output string)
 	return err
 }
 
+// ExportPipeline prints the lineage graph of the whole pipeline in the given format.
+func (r *LineageCommand) ExportPipeline(pipelinePath, format string, opts lineage.Options) error {
+	if !slices.Contains(lineage.SupportedFormats, format) {
+		r.errorPrinter.Printf("Unsupported format '%s', supported formats are: %s\n", format, strings.Join(lineage.SupportedFormats, ", "))
+		return cli.Exit("", 1)
+	}
+
+	foundPipeline, err := DefaultPipelineBuilder.CreatePipelineFromPath(pipelinePath, false)
+	if err != nil {
+		r.errorPrinter.Printf("Failed to build the pipeline at '%s': %v\n", pipelinePath, err)
+		return cli.Exit("", 1)
+	}
+
+	if opts.IncludeColumns {
+		parser, err := newSQLParserForPath(pipelinePath)
+		if err != nil {
+			r.errorPrinter.Printf("Failed to initialize the SQL parser: %v\n", err)
+			return cli.Exit("", 1)
+		}
+		defer parser.Close()
+
+		processedAssets := make(map[string]bool)
+		extractor := pipeline.NewLineageExtractor(parser)
+		fetcher, err := newConnectionSourceSchemaFetcher(pipelinePath, r.logger)
+		switch {
+		case err != nil:
+			r.logger.Debugf("The source tables of the ingestr assets are not introspected: %v", err)
+		case fetcher != nil:
+			extractor = extractor.WithSourceSchemaFetcher(fetcher)
+		}
+		for _, asset := range foundPipeline.Assets {
+			if err := extractor.ColumnLineage(foundPipeline, asset, processedAssets); err != nil {
+				r.errorPrinter.Printf("Failed to extract the column lineage: %v\n", err)
+				return cli.Exit("", 1)
+			}
+		}
+	}
+
+	graph, err := lineage.NewGraph(foundPipeline, opts)
+	if err != nil {
+		r.errorPrinter.Printf("Failed to build the lineage graph: %v\n", err)
+		return cli.Exit("", 1)
+	}
+
+	output, err := graph.Render(format)
+	if err != nil {
+		r.errorPrinter.Printf("Failed to render the lineage graph: %v\n", err)
+		return cli.Exit("", 1)
+	}
+
+	fmt.Println(output)
+	return nil
+}
+
+// sourceSchemaFetchTimeout is the time allowed to introspect a single source table, so that an unreachable connection
+// does not block the lineage export.
+const sourceSchemaFetchTimeout = 30 * time.Second
+
+// connectionSourceSchemaFetcher introspects the source tables of the ingestr assets using the connections in the
+// project config.
+type connectionSourceSchemaFetcher struct {
+	manager *connection.Manager
+	logger  *zap.SugaredLogger
+}
+
+// newConnectionSourceSchemaFetcher returns nil if the project has no config file, the lineage export is read-only so
+// the config file is not created.
+func newConnectionSourceSchemaFetcher(inputPath string, logger *zap.SugaredLogger) (*connectionSourceSchemaFetcher, error) {
+	repoRoot, err := git.FindRepoFromPath(inputPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find the git repository root")
+	}
+
+	configFilePath := filepath.Join(repoRoot.Path, ".bruin.yml")
+	fs := afero.NewOsFs()
+	if exists, err := afero.Exists(fs, configFilePath); err != nil || !exists {
+		logger.Debugf("There is no config file at '%s', the source tables of the ingestr assets are not introspected", configFilePath)
+		return nil, nil //nolint:nilerr
+	}
+
+	cm, err := config.LoadFromFile(fs, configFilePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load the config")
+	}
+
+	manager, errs := connection.NewManagerFromConfig(cm)
+	if len(errs) > 0 {
+		return nil, errors.Wrap(errs[0], "failed to create connection manager")
+	}
+
+	return &connectionSourceSchemaFetcher{manager: manager, logger: logger}, nil
+}
+
+func (f *connectionSourceSchemaFetcher) GetSourceColumns(ctx context.Context, connectionName, tableName string) ([]pipeline.Column, error) {
+	columns, err := f.getSourceColumns(ctx, connectionName, tableName)
+	if err != nil {
+		f.logger.Debugf("Failed to introspect the source table '%s' in the connection '%s': %v", tableName, connectionName, err)
+	}
+
+	return columns, err
+}
+
+func (f *connectionSourceSchemaFetcher) getSourceColumns(ctx context.Context, connectionName, tableName string) ([]pipeline.Column, error) {
+	conn, err := f.manager.GetConnection(connectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	fetcher, ok := conn.(ansisql.TableSchemaFetcher)
+	if !ok {
+		return nil, errors.Errorf("connection '%s' does not support fetching table schemas", connectionName)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sourceSchemaFetchTimeout)
+	defer cancel()
+
+	dbColumns, err := fetcher.GetTableColumns(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]pipeline.Column, 0, len(dbColumns))
+	for _, column := range dbColumns {
+		columns = append(columns, pipeline.Column{
+			Name:        column.Name,
+			Type:        column.Type,
+			Description: column.Description,
+		})
+	}
+
+	return columns, nil
+}
+
 func (r *LineageCommand) printLineageJSON(asset *pipeline.Asset, upstream, downstream []*pipeline.Asset) error {
 	type dependencySummary struct {
 		Name           string                       `json:"name"`
@@ -4,6 +4,8 @@ package cmd
 import (
 	"bytes"
 	"fmt"
+	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"sync"
@@ -13,6 +15,8 @@ import (
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 )
 
 type mockPrinter struct {
@@ -147,3 +151,14 @@ Asset has no downstream dependencies.
 		})
 	}
 }
+
+func TestNewConnectionSourceSchemaFetcher_DoesNotCreateTheConfig(t *testing.T) {
+	repo := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(repo, ".git"), 0o755))
+
+	fetcher, err := newConnectionSourceSchemaFetcher(repo, zap.NewNop().Sugar())
+	require.NoError(t, err)
+	assert.Nil(t, fetcher)
+	assert.NoFileExists(t, filepath.Join(repo, ".bruin.yml"))
+	assert.NoFileExists(t, filepath.Join(repo, ".gitignore"))
+}
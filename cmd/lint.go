@@ -49,6 +49,19 @@ func Lint(isDebug *bool) *cli.Command {
 				Name:  "exclude-warnings",
 				Usage: "exclude warning validations from the output",
 			},
+			&cli.BoolFlag{
+				Name:  "check-schema",
+				Usage: "compare the columns defined in the assets with the tables in the warehouse and report any drift",
+			},
+			&cli.StringFlag{
+				Name:  "changed-since",
+				Usage: "only report the issues of the assets whose files changed since the given git ref, e.g. origin/main, along with their downstream",
+			},
+			&cli.IntFlag{
+				Name:        "sql-parser-workers",
+				Usage:       "the maximum number of SQL parser processes to run in parallel",
+				DefaultText: "number of CPUs, up to 4",
+			},
 		},
 		Action: func(c *cli.Context) error {
 			// if the output is JSON then we intend to discard all the nicer pretty-print statements
@@ -85,6 +98,21 @@ func Lint(isDebug *bool) *cli.Command {
 			}
 			logger.Debugf("found repo root '%s'", repoRoot.Path)
 
+			var changedFiles []string
+			if changedSince := c.String("changed-since"); changedSince != "" {
+				if asset != "" {
+					printError(errors.New("you cannot use the '--changed-since' flag when validating a single asset"), c.String("output"), "Invalid flags")
+					return cli.Exit("", 1)
+				}
+
+				changedFiles, err = git.ChangedFiles(repoRoot.Path, changedSince)
+				if err != nil {
+					printError(err, c.String("output"), fmt.Sprintf("Failed to find the files changed since '%s'", changedSince))
+					return cli.Exit("", 1)
+				}
+				logger.Debugf("found %d files changed since '%s'", len(changedFiles), changedSince)
+			}
+
 			configFilePath := path2.Join(repoRoot.Path, ".bruin.yml")
 			cm, err := config.LoadOrCreate(afero.NewOsFs(), configFilePath)
 			if err != nil {
@@ -94,7 +122,7 @@ func Lint(isDebug *bool) *cli.Command {
 
 			logger.Debugf("loaded the config from path '%s'", configFilePath)
 
-			err = switchEnvironment(c.String("environment"), c.Bool("force"), cm, os.Stdin)
+			err = switchEnvironment(c.String("environment"), c.Bool("force"), cm, os.Stdin, color.Output)
 			if err != nil {
 				return err
 			}
@@ -109,12 +137,30 @@ func Lint(isDebug *bool) *cli.Command {
 
 			logger.Debugf("built the connection manager instance")
 
-			parser, err := sqlparser.NewSQLParser(false)
+			// validating must not modify the repository, the parser cache is only used if it is already ignored
+			parserConfig := sqlparser.Config{Workers: c.Int("sql-parser-workers"), CacheDir: sqlParserCacheDir(repoRoot.Path)}
+			if parserConfig.CacheDir == "" {
+				logger.Debugf("the SQL parser cache folder is not in .gitignore, the queries will be parsed without the cache")
+			}
+
+			parser, err := sqlparser.NewSQLParserWithConfig(false, parserConfig)
 			if err != nil {
 				printError(err, c.String("output"), "Could not initialize sql parser")
 			}
+			if parser != nil {
+				defer func() {
+					logger.Debugf("sql parser stats: %s", parser.Stats())
+					_ = parser.Close()
+				}()
+			}
 
-			rules, err := lint.GetRules(fs, &git.RepoFinder{}, c.Bool("exclude-warnings"), parser, true)
+			macros, err := jinja.LoadMacroLibrary(path2.Join(repoRoot.Path, jinja.MacrosFolder))
+			if err != nil {
+				printError(err, c.String("output"), "Failed to load the macros")
+				return cli.Exit("", 1)
+			}
+
+			rules, err := lint.GetRules(fs, &git.RepoFinder{}, c.Bool("exclude-warnings"), parser, macros, true)
 			if err != nil {
 				printError(err, c.String("output"), "An error occurred while building the validation rules")
 
@@ -123,7 +169,7 @@ func Lint(isDebug *bool) *cli.Command {
 
 			logger.Debugf("successfully loaded %d rules", len(rules))
 
-			renderer := jinja.NewRendererWithYesterday("your-pipeline-name", "your-run-id")
+			renderer := jinja.NewRendererWithYesterday("your-pipeline-name", "your-run-id").WithMacros(macros)
 
 			if len(cm.SelectedEnvironment.Connections.GoogleCloudPlatform) > 0 {
 				rules = append(rules, &lint.QueryValidatorRule{
@@ -158,6 +204,14 @@ func Lint(isDebug *bool) *cli.Command {
 				logger.Debug("no Snowflake connections found, skipping Snowflake validation")
 			}
 
+			if c.Bool("check-schema") {
+				rules = append(rules, &lint.SchemaDriftRule{
+					Identifier:  "schema-drift",
+					Connections: connectionManager,
+					Logger:      logger,
+				})
+			}
+
 			var result *lint.PipelineAnalysisResult
 			var errr error
 			if asset == "" {
@@ -172,6 +226,16 @@ func Lint(isDebug *bool) *cli.Command {
 				result, errr = linter.LintAsset(rootPath, pipelineDefinitionFiles, asset, c)
 			}
 
+			if errr == nil && result != nil && changedFiles != nil {
+				for _, pipelineIssues := range result.Pipelines {
+					impacted := make(map[*pipeline.Asset]bool)
+					for _, impactedAsset := range pipelineIssues.Pipeline.ImpactedAssets(changedFiles) {
+						impacted[impactedAsset.Asset] = true
+					}
+					pipelineIssues.KeepAssets(impacted)
+				}
+			}
+
 			printer := lint.Printer{RootCheckPath: rootPath}
 
 			if errr != nil || result == nil {
@@ -188,7 +252,7 @@ func Lint(isDebug *bool) *cli.Command {
 				return nil
 			}
 
-			err = reportLintErrors(result, err, printer, asset)
+			err = reportLintErrors(result, err, printer, asset, color.Output)
 			if err != nil {
 				printError(err, c.String("output"), "An error occurred")
 				return cli.Exit("", 1)
@@ -200,13 +264,13 @@ func Lint(isDebug *bool) *cli.Command {
 	}
 }
 
-func reportLintErrors(result *lint.PipelineAnalysisResult, err error, printer lint.Printer, asset string) error {
+func reportLintErrors(result *lint.PipelineAnalysisResult, err error, printer lint.Printer, asset string, output io.Writer) error {
 	if err != nil {
-		errorPrinter.Println("\nAn error occurred while linting asset:")
+		errorPrinter.Fprintln(output, "\nAn error occurred while linting asset:")
 
 		errorList := unwrapAllErrors(err)
 		for i, e := range errorList {
-			errorPrinter.Printf("%s└── %s\n", strings.Repeat("  ", i), e)
+			errorPrinter.Fprintf(output, "%s└── %s\n", strings.Repeat("  ", i), e)
 		}
 
 		return err
@@ -249,9 +313,9 @@ func reportLintErrors(result *lint.PipelineAnalysisResult, err error, printer li
 		}
 
 		if asset == "" {
-			infoPrinter.Printf("\n✘ Checked %d %s and %s, please check above.\n", pipelineCount, pipelineStr, foundMessage)
+			infoPrinter.Fprintf(output, "\n✘ Checked %d %s and %s, please check above.\n", pipelineCount, pipelineStr, foundMessage)
 		} else {
-			infoPrinter.Printf("\n✘ Checked '%s' and found %s, please check above.\n", asset, foundMessage)
+			infoPrinter.Fprintf(output, "\n✘ Checked '%s' and found %s, please check above.\n", asset, foundMessage)
 		}
 
 		if errorCount > 0 {
@@ -267,7 +331,7 @@ func reportLintErrors(result *lint.PipelineAnalysisResult, err error, printer li
 		taskCount += len(p.Pipeline.Assets)
 	}
 
-	successPrinter.Printf("\n✓ Successfully validated %d assets across %d %s, all good.\n", taskCount, pipelineCount, pipelineStr)
+	successPrinter.Fprintf(output, "\n✓ Successfully validated %d assets across %d %s, all good.\n", taskCount, pipelineCount, pipelineStr)
 	return nil
 }
 
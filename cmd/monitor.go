@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	path2 "path"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/config"
+	"github.com/bruin-data/bruin/pkg/connection"
+	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/telemetry"
+	"github.com/fatih/color"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v2"
+)
+
+func Monitor() *cli.Command {
+	return &cli.Command{
+		Name:  "monitor",
+		Usage: "monitor the state of the assets without running them",
+		Subcommands: []*cli.Command{
+			MonitorFreshness(),
+		},
+		Before: telemetry.BeforeCommand,
+		After:  telemetry.AfterCommand,
+	}
+}
+
+func MonitorFreshness() *cli.Command {
+	return &cli.Command{
+		Name:      "freshness",
+		Usage:     "evaluate the freshness checks of all the assets in a pipeline",
+		ArgsUsage: "[path to the pipeline]",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "environment",
+				Aliases: []string{"e", "env"},
+				Usage:   "the environment to use",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "the output type, possible values are: json, plain",
+				Value:   "json",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			defer RecoverFromPanic()
+
+			output := c.String("output")
+			pipelinePath := c.Args().Get(0)
+			if pipelinePath == "" {
+				printErrorForOutput(output, errors.New("please provide the path to the pipeline"))
+				return cli.Exit("", 1)
+			}
+
+			foundPipeline, err := DefaultPipelineBuilder.CreatePipelineFromPath(pipelinePath, true)
+			if err != nil {
+				printError(err, output, fmt.Sprintf("Failed to build the pipeline at '%s'", pipelinePath))
+				return cli.Exit("", 1)
+			}
+
+			repoRoot, err := git.FindRepoFromPath(pipelinePath)
+			if err != nil {
+				printError(err, output, "Failed to find the git repository root")
+				return cli.Exit("", 1)
+			}
+
+			configFilePath := path2.Join(repoRoot.Path, ".bruin.yml")
+			cm, err := config.LoadOrCreate(afero.NewOsFs(), configFilePath)
+			if err != nil {
+				printError(err, output, fmt.Sprintf("Failed to load the config file at '%s'", configFilePath))
+				return cli.Exit("", 1)
+			}
+
+			// the monitor only reads from the environment, so it does not ask for a confirmation for the production ones
+			err = switchEnvironment(c.String("environment"), true, cm, os.Stdin, color.Output)
+			if err != nil {
+				return err
+			}
+
+			manager, errs := connection.NewManagerFromConfig(cm)
+			if len(errs) > 0 {
+				printErrors(errs, output, "Failed to register connections")
+				return cli.Exit("", 1)
+			}
+
+			monitor := &FreshnessMonitor{evaluator: ansisql.NewFreshnessEvaluator(manager)}
+			results := monitor.Evaluate(c.Context, foundPipeline)
+
+			failed := false
+			for _, result := range results {
+				if result.Status == pipeline.FreshnessStatusError {
+					failed = true
+				}
+			}
+
+			if output == "json" {
+				res, err := json.MarshalIndent(struct {
+					Pipeline  string                     `json:"pipeline"`
+					CheckedAt time.Time                  `json:"checked_at"`
+					Assets    []*ansisql.FreshnessResult `json:"assets"`
+				}{
+					Pipeline:  foundPipeline.Name,
+					CheckedAt: time.Now().UTC(),
+					Assets:    results,
+				}, "", "  ")
+				if err != nil {
+					printError(err, output, "Failed to marshal the freshness results")
+					return cli.Exit("", 1)
+				}
+
+				fmt.Println(string(res))
+			} else {
+				printFreshnessResults(results)
+			}
+
+			if failed {
+				return cli.Exit("", 1)
+			}
+
+			return nil
+		},
+	}
+}
+
+// FreshnessMonitor evaluates the freshness checks of the assets in a pipeline without running them.
+type FreshnessMonitor struct {
+	evaluator *ansisql.FreshnessEvaluator
+}
+
+// Evaluate returns a result for every asset with a freshness check, the assets whose check cannot be evaluated are
+// reported with the error status.
+func (m *FreshnessMonitor) Evaluate(ctx context.Context, p *pipeline.Pipeline) []*ansisql.FreshnessResult {
+	results := make([]*ansisql.FreshnessResult, 0)
+	for _, asset := range p.Assets {
+		if asset.Freshness == nil {
+			continue
+		}
+
+		result, err := m.evaluator.Evaluate(ctx, p, asset)
+		if err != nil {
+			result = &ansisql.FreshnessResult{
+				Asset:      asset.Name,
+				Column:     asset.Freshness.Column,
+				WarnAfter:  asset.Freshness.WarnAfter,
+				ErrorAfter: asset.Freshness.ErrorAfter,
+				Status:     pipeline.FreshnessStatusError,
+				Error:      err.Error(),
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func printFreshnessResults(results []*ansisql.FreshnessResult) {
+	if len(results) == 0 {
+		infoPrinter.Println("No assets with a freshness check found.")
+		return
+	}
+
+	for _, result := range results {
+		details := result.Error
+		if details == "" {
+			details = fmt.Sprintf("latest value is %s old", result.Age)
+		}
+
+		switch result.Status {
+		case pipeline.FreshnessStatusFresh:
+			successPrinter.Printf("  ✓ %s: %s\n", result.Asset, details)
+		case pipeline.FreshnessStatusWarn:
+			warningPrinter.Printf("  ! %s: %s\n", result.Asset, details)
+		default:
+			errorPrinter.Printf("  ✘ %s: %s\n", result.Asset, details)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	duck "github.com/bruin-data/bruin/pkg/duckdb"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticConnections map[string]interface{}
+
+func (s staticConnections) GetConnection(name string) (interface{}, error) {
+	return s[name], nil
+}
+
+func TestFreshnessMonitor_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, err := duck.NewClient(duck.Config{Path: filepath.Join(t.TempDir(), "freshness.db")})
+	require.NoError(t, err)
+
+	err = client.RunQueryWithoutResult(ctx, &query.Query{Query: `
+CREATE SCHEMA raw;
+CREATE TABLE raw.orders AS SELECT now()::TIMESTAMP - INTERVAL 1 HOUR AS updated_at;
+CREATE TABLE raw.users AS SELECT now()::TIMESTAMP - INTERVAL 3 DAY AS updated_at;
+`})
+	require.NoError(t, err)
+
+	check := &pipeline.FreshnessCheck{Column: "updated_at", WarnAfter: "12h", ErrorAfter: "1d"}
+	p := &pipeline.Pipeline{
+		DefaultConnections: map[string]string{"duckdb": "duck"},
+		Assets: []*pipeline.Asset{
+			{Name: "raw.orders", Type: pipeline.AssetTypeDuckDBSource, Freshness: check},
+			{Name: "raw.events", Type: pipeline.AssetTypeDuckDBSource},
+			{Name: "raw.users", Type: pipeline.AssetTypeDuckDBSource, Freshness: check},
+			{Name: "raw.missing", Type: pipeline.AssetTypeDuckDBSource, Freshness: check},
+		},
+	}
+
+	monitor := &FreshnessMonitor{evaluator: ansisql.NewFreshnessEvaluator(staticConnections{"duck": client})}
+	results := monitor.Evaluate(ctx, p)
+	require.Len(t, results, 3)
+
+	assert.Equal(t, "raw.orders", results[0].Asset)
+	assert.Equal(t, pipeline.FreshnessStatusFresh, results[0].Status)
+	assert.NotNil(t, results[0].LatestAt)
+
+	assert.Equal(t, "raw.users", results[1].Asset)
+	assert.Equal(t, pipeline.FreshnessStatusError, results[1].Status)
+	assert.Empty(t, results[1].Error)
+
+	assert.Equal(t, "raw.missing", results[2].Asset)
+	assert.Equal(t, pipeline.FreshnessStatusError, results[2].Status)
+	assert.NotEmpty(t, results[2].Error)
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -134,6 +135,12 @@ func Render() *cli.Command {
 				return cli.Exit("", 1)
 			}
 
+			macros, err := loadMacrosForPath(inputPath)
+			if err != nil {
+				printError(err, c.String("output"), "Failed to load the macros:")
+				return cli.Exit("", 1)
+			}
+
 			resultsLocation := "s3://{destination-bucket}"
 			if asset.Type == pipeline.AssetTypeAthenaQuery {
 				connName, err := pl.GetConnectionNameForAsset(asset)
@@ -165,7 +172,7 @@ func Render() *cli.Command {
 			r := RenderCommand{
 				extractor: &query.WholeFileExtractor{
 					Fs:       fs,
-					Renderer: jinja.NewRendererWithStartEndDates(&startDate, &endDate, "your-pipeline-name", "your-run-id"),
+					Renderer: jinja.NewRendererWithStartEndDates(&startDate, &endDate, "your-pipeline-name", "your-run-id").WithMacros(macros),
 				},
 				materializers: map[pipeline.AssetType]queryMaterializer{
 					pipeline.AssetTypeBigqueryQuery:   bigquery.NewMaterializer(fullRefresh),
@@ -193,6 +200,7 @@ func Render() *cli.Command {
 
 type queryExtractor interface {
 	ExtractQueriesFromString(content string) ([]*query.Query, error)
+	CloneForAsset(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) query.QueryExtractor
 }
 
 type queryMaterializer interface {
@@ -231,7 +239,7 @@ func (r *RenderCommand) Run(taskPath string, foundPipeline *pipeline.Pipeline) e
 		return cli.Exit("", 1)
 	}
 
-	queries, err := r.extractor.ExtractQueriesFromString(task.ExecutableFile.Content)
+	queries, err := r.extractor.CloneForAsset(context.Background(), foundPipeline, task).ExtractQueriesFromString(task.ExecutableFile.Content)
 	if err != nil {
 		r.printErrorOrJSON(err.Error())
 		return cli.Exit("", 1)
@@ -313,6 +321,17 @@ func (r *RenderCommand) printErrorOrJsonf(msg string, args ...interface{}) {
 	r.printErrorOrJSON(fmt.Sprintf(msg, args...))
 }
 
+// loadMacrosForPath loads the macros folder at the root of the repository the given path belongs to. The paths outside
+// of a git repository have no macro library, so they are rendered without any macros.
+func loadMacrosForPath(inputPath string) (*jinja.MacroLibrary, error) {
+	repoRoot, err := git.FindRepoFromPath(inputPath)
+	if err != nil {
+		return nil, nil //nolint:nilerr
+	}
+
+	return jinja.LoadMacroLibrary(filepath.Join(repoRoot.Path, jinja.MacrosFolder))
+}
+
 func getPipelineDefinitionFullPath(pipelinePath string) (string, error) {
 	for _, pipelineDefinitionfile := range pipelineDefinitionFiles {
 		fullPath := filepath.Join(pipelinePath, pipelineDefinitionfile)
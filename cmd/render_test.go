@@ -1,12 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"testing"
 
+	"github.com/bruin-data/bruin/pkg/git"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/query"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type mockBuilder struct {
@@ -35,6 +38,10 @@ func (m *mockExtractor) ExtractQueriesFromString(content string) ([]*query.Query
 	return res.Get(0).([]*query.Query), res.Error(1)
 }
 
+func (m *mockExtractor) CloneForAsset(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) query.QueryExtractor {
+	return m
+}
+
 type mockMaterializer struct {
 	mock.Mock
 }
@@ -217,3 +224,16 @@ func TestRenderCommand_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadMacrosForPath_OutsideOfAGitRepository(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if _, err := git.FindRepoFromPath(dir); err == nil {
+		t.Skip("the temporary folder is inside a git repository")
+	}
+
+	macros, err := loadMacrosForPath(dir)
+	require.NoError(t, err)
+	assert.Nil(t, macros)
+}
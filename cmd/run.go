@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
@@ -39,7 +40,6 @@ import (
 	"github.com/bruin-data/bruin/pkg/sqlparser"
 	"github.com/bruin-data/bruin/pkg/synapse"
 	"github.com/bruin-data/bruin/pkg/telemetry"
-	"github.com/fatih/color"
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 	"github.com/urfave/cli/v2"
@@ -47,7 +47,10 @@ import (
 	"go.uber.org/zap"
 )
 
-const LogsFolder = "logs"
+const (
+	LogsFolder    = "logs"
+	MetricsFolder = "logs/metrics"
+)
 
 type PipelineInfo struct {
 	Pipeline           *pipeline.Pipeline
@@ -138,6 +141,11 @@ func Run(isDebug *bool) *cli.Command {
 				DefaultText: "'main', 'checks', 'push-metadata'",
 				Usage:       "limit the types of tasks to run. By default it will run main and checks, while push-metadata is optional if defined in the pipeline definition",
 			},
+			&cli.GenericFlag{
+				Name:  "var",
+				Usage: "override a pipeline variable, e.g. --var segment=smb, the value is parsed as JSON for non-string variables",
+				Value: &variableFlag{},
+			},
 			&cli.BoolFlag{
 				Name:  "exp-use-winget-for-uv",
 				Usage: "use powershell to manage and install uv on windows, on non-windows systems this has no effect.",
@@ -146,6 +154,31 @@ func Run(isDebug *bool) *cli.Command {
 				Name:  "debug-ingestr-src",
 				Usage: "Use ingestr from the given path instead of the builtin version.",
 			},
+			&cli.BoolFlag{
+				Name:  "check-schema",
+				Usage: "after the run, compare the columns defined in the succeeded assets with the tables in the warehouse and fail on any drift",
+			},
+			&cli.BoolFlag{
+				Name:  "smart",
+				Usage: "skip the assets whose definition, query, upstream and run parameters did not change since their last successful run",
+			},
+			&cli.StringFlag{
+				Name:  "changed-since",
+				Usage: "only run the assets whose files changed since the given git ref, e.g. origin/main, along with their downstream",
+			},
+			&cli.BoolFlag{
+				Name:  "strict",
+				Usage: "fail the run when a non-blocking check fails, instead of only reporting it as a warning",
+			},
+			&cli.Int64Flag{
+				Name:  "sample-failing-rows",
+				Usage: "fetch up to the given number of the rows failing the not_null, unique, accepted_values and pattern checks and show them in the results",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "the output type for the summary of the run, possible values are: plain, json",
+			},
 		},
 		Action: func(c *cli.Context) error {
 			defer func() {
@@ -157,6 +190,12 @@ func Run(isDebug *bool) *cli.Command {
 				}
 			}()
 
+			// with the JSON output, the logs go to stderr so that stdout only has the JSON summary of the run
+			var output io.Writer = os.Stdout
+			if c.String("output") == "json" {
+				output = os.Stderr
+			}
+
 			logger := makeLogger(*isDebug)
 			// Initialize runConfig with values from cli.Context
 			runConfig := &scheduler.RunConfig{
@@ -175,24 +214,35 @@ func Run(isDebug *bool) *cli.Command {
 				Only:              c.StringSlice("only"),
 				Output:            c.String("output"),
 				ExpUseWingetForUv: c.Bool("exp-use-winget-for-uv"),
+				CheckSchema:       c.Bool("check-schema"),
+				ChangedSince:      c.String("changed-since"),
+				Smart:             c.Bool("smart"),
+				Strict:            c.Bool("strict"),
+				SampleFailingRows: c.Int64("sample-failing-rows"),
+			}
+
+			variables, err := parseVariableOverrides(c.Generic("var").(*variableFlag).values)
+			if err != nil {
+				errorPrinter.Fprintf(output, "Failed to parse the variables: %v\n", err)
+				return cli.Exit("", 1)
 			}
+			runConfig.Variables = variables
 
 			var startDate, endDate time.Time
 
-			var err error
-			startDate, endDate, inputPath, err := ValidateRunConfig(runConfig, c.Args().Get(0), logger)
+			startDate, endDate, inputPath, err := ValidateRunConfig(runConfig, c.Args().Get(0), logger, output)
 			if err != nil {
 				return err
 			}
 
-			pipelineInfo, err := GetPipeline(inputPath, runConfig, logger)
+			pipelineInfo, err := GetPipeline(inputPath, runConfig, logger, output)
 			if err != nil {
 				return err
 			}
 
 			repoRoot, err := git.FindRepoFromPath(inputPath)
 			if err != nil {
-				errorPrinter.Printf("Failed to find the git repository root: %v\n", err)
+				errorPrinter.Fprintf(output, "Failed to find the git repository root: %v\n", err)
 				return cli.Exit("", 1)
 			}
 
@@ -200,12 +250,12 @@ func Run(isDebug *bool) *cli.Command {
 			if pipelineInfo.RunningForAnAsset {
 				task, err = DefaultPipelineBuilder.CreateAssetFromFile(inputPath, pipelineInfo.Pipeline)
 				if err != nil {
-					errorPrinter.Printf("Failed to build asset: %v\n", err)
+					errorPrinter.Fprintf(output, "Failed to build asset: %v\n", err)
 					return cli.Exit("", 1)
 				}
 				task, err = DefaultPipelineBuilder.MutateAsset(task, nil)
 				if err != nil {
-					errorPrinter.Printf("Failed to mutate asset: %v\n", err)
+					errorPrinter.Fprintf(output, "Failed to mutate asset: %v\n", err)
 					return cli.Exit("", 1)
 				}
 			}
@@ -213,20 +263,33 @@ func Run(isDebug *bool) *cli.Command {
 			// handle log files
 			runID := time.Now().Format("2006_01_02_15_04_05")
 
-			infoPrinter.Printf("Analyzed the pipeline '%s' with %d assets.\n", pipelineInfo.Pipeline.Name, len(pipelineInfo.Pipeline.Assets))
+			infoPrinter.Fprintf(output, "Analyzed the pipeline '%s' with %d assets.\n", pipelineInfo.Pipeline.Name, len(pipelineInfo.Pipeline.Assets))
 
 			if pipelineInfo.RunningForAnAsset {
-				infoPrinter.Printf("Running only the asset '%s'\n", task.Name)
+				infoPrinter.Fprintf(output, "Running only the asset '%s'\n", task.Name)
+			}
+
+			macros, err := jinja.LoadMacroLibrary(filepath.Join(repoRoot.Path, jinja.MacrosFolder))
+			if err != nil {
+				errorPrinter.Fprintf(output, "Failed to load the macros: %v\n", err)
+				return cli.Exit("", 1)
 			}
 
-			if err := CheckLint(pipelineInfo.Pipeline, inputPath, logger, nil); err != nil {
+			if err := CheckLint(c.Context, pipelineInfo.Pipeline, inputPath, logger, nil, macros, output); err != nil {
 				return err
 			}
 
 			statePath := filepath.Join(repoRoot.Path, "logs/runs", pipelineInfo.Pipeline.Name)
 			err = git.EnsureGivenPatternIsInGitignore(afero.NewOsFs(), repoRoot.Path, "logs/runs")
 			if err != nil {
-				errorPrinter.Printf("Failed to add the run state folder to .gitignore: %v\n", err)
+				errorPrinter.Fprintf(output, "Failed to add the run state folder to .gitignore: %v\n", err)
+				return cli.Exit("", 1)
+			}
+
+			// the read-only commands only use the SQL parser cache once it is ignored, so the runs take care of that
+			err = git.EnsureGivenPatternIsInGitignore(afero.NewOsFs(), repoRoot.Path, sqlparser.CacheFolder)
+			if err != nil {
+				errorPrinter.Fprintf(output, "Failed to add the SQL parser cache folder to .gitignore: %v\n", err)
 				return cli.Exit("", 1)
 			}
 
@@ -237,18 +300,35 @@ func Run(isDebug *bool) *cli.Command {
 				PushMetaData:      runConfig.PushMetadata,
 				SingleTask:        task,
 				ExcludeTag:        runConfig.ExcludeTag,
+				ChangedSince:      runConfig.ChangedSince,
+			}
+			if runConfig.ChangedSince != "" {
+				impacted, err := findImpactedAssets(pipelineInfo.Pipeline, repoRoot.Path, runConfig.ChangedSince)
+				if err != nil {
+					errorPrinter.Fprintf(output, "Failed to find the assets changed since '%s': %v\n", runConfig.ChangedSince, err)
+					return cli.Exit("", 1)
+				}
+
+				for _, asset := range impacted {
+					if asset.Reason == pipeline.ImpactReasonChanged {
+						filter.ChangedAssets = append(filter.ChangedAssets, asset.Asset)
+					}
+				}
+				if runConfig.Output != "json" {
+					infoPrinter.Fprintf(output, "Found %d assets impacted by the changes since '%s'.\n", len(impacted), runConfig.ChangedSince)
+				}
 			}
 			var pipelineState *scheduler.PipelineState
 			if c.Bool("continue") {
-				pipelineState, err = ReadState(afero.NewOsFs(), statePath, filter)
+				pipelineState, err = ReadState(afero.NewOsFs(), statePath, filter, output)
 				if err != nil {
-					errorPrinter.Printf("Failed to restore state: %v\n", err)
+					errorPrinter.Fprintf(output, "Failed to restore state: %v\n", err)
 					return err
 				}
 
 				runConfig = &pipelineState.Parameters
 
-				parsedStartDate, parsedEndDate, err := ParseDate(runConfig.StartDate, runConfig.EndDate, logger)
+				parsedStartDate, parsedEndDate, err := ParseDate(runConfig.StartDate, runConfig.EndDate, logger, output)
 				if err != nil {
 					return cli.Exit("", 1)
 				}
@@ -259,7 +339,7 @@ func Run(isDebug *bool) *cli.Command {
 			foundPipeline := pipelineInfo.Pipeline
 
 			if runConfig.Downstream {
-				infoPrinter.Println("The downstream tasks will be executed as well.")
+				infoPrinter.Fprintln(output, "The downstream tasks will be executed as well.")
 				pipelineInfo.RunDownstreamTasks = true
 			}
 
@@ -271,22 +351,22 @@ func Run(isDebug *bool) *cli.Command {
 
 				logPath, err := filepath.Abs(fmt.Sprintf("%s/%s/%s.log", repoRoot.Path, LogsFolder, logFileName))
 				if err != nil {
-					errorPrinter.Printf("Failed to create log file: %v\n", err)
+					errorPrinter.Fprintf(output, "Failed to create log file: %v\n", err)
 					return cli.Exit("", 1)
 				}
 
-				fn, err2 := logOutput(logPath)
+				logWriter, fn, err2 := logOutput(logPath, output)
 				if err2 != nil {
-					errorPrinter.Printf("Failed to create log file: %v\n", err2)
+					errorPrinter.Fprintf(output, "Failed to create log file: %v\n", err2)
 					return cli.Exit("", 1)
 				}
 
 				defer fn()
-				color.Output = os.Stdout
+				output = logWriter
 
 				err = git.EnsureGivenPatternIsInGitignore(afero.NewOsFs(), repoRoot.Path, LogsFolder+"/*.log")
 				if err != nil {
-					errorPrinter.Printf("Failed to add the log file to .gitignore: %v\n", err)
+					errorPrinter.Fprintf(output, "Failed to add the log file to .gitignore: %v\n", err)
 					return cli.Exit("", 1)
 				}
 			}
@@ -295,7 +375,12 @@ func Run(isDebug *bool) *cli.Command {
 				foundPipeline.MetadataPush.Global = true
 			}
 
-			err = switchEnvironment(runConfig.Environment, runConfig.Force, pipelineInfo.Config, os.Stdin)
+			if err := applyVariableOverrides(foundPipeline, runConfig.Variables); err != nil {
+				errorPrinter.Fprintf(output, "Failed to set the variables: %v\n", err)
+				return cli.Exit("", 1)
+			}
+
+			err = switchEnvironment(runConfig.Environment, runConfig.Force, pipelineInfo.Config, os.Stdin, output)
 			if err != nil {
 				return err
 			}
@@ -310,7 +395,7 @@ func Run(isDebug *bool) *cli.Command {
 
 			if c.Bool("continue") {
 				if err := s.RestoreState(pipelineState); err != nil {
-					errorPrinter.Printf("Failed to restore state: %v\n", err)
+					errorPrinter.Fprintf(output, "Failed to restore state: %v\n", err)
 					return cli.Exit("", 1)
 				}
 			}
@@ -318,28 +403,45 @@ func Run(isDebug *bool) *cli.Command {
 			if !c.Bool("continue") {
 				// Apply the filter to mark assets based on include/exclude tags
 				if err := filter.ApplyFiltersAndMarkAssets(foundPipeline, s); err != nil {
-					errorPrinter.Printf("Failed to filter assets: %v\n", err)
+					errorPrinter.Fprintf(output, "Failed to filter assets: %v\n", err)
 					return cli.Exit("", 1)
 				}
 			}
 
+			if runConfig.Smart {
+				skipUnchangedAssets(s, foundPipeline, statePath, startDate, endDate, pipelineInfo.Config.SelectedEnvironmentName, runConfig.FullRefresh, macros, logger, output)
+			} else if previousState, err := scheduler.ReadState(afero.NewOsFs(), statePath); err == nil {
+				s.KeepPreviousFingerprints(previousState)
+			}
+
 			if s.InstanceCountByStatus(scheduler.Pending) == 0 {
-				warningPrinter.Println("No tasks to run.")
+				warningPrinter.Fprintln(output, "No tasks to run.")
 				return nil
 			}
 			sendTelemetry(s, c)
-			infoPrinter.Printf("\nStarting the pipeline execution...\n")
-			infoPrinter.Println()
+			infoPrinter.Fprintf(output, "\nStarting the pipeline execution...\n")
+			infoPrinter.Fprintln(output)
 
-			mainExecutors, err := setupExecutors(s, pipelineInfo.Config, connectionManager, startDate, endDate, foundPipeline.Name, runID, runConfig.FullRefresh, runConfig.UsePip)
+			// the environments usually point to different databases, so each of them has its own metric history
+			metricsPath := filepath.Join(repoRoot.Path, MetricsFolder, pipelineInfo.Config.SelectedEnvironmentName, foundPipeline.Name)
+			if willRunAnomalyChecks(s) {
+				err = git.EnsureGivenPatternIsInGitignore(afero.NewOsFs(), repoRoot.Path, MetricsFolder)
+				if err != nil {
+					errorPrinter.Fprintf(output, "Failed to add the metric history folder to .gitignore: %v\n", err)
+					return cli.Exit("", 1)
+				}
+			}
+			metricHistory := ansisql.NewMetricHistory(afero.NewOsFs(), metricsPath)
+
+			mainExecutors, err := setupExecutors(s, pipelineInfo.Config, connectionManager, startDate, endDate, foundPipeline.Name, runID, foundPipeline.Variables.Value(), runConfig.FullRefresh, runConfig.UsePip, macros, metricHistory)
 			if err != nil {
-				errorPrinter.Println(err.Error())
+				errorPrinter.Fprintln(output, err.Error())
 				return cli.Exit("", 1)
 			}
 
-			ex, err := executor.NewConcurrent(logger, mainExecutors, c.Int("workers"))
+			ex, err := executor.NewConcurrent(logger, mainExecutors, c.Int("workers"), output)
 			if err != nil {
-				errorPrinter.Printf("Failed to create executor: %v\n", err)
+				errorPrinter.Fprintf(output, "Failed to create executor: %v\n", err)
 				return cli.Exit("", 1)
 			}
 
@@ -347,6 +449,9 @@ func Run(isDebug *bool) *cli.Command {
 			runCtx = context.WithValue(runCtx, pipeline.RunConfigFullRefresh, runConfig.FullRefresh)
 			runCtx = context.WithValue(runCtx, pipeline.RunConfigStartDate, startDate)
 			runCtx = context.WithValue(runCtx, pipeline.RunConfigEndDate, endDate)
+			runCtx = context.WithValue(runCtx, pipeline.RunConfigRunID, runID)
+			runCtx = context.WithValue(runCtx, pipeline.RunConfigEnvironment, pipelineInfo.Config.SelectedEnvironmentName)
+			runCtx = context.WithValue(runCtx, pipeline.RunConfigFailingRowsSample, runConfig.SampleFailingRows)
 			runCtx = context.WithValue(runCtx, executor.KeyIsDebug, isDebug)
 			runCtx = context.WithValue(runCtx, python.CtxUseWingetForUv, runConfig.ExpUseWingetForUv) //nolint:staticcheck
 			runCtx = context.WithValue(runCtx, python.LocalIngestr, c.String("debug-ingestr-src"))
@@ -361,16 +466,55 @@ func Run(isDebug *bool) *cli.Command {
 				logger.Error("failed to save pipeline state", zap.Error(err))
 			}
 
-			successPrinter.Printf("\n\nExecuted %d tasks in %s\n", len(results), duration.Truncate(time.Millisecond).String())
-			errorsInTaskResults := make([]*scheduler.TaskExecutionResult, 0)
-			for _, res := range results {
-				if res.Error != nil {
-					errorsInTaskResults = append(errorsInTaskResults, res)
+			successPrinter.Fprintf(output, "\n\nExecuted %d tasks in %s\n", len(results), duration.Truncate(time.Millisecond).String())
+			errorsInTaskResults, warningsInTaskResults := splitFailedResults(results)
+
+			// the schema is only compared when the run itself succeeded
+			driftIssues := make([]*lint.Issue, 0)
+			failedByWarnings := runConfig.Strict && len(warningsInTaskResults) > 0
+			if runConfig.CheckSchema && len(errorsInTaskResults) == 0 && !failedByWarnings {
+				driftIssues, err = checkSchemaDriftForSucceededAssets(runCtx, s, foundPipeline, connectionManager, logger)
+				if err != nil {
+					printError(err, runConfig.Output, "Failed to check the schema drift")
+					return cli.Exit("", 1)
+				}
+			}
+
+			if runConfig.Output == "json" {
+				summary := newRunSummary(foundPipeline.Name, runID, errorsInTaskResults, warningsInTaskResults, runConfig.Strict)
+				summary.addSchemaDrift(driftIssues)
+				js, err := json.MarshalIndent(summary, "", "  ")
+				if err != nil {
+					printError(err, runConfig.Output, "Failed to marshal the run summary")
+					return cli.Exit("", 1)
+				}
+
+				fmt.Fprintln(jsonOutput, string(js))
+			} else {
+				if len(warningsInTaskResults) > 0 {
+					printWarningsInResults(warningsInTaskResults, output)
+				}
+
+				if len(errorsInTaskResults) > 0 {
+					printErrorsInResults(errorsInTaskResults, s, output)
 				}
 			}
 
 			if len(errorsInTaskResults) > 0 {
-				printErrorsInResults(errorsInTaskResults, s)
+				return cli.Exit("", 1)
+			}
+
+			if failedByWarnings {
+				if runConfig.Output != "json" {
+					errorPrinter.Fprintln(output, "The run failed due to the warnings above since '--strict' is given.")
+				}
+				return cli.Exit("", 1)
+			}
+
+			if len(driftIssues) > 0 {
+				if runConfig.Output != "json" {
+					printSchemaDriftIssues(driftIssues, output)
+				}
 				return cli.Exit("", 1)
 			}
 
@@ -381,10 +525,123 @@ func Run(isDebug *bool) *cli.Command {
 	}
 }
 
-func ReadState(fs afero.Fs, statePath string, filter *Filter) (*scheduler.PipelineState, error) {
+// skipUnchangedAssets marks the assets whose fingerprint did not change since their last successful run as skipped.
+func skipUnchangedAssets(s *scheduler.Scheduler, p *pipeline.Pipeline, statePath string, startDate, endDate time.Time, environment string, fullRefresh bool, macros *jinja.MacroLibrary, logger *zap.SugaredLogger, output io.Writer) {
+	if fullRefresh {
+		warningPrinter.Fprintln(output, "The '--smart' flag has no effect with '--full-refresh', all the selected assets will be run.")
+		return
+	}
+
+	previousState, err := scheduler.ReadState(afero.NewOsFs(), statePath)
+	if err != nil {
+		logger.Debugf("no previous run state found at '%s', all the selected assets will be run: %v", statePath, err)
+		previousState = nil
+	}
+
+	// the run ID is left out of the rendered queries so that they are comparable across runs
+	renderer := jinja.NewRendererWithStartEndDates(&startDate, &endDate, p.Name, "fingerprint").WithMacros(macros)
+	fingerprints := pipeline.Fingerprints(p, renderer, pipeline.FingerprintInputs{
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Environment: environment,
+	})
+
+	skipped := s.SkipUnchangedAssets(fingerprints, previousState)
+	if len(skipped) == 0 {
+		return
+	}
+
+	infoPrinter.Fprintf(output, "Skipping %d unchanged assets:\n", len(skipped))
+	for _, name := range skipped {
+		infoPrinter.Fprintf(output, "  - %s: %s\n", name, s.SkipReason(name))
+	}
+}
+
+// checkSchemaDriftForSucceededAssets compares the columns of the assets that succeeded in this run with the tables
+// that were just created or updated in the warehouse, and returns the drift issues found.
+func checkSchemaDriftForSucceededAssets(ctx context.Context, s *scheduler.Scheduler, p *pipeline.Pipeline, conn *connection.Manager, logger *zap.SugaredLogger) ([]*lint.Issue, error) {
+	rule := &lint.SchemaDriftRule{
+		Identifier:  "schema-drift",
+		Connections: conn,
+		Logger:      logger,
+	}
+
+	issues := make([]*lint.Issue, 0)
+	for _, instance := range s.GetTaskInstancesByStatus(scheduler.Succeeded) {
+		if instance.GetType() != scheduler.TaskInstanceTypeMain {
+			continue
+		}
+
+		assetIssues, err := rule.ValidateAsset(ctx, p, instance.GetAsset())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to check the schema of the asset '%s'", instance.GetAsset().Name)
+		}
+
+		issues = append(issues, assetIssues...)
+	}
+
+	return issues, nil
+}
+
+func printSchemaDriftIssues(issues []*lint.Issue, output io.Writer) {
+	errorPrinter.Fprintf(output, "\nFound %d schema drift issues:\n", len(issues))
+	for _, issue := range issues {
+		errorPrinter.Fprintf(output, "  - %s: %s\n", issue.Task.Name, issue.Description)
+	}
+}
+
+// variableFlag collects the values of the repeatable --var flag. Unlike the string slice flags, the values are not split
+// on commas since they can be JSON lists or objects.
+type variableFlag struct {
+	values []string
+}
+
+func (v *variableFlag) Set(value string) error {
+	v.values = append(v.values, value)
+	return nil
+}
+
+func (v *variableFlag) String() string {
+	return strings.Join(v.values, " ")
+}
+
+// parseVariableOverrides parses the variables given in the `key=value` format.
+func parseVariableOverrides(values []string) (map[string]string, error) {
+	variables := make(map[string]string, len(values))
+	for _, value := range values {
+		name, rawValue, found := strings.Cut(value, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, errors.Errorf("invalid variable '%s', variables must be given in the 'key=value' format", value)
+		}
+
+		variables[name] = rawValue
+	}
+
+	return variables, nil
+}
+
+// applyVariableOverrides replaces the values of the pipeline variables with the given ones after validating them.
+func applyVariableOverrides(p *pipeline.Pipeline, overrides map[string]string) error {
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		if err := p.Variables.Override(name, overrides[name]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func ReadState(fs afero.Fs, statePath string, filter *Filter, output io.Writer) (*scheduler.PipelineState, error) {
 	pipelineState, err := scheduler.ReadState(fs, statePath)
 	if err != nil {
-		errorPrinter.Printf("Failed to restore state: %v\n", err)
+		errorPrinter.Fprintf(output, "Failed to restore state: %v\n", err)
 		return nil, err
 	}
 	filter.IncludeTag = pipelineState.Parameters.Tag
@@ -394,17 +651,17 @@ func ReadState(fs afero.Fs, statePath string, filter *Filter) (*scheduler.Pipeli
 	return pipelineState, nil
 }
 
-func GetPipeline(inputPath string, runConfig *scheduler.RunConfig, logger *zap.SugaredLogger) (*PipelineInfo, error) {
+func GetPipeline(inputPath string, runConfig *scheduler.RunConfig, logger *zap.SugaredLogger, output io.Writer) (*PipelineInfo, error) {
 	pipelinePath := inputPath
 	repoRoot, err := git.FindRepoFromPath(inputPath)
 	if err != nil {
-		errorPrinter.Printf("Failed to find the git repository root: %v\n", err)
+		errorPrinter.Fprintf(output, "Failed to find the git repository root: %v\n", err)
 		return nil, err
 	}
 
 	runningForAnAsset := isPathReferencingAsset(inputPath)
 	if runningForAnAsset && runConfig.Tag != "" {
-		errorPrinter.Printf("You cannot use the '--tag' flag when running a single asset.\n")
+		errorPrinter.Fprintf(output, "You cannot use the '--tag' flag when running a single asset.\n")
 		return nil, err
 	}
 
@@ -414,7 +671,7 @@ func GetPipeline(inputPath string, runConfig *scheduler.RunConfig, logger *zap.S
 	if runningForAnAsset {
 		pipelinePath, err = path.GetPipelineRootFromTask(inputPath, pipelineDefinitionFiles)
 		if err != nil {
-			errorPrinter.Printf("Failed to find the pipeline this task belongs to: '%s'\n", inputPath)
+			errorPrinter.Fprintf(output, "Failed to find the pipeline this task belongs to: '%s'\n", inputPath)
 			return &PipelineInfo{
 				RunningForAnAsset:  runningForAnAsset,
 				RunDownstreamTasks: runDownstreamTasks,
@@ -425,7 +682,7 @@ func GetPipeline(inputPath string, runConfig *scheduler.RunConfig, logger *zap.S
 	configFilePath := path2.Join(repoRoot.Path, ".bruin.yml")
 	cm, err := config.LoadOrCreate(afero.NewOsFs(), configFilePath)
 	if err != nil {
-		errorPrinter.Printf("Failed to load the config file at '%s': %v\n", configFilePath, err)
+		errorPrinter.Fprintf(output, "Failed to load the config file at '%s': %v\n", configFilePath, err)
 		return &PipelineInfo{
 			RunningForAnAsset:  runningForAnAsset,
 			RunDownstreamTasks: runDownstreamTasks,
@@ -437,8 +694,8 @@ func GetPipeline(inputPath string, runConfig *scheduler.RunConfig, logger *zap.S
 
 	foundPipeline, err := DefaultPipelineBuilder.CreatePipelineFromPath(pipelinePath, true)
 	if err != nil {
-		errorPrinter.Println("failed to build pipeline, are you sure you have referred the right path?")
-		errorPrinter.Println("\nHint: You need to run this command with a path to either the pipeline directory or the asset file itself directly.")
+		errorPrinter.Fprintln(output, "failed to build pipeline, are you sure you have referred the right path?")
+		errorPrinter.Fprintln(output, "\nHint: You need to run this command with a path to either the pipeline directory or the asset file itself directly.")
 
 		return &PipelineInfo{
 			Pipeline:           foundPipeline,
@@ -451,7 +708,7 @@ func GetPipeline(inputPath string, runConfig *scheduler.RunConfig, logger *zap.S
 	if runningForAnAsset {
 		task, err = DefaultPipelineBuilder.CreateAssetFromFile(inputPath, foundPipeline)
 		if err != nil {
-			errorPrinter.Printf("Failed to build asset: %v. Are you sure you used the correct path?\n", err.Error())
+			errorPrinter.Fprintf(output, "Failed to build asset: %v. Are you sure you used the correct path?\n", err.Error())
 			return &PipelineInfo{
 				RunningForAnAsset:  runningForAnAsset,
 				RunDownstreamTasks: runDownstreamTasks,
@@ -462,7 +719,7 @@ func GetPipeline(inputPath string, runConfig *scheduler.RunConfig, logger *zap.S
 
 		task, err = DefaultPipelineBuilder.MutateAsset(task, foundPipeline)
 		if err != nil {
-			errorPrinter.Printf("Failed to mutate asset: %v\n", err)
+			errorPrinter.Fprintf(output, "Failed to mutate asset: %v\n", err)
 			return &PipelineInfo{
 				RunningForAnAsset:  runningForAnAsset,
 				RunDownstreamTasks: runDownstreamTasks,
@@ -470,7 +727,7 @@ func GetPipeline(inputPath string, runConfig *scheduler.RunConfig, logger *zap.S
 			}, err
 		}
 		if task == nil {
-			errorPrinter.Printf("The given file path doesn't seem to be a Bruin task definition: '%s'\n", inputPath)
+			errorPrinter.Fprintf(output, "The given file path doesn't seem to be a Bruin task definition: '%s'\n", inputPath)
 			return &PipelineInfo{
 				RunningForAnAsset:  runningForAnAsset,
 				RunDownstreamTasks: runDownstreamTasks,
@@ -488,36 +745,36 @@ func GetPipeline(inputPath string, runConfig *scheduler.RunConfig, logger *zap.S
 	}, nil
 }
 
-func ParseDate(startDateStr, endDateStr string, logger *zap.SugaredLogger) (time.Time, time.Time, error) {
+func ParseDate(startDateStr, endDateStr string, logger *zap.SugaredLogger, output io.Writer) (time.Time, time.Time, error) {
 	startDate, err := date.ParseTime(startDateStr)
 	logger.Debug("given start date: ", startDate)
 	if err != nil {
-		errorPrinter.Printf("Please give a valid start date: bruin run --start-date <start date>)\n")
-		errorPrinter.Printf("A valid start date can be in the YYYY-MM-DD or YYYY-MM-DD HH:MM:SS formats. \n")
-		errorPrinter.Printf("    e.g. %s  \n", time.Now().AddDate(0, 0, -1).Format("2006-01-02"))
-		errorPrinter.Printf("    e.g. %s  \n", time.Now().AddDate(0, 0, -1).Format("2006-01-02 15:04:05"))
+		errorPrinter.Fprintf(output, "Please give a valid start date: bruin run --start-date <start date>)\n")
+		errorPrinter.Fprintf(output, "A valid start date can be in the YYYY-MM-DD or YYYY-MM-DD HH:MM:SS formats. \n")
+		errorPrinter.Fprintf(output, "    e.g. %s  \n", time.Now().AddDate(0, 0, -1).Format("2006-01-02"))
+		errorPrinter.Fprintf(output, "    e.g. %s  \n", time.Now().AddDate(0, 0, -1).Format("2006-01-02 15:04:05"))
 		return time.Time{}, time.Time{}, err
 	}
 
 	endDate, err := date.ParseTime(endDateStr)
 	logger.Debug("given end date: ", endDate)
 	if err != nil {
-		errorPrinter.Printf("Please give a valid end date: bruin run --start-date <start date>)\n")
-		errorPrinter.Printf("A valid start date can be in the YYYY-MM-DD or YYYY-MM-DD HH:MM:SS formats. \n")
-		errorPrinter.Printf("    e.g. %s  \n", time.Now().AddDate(0, 0, -1).Format("2006-01-02"))
-		errorPrinter.Printf("    e.g. %s  \n", time.Now().AddDate(0, 0, -1).Format("2006-01-02 15:04:05"))
+		errorPrinter.Fprintf(output, "Please give a valid end date: bruin run --start-date <start date>)\n")
+		errorPrinter.Fprintf(output, "A valid start date can be in the YYYY-MM-DD or YYYY-MM-DD HH:MM:SS formats. \n")
+		errorPrinter.Fprintf(output, "    e.g. %s  \n", time.Now().AddDate(0, 0, -1).Format("2006-01-02"))
+		errorPrinter.Fprintf(output, "    e.g. %s  \n", time.Now().AddDate(0, 0, -1).Format("2006-01-02 15:04:05"))
 		return time.Time{}, time.Time{}, err
 	}
 
 	return startDate, endDate, nil
 }
 
-func ValidateRunConfig(runConfig *scheduler.RunConfig, inputPath string, logger *zap.SugaredLogger) (time.Time, time.Time, string, error) {
+func ValidateRunConfig(runConfig *scheduler.RunConfig, inputPath string, logger *zap.SugaredLogger, output io.Writer) (time.Time, time.Time, string, error) {
 	if inputPath == "" {
 		inputPath = "."
 	}
 
-	startDate, endDate, err := ParseDate(runConfig.StartDate, runConfig.EndDate, logger)
+	startDate, endDate, err := ParseDate(runConfig.StartDate, runConfig.EndDate, logger, output)
 	if err != nil {
 		return time.Now(), time.Now(), "", err
 	}
@@ -525,18 +782,18 @@ func ValidateRunConfig(runConfig *scheduler.RunConfig, inputPath string, logger
 	return startDate, endDate, inputPath, nil
 }
 
-func CheckLint(foundPipeline *pipeline.Pipeline, pipelinePath string, logger *zap.SugaredLogger, parser *sqlparser.SQLParser) error {
-	rules, err := lint.GetRules(fs, &git.RepoFinder{}, true, parser, true)
+func CheckLint(ctx context.Context, foundPipeline *pipeline.Pipeline, pipelinePath string, logger *zap.SugaredLogger, parser *sqlparser.SQLParser, macros *jinja.MacroLibrary, output io.Writer) error {
+	rules, err := lint.GetRules(fs, &git.RepoFinder{}, true, parser, macros, true)
 	if err != nil {
-		errorPrinter.Printf("An error occurred while linting the pipelines: %v\n", err)
+		errorPrinter.Fprintf(output, "An error occurred while linting the pipelines: %v\n", err)
 		return err
 	}
 
 	rules = lint.FilterRulesBySpeed(rules, true)
 
 	linter := lint.NewLinter(path.GetPipelinePaths, DefaultPipelineBuilder, rules, logger)
-	res, err := linter.LintPipelines([]*pipeline.Pipeline{foundPipeline})
-	err = reportLintErrors(res, err, lint.Printer{RootCheckPath: pipelinePath}, "")
+	res, err := linter.LintPipelines(ctx, []*pipeline.Pipeline{foundPipeline})
+	err = reportLintErrors(res, err, lint.Printer{RootCheckPath: pipelinePath, Output: output}, "", output)
 	if err != nil {
 		return err
 	}
@@ -544,9 +801,100 @@ func CheckLint(foundPipeline *pipeline.Pipeline, pipelinePath string, logger *za
 	return nil
 }
 
-func printErrorsInResults(errorsInTaskResults []*scheduler.TaskExecutionResult, s *scheduler.Scheduler) {
-	data := make(map[string][]*scheduler.TaskExecutionResult, len(errorsInTaskResults))
-	for _, result := range errorsInTaskResults {
+// splitFailedResults separates the failed tasks from the non-blocking checks whose failures are only warnings.
+func splitFailedResults(results []*scheduler.TaskExecutionResult) ([]*scheduler.TaskExecutionResult, []*scheduler.TaskExecutionResult) {
+	errorsInTaskResults := make([]*scheduler.TaskExecutionResult, 0)
+	warningsInTaskResults := make([]*scheduler.TaskExecutionResult, 0)
+	for _, res := range results {
+		if res.Error == nil {
+			continue
+		}
+
+		if res.Instance.GetStatus() == scheduler.Warned {
+			warningsInTaskResults = append(warningsInTaskResults, res)
+			continue
+		}
+
+		errorsInTaskResults = append(errorsInTaskResults, res)
+	}
+
+	return errorsInTaskResults, warningsInTaskResults
+}
+
+type taskResultSummary struct {
+	Asset  string               `json:"asset"`
+	Task   string               `json:"task"`
+	Type   string               `json:"type"`
+	Error  string               `json:"error"`
+	Sample *scheduler.RowSample `json:"sample,omitempty"`
+}
+
+type schemaDriftSummary struct {
+	Asset       string `json:"asset"`
+	Description string `json:"description"`
+}
+
+type runSummary struct {
+	Pipeline    string                `json:"pipeline"`
+	RunID       string                `json:"run_id"`
+	Status      string                `json:"status"`
+	Errors      []*taskResultSummary  `json:"errors"`
+	Warnings    []*taskResultSummary  `json:"warnings"`
+	SchemaDrift []*schemaDriftSummary `json:"schema_drift,omitempty"`
+}
+
+// addSchemaDrift adds the schema drift issues found after the run to the summary, any drift fails the run.
+func (r *runSummary) addSchemaDrift(issues []*lint.Issue) {
+	if len(issues) == 0 {
+		return
+	}
+
+	for _, issue := range issues {
+		r.SchemaDrift = append(r.SchemaDrift, &schemaDriftSummary{
+			Asset:       issue.Task.Name,
+			Description: issue.Description,
+		})
+	}
+	r.Status = scheduler.Failed.String()
+}
+
+func newRunSummary(pipelineName, runID string, errorsInTaskResults, warningsInTaskResults []*scheduler.TaskExecutionResult, strict bool) *runSummary {
+	summarize := func(results []*scheduler.TaskExecutionResult) []*taskResultSummary {
+		summaries := make([]*taskResultSummary, 0, len(results))
+		for _, res := range results {
+			summaries = append(summaries, &taskResultSummary{
+				Asset:  res.Instance.GetAsset().Name,
+				Task:   res.Instance.GetHumanID(),
+				Type:   res.Instance.GetType().String(),
+				Error:  res.Error.Error(),
+				Sample: scheduler.FailingRowsSample(res.Error),
+			})
+		}
+
+		return summaries
+	}
+
+	status := scheduler.Succeeded
+	switch {
+	case len(errorsInTaskResults) > 0, strict && len(warningsInTaskResults) > 0:
+		status = scheduler.Failed
+	case len(warningsInTaskResults) > 0:
+		status = scheduler.Warned
+	}
+
+	return &runSummary{
+		Pipeline: pipelineName,
+		RunID:    runID,
+		Status:   status.String(),
+		Errors:   summarize(errorsInTaskResults),
+		Warnings: summarize(warningsInTaskResults),
+	}
+}
+
+// resultsTree groups the failed tasks by their asset, and returns the tree along with the number of assets in it.
+func resultsTree(taskResults []*scheduler.TaskExecutionResult) (treeprint.Tree, int) {
+	data := make(map[string][]*scheduler.TaskExecutionResult, len(taskResults))
+	for _, result := range taskResults {
 		assetName := result.Instance.GetAsset().Name
 		data[assetName] = append(data[assetName], result)
 	}
@@ -568,21 +916,65 @@ func printErrorsInResults(errorsInTaskResults []*scheduler.TaskExecutionResult,
 
 				checkBranch := colBranch.AddBranch("[Check] " + instance.Check.Name)
 				checkBranch.AddNode(fmt.Sprintf("'%s'", result.Error))
+				addRowSampleToTree(checkBranch, scheduler.FailingRowsSample(result.Error))
 
 			case *scheduler.CustomCheckInstance:
 				customBranch := assetBranch.AddBranch("[Custom Check] " + instance.Check.Name)
 				customBranch.AddNode(fmt.Sprintf("'%s'", result.Error))
 
+			case *scheduler.FreshnessCheckInstance:
+				freshnessBranch := assetBranch.AddBranch("[Freshness] " + instance.Check.Column)
+				freshnessBranch.AddNode(fmt.Sprintf("'%s'", result.Error))
+
+			case *scheduler.AnomalyCheckInstance:
+				anomalyBranch := assetBranch.AddBranch("[Anomaly Check] " + instance.Check.Name)
+				anomalyBranch.AddNode(fmt.Sprintf("'%s'", result.Error))
+
 			default:
 				assetBranch.AddNode(fmt.Sprintf("'%s'", result.Error))
 			}
 		}
 	}
-	errorPrinter.Println(fmt.Sprintf("Failed assets %d", len(data)))
-	errorPrinter.Println(tree.String())
+
+	return tree, len(data)
+}
+
+func addRowSampleToTree(branch treeprint.Tree, sample *scheduler.RowSample) {
+	if sample == nil {
+		return
+	}
+
+	sampleBranch := branch.AddBranch(fmt.Sprintf("Sample of %d failing rows", len(sample.Rows)))
+	for _, row := range sample.Rows {
+		values := make([]string, len(row))
+		for i, v := range row {
+			value := fmt.Sprintf("%v", v)
+			if v == nil {
+				value = "NULL"
+			}
+
+			if i < len(sample.Columns) {
+				value = sample.Columns[i] + "=" + value
+			}
+			values[i] = value
+		}
+		sampleBranch.AddNode(strings.Join(values, ", "))
+	}
+}
+
+func printWarningsInResults(warningsInTaskResults []*scheduler.TaskExecutionResult, output io.Writer) {
+	tree, assetCount := resultsTree(warningsInTaskResults)
+	warningPrinter.Fprintln(output, fmt.Sprintf("Assets with warnings %d", assetCount))
+	warningPrinter.Fprintln(output, tree.String())
+}
+
+func printErrorsInResults(errorsInTaskResults []*scheduler.TaskExecutionResult, s *scheduler.Scheduler, output io.Writer) {
+	tree, assetCount := resultsTree(errorsInTaskResults)
+	errorPrinter.Fprintln(output, fmt.Sprintf("Failed assets %d", assetCount))
+	errorPrinter.Fprintln(output, tree.String())
 	upstreamFailedTasks := s.GetTaskInstancesByStatus(scheduler.UpstreamFailed)
 	if len(upstreamFailedTasks) > 0 {
-		errorPrinter.Printf("The following tasks are skipped due to their upstream failing:\n")
+		errorPrinter.Fprintf(output, "The following tasks are skipped due to their upstream failing:\n")
 
 		skippedAssets := make(map[string]int, 0)
 		for _, t := range upstreamFailedTasks {
@@ -599,9 +991,9 @@ func printErrorsInResults(errorsInTaskResults []*scheduler.TaskExecutionResult,
 
 		for asset, checkCount := range skippedAssets {
 			if checkCount == 0 {
-				errorPrinter.Printf("  - %s\n", asset)
+				errorPrinter.Fprintf(output, "  - %s\n", asset)
 			} else {
-				errorPrinter.Printf("  - %s %s\n", asset, faint(fmt.Sprintf("(and %d checks)", checkCount)))
+				errorPrinter.Fprintf(output, "  - %s %s\n", asset, faint(fmt.Sprintf("(and %d checks)", checkCount)))
 			}
 		}
 	}
@@ -615,8 +1007,11 @@ func setupExecutors(
 	endDate time.Time,
 	pipelineName string,
 	runID string,
+	variables map[string]any,
 	fullRefresh bool,
 	usePipForPython bool,
+	macros *jinja.MacroLibrary,
+	metricHistory *ansisql.MetricHistory,
 ) (map[pipeline.AssetType]executor.Config, error) {
 	mainExecutors := executor.DefaultExecutorsV2
 
@@ -630,7 +1025,7 @@ func setupExecutors(
 	}
 
 	if s.WillRunTaskOfType(pipeline.AssetTypePython) {
-		jinjaVariables := jinja.PythonEnvVariables(&startDate, &endDate, pipelineName, runID, fullRefresh)
+		jinjaVariables := jinja.PythonEnvVariables(&startDate, &endDate, pipelineName, runID, fullRefresh, variables)
 		if usePipForPython {
 			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeMain] = python.NewLocalOperator(config, jinjaVariables)
 		} else {
@@ -638,7 +1033,7 @@ func setupExecutors(
 		}
 	}
 
-	renderer := jinja.NewRendererWithStartEndDates(&startDate, &endDate, pipelineName, runID)
+	renderer := jinja.NewRendererWithStartEndDates(&startDate, &endDate, pipelineName, runID).WithMacros(macros)
 	wholeFileExtractor := &query.WholeFileExtractor{
 		Fs:       fs,
 		Renderer: renderer,
@@ -686,25 +1081,40 @@ func setupExecutors(
 	}
 
 	if s.WillRunTaskOfType(pipeline.AssetTypePostgresQuery) || estimateCustomCheckType == pipeline.AssetTypePostgresQuery ||
-		s.WillRunTaskOfType(pipeline.AssetTypeRedshiftQuery) || estimateCustomCheckType == pipeline.AssetTypeRedshiftQuery || s.WillRunTaskOfType(pipeline.AssetTypeRedshiftSeed) || s.WillRunTaskOfType(pipeline.AssetTypePostgresSeed) {
+		s.WillRunTaskOfType(pipeline.AssetTypeRedshiftQuery) || estimateCustomCheckType == pipeline.AssetTypeRedshiftQuery || s.WillRunTaskOfType(pipeline.AssetTypeRedshiftSeed) || s.WillRunTaskOfType(pipeline.AssetTypePostgresSeed) ||
+		s.WillRunTaskOfType(pipeline.AssetTypePostgresSource) || s.WillRunTaskOfType(pipeline.AssetTypeRedshiftSource) {
 		pgCheckRunner := postgres.NewColumnCheckOperator(conn)
 		pgOperator := postgres.NewBasicOperator(conn, wholeFileExtractor, postgres.NewMaterializer(fullRefresh))
+		pgMetadataPushOperator := postgres.NewMetadataPushOperator(conn)
+		redshiftMetadataPushOperator := postgres.NewRedshiftMetadataPushOperator(conn)
 
 		mainExecutors[pipeline.AssetTypeRedshiftQuery][scheduler.TaskInstanceTypeMain] = pgOperator
 		mainExecutors[pipeline.AssetTypeRedshiftQuery][scheduler.TaskInstanceTypeColumnCheck] = pgCheckRunner
 		mainExecutors[pipeline.AssetTypeRedshiftQuery][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypeRedshiftQuery][scheduler.TaskInstanceTypeMetadataPush] = redshiftMetadataPushOperator
 
 		mainExecutors[pipeline.AssetTypePostgresQuery][scheduler.TaskInstanceTypeMain] = pgOperator
 		mainExecutors[pipeline.AssetTypePostgresQuery][scheduler.TaskInstanceTypeColumnCheck] = pgCheckRunner
 		mainExecutors[pipeline.AssetTypePostgresQuery][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypePostgresQuery][scheduler.TaskInstanceTypeMetadataPush] = pgMetadataPushOperator
 
 		mainExecutors[pipeline.AssetTypePostgresSeed][scheduler.TaskInstanceTypeMain] = seedOperator
 		mainExecutors[pipeline.AssetTypePostgresSeed][scheduler.TaskInstanceTypeColumnCheck] = pgCheckRunner
 		mainExecutors[pipeline.AssetTypePostgresSeed][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypePostgresSeed][scheduler.TaskInstanceTypeMetadataPush] = pgMetadataPushOperator
 
 		mainExecutors[pipeline.AssetTypeRedshiftSeed][scheduler.TaskInstanceTypeMain] = seedOperator
 		mainExecutors[pipeline.AssetTypeRedshiftSeed][scheduler.TaskInstanceTypeColumnCheck] = pgCheckRunner
 		mainExecutors[pipeline.AssetTypeRedshiftSeed][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypeRedshiftSeed][scheduler.TaskInstanceTypeMetadataPush] = redshiftMetadataPushOperator
+
+		mainExecutors[pipeline.AssetTypePostgresSource][scheduler.TaskInstanceTypeColumnCheck] = pgCheckRunner
+		mainExecutors[pipeline.AssetTypePostgresSource][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypePostgresSource][scheduler.TaskInstanceTypeMetadataPush] = pgMetadataPushOperator
+
+		mainExecutors[pipeline.AssetTypeRedshiftSource][scheduler.TaskInstanceTypeColumnCheck] = pgCheckRunner
+		mainExecutors[pipeline.AssetTypeRedshiftSource][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypeRedshiftSource][scheduler.TaskInstanceTypeMetadataPush] = redshiftMetadataPushOperator
 
 		// we set the Python runners to run the checks on Snowflake assuming that there won't be many usecases where a user has both BQ and Snowflake
 		if estimateCustomCheckType == pipeline.AssetTypePostgresQuery || estimateCustomCheckType == pipeline.AssetTypeRedshiftQuery {
@@ -713,7 +1123,7 @@ func setupExecutors(
 		}
 	}
 
-	shouldInitiateSnowflake := s.WillRunTaskOfType(pipeline.AssetTypeSnowflakeQuery) || s.WillRunTaskOfType(pipeline.AssetTypeSnowflakeQuerySensor) || estimateCustomCheckType == pipeline.AssetTypeSnowflakeQuery || s.WillRunTaskOfType(pipeline.AssetTypeSnowflakeSeed)
+	shouldInitiateSnowflake := s.WillRunTaskOfType(pipeline.AssetTypeSnowflakeQuery) || s.WillRunTaskOfType(pipeline.AssetTypeSnowflakeQuerySensor) || estimateCustomCheckType == pipeline.AssetTypeSnowflakeQuery || s.WillRunTaskOfType(pipeline.AssetTypeSnowflakeSeed) || s.WillRunTaskOfType(pipeline.AssetTypeSnowflakeSource)
 	if shouldInitiateSnowflake {
 		sfOperator := snowflake.NewBasicOperator(conn, wholeFileExtractor, snowflake.NewMaterializer(fullRefresh))
 
@@ -736,6 +1146,10 @@ func setupExecutors(
 		mainExecutors[pipeline.AssetTypeSnowflakeSeed][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
 		mainExecutors[pipeline.AssetTypeSnowflakeSeed][scheduler.TaskInstanceTypeMetadataPush] = sfMetadataPushOperator
 
+		mainExecutors[pipeline.AssetTypeSnowflakeSource][scheduler.TaskInstanceTypeColumnCheck] = sfCheckRunner
+		mainExecutors[pipeline.AssetTypeSnowflakeSource][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypeSnowflakeSource][scheduler.TaskInstanceTypeMetadataPush] = sfMetadataPushOperator
+
 		// we set the Python runners to run the checks on Snowflake assuming that there won't be many usecases where a user has both BQ and Snowflake
 		if estimateCustomCheckType == pipeline.AssetTypeSnowflakeQuery {
 			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeColumnCheck] = sfCheckRunner
@@ -745,16 +1159,19 @@ func setupExecutors(
 	}
 
 	if s.WillRunTaskOfType(pipeline.AssetTypeMsSQLQuery) || estimateCustomCheckType == pipeline.AssetTypeMsSQLQuery ||
-		s.WillRunTaskOfType(pipeline.AssetTypeSynapseQuery) || estimateCustomCheckType == pipeline.AssetTypeSynapseQuery || s.WillRunTaskOfType(pipeline.AssetTypeMsSQLSeed) || s.WillRunTaskOfType(pipeline.AssetTypeSynapseSeed) {
+		s.WillRunTaskOfType(pipeline.AssetTypeSynapseQuery) || estimateCustomCheckType == pipeline.AssetTypeSynapseQuery || s.WillRunTaskOfType(pipeline.AssetTypeMsSQLSeed) || s.WillRunTaskOfType(pipeline.AssetTypeSynapseSeed) ||
+		s.WillRunTaskOfType(pipeline.AssetTypeMsSQLSource) || s.WillRunTaskOfType(pipeline.AssetTypeSynapseSource) {
 		msOperator := mssql.NewBasicOperator(conn, wholeFileExtractor, mssql.NewMaterializer(fullRefresh))
 		synapseOperator := synapse.NewBasicOperator(conn, wholeFileExtractor, synapse.NewMaterializer(fullRefresh))
 
 		msCheckRunner := mssql.NewColumnCheckOperator(conn)
 		synapseCheckRunner := synapse.NewColumnCheckOperator(conn)
+		msMetadataPushOperator := mssql.NewMetadataPushOperator(conn)
 
 		mainExecutors[pipeline.AssetTypeMsSQLQuery][scheduler.TaskInstanceTypeMain] = msOperator
 		mainExecutors[pipeline.AssetTypeMsSQLQuery][scheduler.TaskInstanceTypeColumnCheck] = msCheckRunner
 		mainExecutors[pipeline.AssetTypeMsSQLQuery][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypeMsSQLQuery][scheduler.TaskInstanceTypeMetadataPush] = msMetadataPushOperator
 
 		mainExecutors[pipeline.AssetTypeSynapseQuery][scheduler.TaskInstanceTypeMain] = synapseOperator
 		mainExecutors[pipeline.AssetTypeSynapseQuery][scheduler.TaskInstanceTypeColumnCheck] = synapseCheckRunner
@@ -763,11 +1180,19 @@ func setupExecutors(
 		mainExecutors[pipeline.AssetTypeMsSQLSeed][scheduler.TaskInstanceTypeMain] = seedOperator
 		mainExecutors[pipeline.AssetTypeMsSQLSeed][scheduler.TaskInstanceTypeColumnCheck] = msCheckRunner
 		mainExecutors[pipeline.AssetTypeMsSQLSeed][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypeMsSQLSeed][scheduler.TaskInstanceTypeMetadataPush] = msMetadataPushOperator
 
 		mainExecutors[pipeline.AssetTypeSynapseSeed][scheduler.TaskInstanceTypeMain] = seedOperator
 		mainExecutors[pipeline.AssetTypeSynapseSeed][scheduler.TaskInstanceTypeColumnCheck] = synapseCheckRunner
 		mainExecutors[pipeline.AssetTypeSynapseSeed][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
 
+		mainExecutors[pipeline.AssetTypeMsSQLSource][scheduler.TaskInstanceTypeColumnCheck] = msCheckRunner
+		mainExecutors[pipeline.AssetTypeMsSQLSource][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypeMsSQLSource][scheduler.TaskInstanceTypeMetadataPush] = msMetadataPushOperator
+
+		mainExecutors[pipeline.AssetTypeSynapseSource][scheduler.TaskInstanceTypeColumnCheck] = synapseCheckRunner
+		mainExecutors[pipeline.AssetTypeSynapseSource][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+
 		// we set the Python runners to run the checks on MsSQL
 		if estimateCustomCheckType == pipeline.AssetTypeMsSQLQuery || estimateCustomCheckType == pipeline.AssetTypeSynapseQuery {
 			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeColumnCheck] = msCheckRunner
@@ -775,17 +1200,24 @@ func setupExecutors(
 		}
 	}
 
-	if s.WillRunTaskOfType(pipeline.AssetTypeDatabricksQuery) || estimateCustomCheckType == pipeline.AssetTypeDatabricksQuery || s.WillRunTaskOfType(pipeline.AssetTypeDatabricksSeed) {
+	if s.WillRunTaskOfType(pipeline.AssetTypeDatabricksQuery) || estimateCustomCheckType == pipeline.AssetTypeDatabricksQuery || s.WillRunTaskOfType(pipeline.AssetTypeDatabricksSeed) || s.WillRunTaskOfType(pipeline.AssetTypeDatabricksSource) {
 		databricksOperator := databricks.NewBasicOperator(conn, wholeFileExtractor, databricks.NewMaterializer(fullRefresh))
 		databricksCheckRunner := databricks.NewColumnCheckOperator(conn)
+		databricksMetadataPushOperator := databricks.NewMetadataPushOperator(conn)
 
 		mainExecutors[pipeline.AssetTypeDatabricksQuery][scheduler.TaskInstanceTypeMain] = databricksOperator
 		mainExecutors[pipeline.AssetTypeDatabricksQuery][scheduler.TaskInstanceTypeColumnCheck] = databricksCheckRunner
 		mainExecutors[pipeline.AssetTypeDatabricksQuery][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypeDatabricksQuery][scheduler.TaskInstanceTypeMetadataPush] = databricksMetadataPushOperator
 
 		mainExecutors[pipeline.AssetTypeDatabricksSeed][scheduler.TaskInstanceTypeMain] = seedOperator
 		mainExecutors[pipeline.AssetTypeDatabricksSeed][scheduler.TaskInstanceTypeColumnCheck] = databricksCheckRunner
 		mainExecutors[pipeline.AssetTypeDatabricksSeed][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypeDatabricksSeed][scheduler.TaskInstanceTypeMetadataPush] = databricksMetadataPushOperator
+
+		mainExecutors[pipeline.AssetTypeDatabricksSource][scheduler.TaskInstanceTypeColumnCheck] = databricksCheckRunner
+		mainExecutors[pipeline.AssetTypeDatabricksSource][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypeDatabricksSource][scheduler.TaskInstanceTypeMetadataPush] = databricksMetadataPushOperator
 
 		// we set the Python runners to run the checks on MsSQL
 		if estimateCustomCheckType == pipeline.AssetTypeDatabricksQuery {
@@ -825,17 +1257,24 @@ func setupExecutors(
 		}
 	}
 
-	if s.WillRunTaskOfType(pipeline.AssetTypeDuckDBQuery) || estimateCustomCheckType == pipeline.AssetTypeDuckDBQuery || s.WillRunTaskOfType(pipeline.AssetTypeDuckDBSeed) {
+	if s.WillRunTaskOfType(pipeline.AssetTypeDuckDBQuery) || estimateCustomCheckType == pipeline.AssetTypeDuckDBQuery || s.WillRunTaskOfType(pipeline.AssetTypeDuckDBSeed) || s.WillRunTaskOfType(pipeline.AssetTypeDuckDBSource) {
 		duckDBOperator := duck.NewBasicOperator(conn, wholeFileExtractor, duck.NewMaterializer(fullRefresh))
 		duckDBCheckRunner := duck.NewColumnCheckOperator(conn)
+		duckDBMetadataPushOperator := duck.NewMetadataPushOperator(conn)
 
 		mainExecutors[pipeline.AssetTypeDuckDBQuery][scheduler.TaskInstanceTypeMain] = duckDBOperator
 		mainExecutors[pipeline.AssetTypeDuckDBQuery][scheduler.TaskInstanceTypeColumnCheck] = duckDBCheckRunner
 		mainExecutors[pipeline.AssetTypeDuckDBQuery][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypeDuckDBQuery][scheduler.TaskInstanceTypeMetadataPush] = duckDBMetadataPushOperator
 
 		mainExecutors[pipeline.AssetTypeDuckDBSeed][scheduler.TaskInstanceTypeMain] = seedOperator
 		mainExecutors[pipeline.AssetTypeDuckDBSeed][scheduler.TaskInstanceTypeColumnCheck] = duckDBCheckRunner
 		mainExecutors[pipeline.AssetTypeDuckDBSeed][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypeDuckDBSeed][scheduler.TaskInstanceTypeMetadataPush] = duckDBMetadataPushOperator
+
+		mainExecutors[pipeline.AssetTypeDuckDBSource][scheduler.TaskInstanceTypeColumnCheck] = duckDBCheckRunner
+		mainExecutors[pipeline.AssetTypeDuckDBSource][scheduler.TaskInstanceTypeCustomCheck] = customCheckRunner
+		mainExecutors[pipeline.AssetTypeDuckDBSource][scheduler.TaskInstanceTypeMetadataPush] = duckDBMetadataPushOperator
 
 		if estimateCustomCheckType == pipeline.AssetTypeDuckDBQuery {
 			mainExecutors[pipeline.AssetTypePython][scheduler.TaskInstanceTypeColumnCheck] = duckDBCheckRunner
@@ -862,9 +1301,30 @@ func setupExecutors(
 		}
 	}
 
+	// freshness and anomaly checks only run a single query against the asset, so they can run on any asset that can
+	// run custom checks
+	freshnessCheckRunner := ansisql.NewFreshnessCheckOperator(conn)
+	anomalyCheckRunner := ansisql.NewAnomalyCheckOperator(conn, metricHistory)
+	for _, executors := range mainExecutors {
+		if _, ok := executors[scheduler.TaskInstanceTypeCustomCheck]; ok {
+			executors[scheduler.TaskInstanceTypeFreshnessCheck] = freshnessCheckRunner
+			executors[scheduler.TaskInstanceTypeAnomalyCheck] = anomalyCheckRunner
+		}
+	}
+
 	return mainExecutors, nil
 }
 
+func willRunAnomalyChecks(s *scheduler.Scheduler) bool {
+	for _, instance := range s.GetTaskInstancesByStatus(scheduler.Pending) {
+		if instance.GetType() == scheduler.TaskInstanceTypeAnomalyCheck {
+			return true
+		}
+	}
+
+	return false
+}
+
 func isPathReferencingAsset(p string) bool {
 	// Check if the path matches any of the pipeline definition file names
 	for _, pipelineDefinitionfile := range pipelineDefinitionFiles {
@@ -898,53 +1358,27 @@ func (c *clearFileWriter) Write(p []byte) (int, error) {
 	return len(p), err
 }
 
-func logOutput(logPath string) (func(), error) {
+func logOutput(logPath string, output io.Writer) (io.Writer, func(), error) {
 	err := os.MkdirAll(filepath.Dir(logPath), 0o755)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create log directory")
+		return nil, nil, errors.Wrap(err, "failed to create log directory")
 	}
 
 	// open file read/write | create if not exist | clear file at open if exists
 	f, err := os.OpenFile(logPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to open log file")
-	}
-
-	// save existing stdout | MultiWriter writes to saved stdout and file
-	mw := io.MultiWriter(os.Stdout, &clearFileWriter{f, sync.Mutex{}})
-
-	// get pipe reader and writer | writes to pipe writer come out pipe reader
-	r, w, err := os.Pipe()
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create log pipe")
+		return nil, nil, errors.Wrap(err, "failed to open log file")
 	}
 
-	// replace stdout,stderr with pipe writer | all writes to stdout, stderr will go through pipe instead (fmt.print, log)
-	os.Stdout = w
-	os.Stderr = w
+	// MultiWriter writes to the given output and file
+	mw := io.MultiWriter(output, &clearFileWriter{f, sync.Mutex{}})
 
 	// writes with log.Print should also write to mw
 	log.SetOutput(mw)
 
-	// create channel to control exit | will block until all copies are finished
-	exit := make(chan bool)
-
-	go func() {
-		// copy all reads from pipe to multiwriter, which writes to stdout and file
-		_, err := io.Copy(mw, r)
-		if err != nil {
-			panic(err)
-		}
-		// when r or w is closed copy will finish and true will be sent to channel
-		exit <- true
-	}()
-
-	// function to be deferred in main until program exits
-	return func() {
-		// close writer then block on exit channel | this will let mw finish writing before the program exits
-		_ = w.Close()
-		<-exit
-		// close file after all writes have finished
+	// the writer is passed down to the printers and the executors of the run, the function is to be deferred in main
+	// until program exits
+	return mw, func() {
 		_ = f.Close()
 	}, nil
 }
@@ -977,6 +1411,8 @@ type Filter struct {
 	PushMetaData      bool
 	SingleTask        *pipeline.Asset
 	ExcludeTag        string
+	ChangedSince      string            // Git ref to run the changed assets against (from `--changed-since`)
+	ChangedAssets     []*pipeline.Asset // Assets changed since ChangedSince, their downstream is run as well
 }
 
 func (f *Filter) ApplyFiltersAndMarkAssets(pipeline *pipeline.Pipeline, s *scheduler.Scheduler) error {
@@ -998,16 +1434,29 @@ func (f *Filter) ApplyFiltersAndMarkAssets(pipeline *pipeline.Pipeline, s *sched
 		if f.ExcludeTag != "" {
 			return errors.New("you cannot use the '--exclude-tag' flag when running a single asset")
 		}
+
+		if f.ChangedSince != "" {
+			return errors.New("you cannot use the '--changed-since' flag when running a single asset")
+		}
+	}
+
+	// Handle changed assets: only the changed assets and everything downstream of them are run
+	if f.ChangedSince != "" {
+		if f.IncludeTag != "" {
+			return errors.New("you cannot use the '--tag' flag together with '--changed-since'")
+		}
+
+		s.MarkAll(scheduler.Skipped)
+		for _, asset := range f.ChangedAssets {
+			s.MarkAsset(asset, scheduler.Pending, true)
+		}
 	}
 	// Default task execution flags
 	runMain := true
 	runChecks := true
+	// the platform flags are not promoted to global, the metadata push instances exist only for the enabled platforms
 	runPushMetadata := f.PushMetaData || pipeline.MetadataPush.HasAnyEnabled()
 
-	if runPushMetadata {
-		pipeline.MetadataPush.Global = true
-	}
-
 	// Apply task-type filtering if specified
 	if len(f.OnlyTaskTypes) > 0 {
 		// Validate the provided task types
@@ -1047,6 +1496,8 @@ func (f *Filter) ApplyFiltersAndMarkAssets(pipeline *pipeline.Pipeline, s *sched
 	if !runChecks {
 		s.MarkPendingInstancesByType(scheduler.TaskInstanceTypeColumnCheck, scheduler.Skipped)
 		s.MarkPendingInstancesByType(scheduler.TaskInstanceTypeCustomCheck, scheduler.Skipped)
+		s.MarkPendingInstancesByType(scheduler.TaskInstanceTypeFreshnessCheck, scheduler.Skipped)
+		s.MarkPendingInstancesByType(scheduler.TaskInstanceTypeAnomalyCheck, scheduler.Skipped)
 	}
 	if !runPushMetadata {
 		s.MarkPendingInstancesByType(scheduler.TaskInstanceTypeMetadataPush, scheduler.Skipped)
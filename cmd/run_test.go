@@ -1,12 +1,19 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/bruin-data/bruin/pkg/lint"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -207,6 +214,48 @@ func TestApplyFilters(t *testing.T) {
 			expectedPending: []string{"Task1"},
 			expectError:     false,
 		},
+		{
+			name: "Changed Since Runs The Changed Assets And Their Downstream",
+			pipeline: &pipeline.Pipeline{
+				Name: "TestPipeline",
+				Assets: []*pipeline.Asset{
+					{Name: "Task1", Type: pipeline.AssetTypePython},
+					{Name: "Task2", Type: pipeline.AssetTypeBigqueryQuery, Upstreams: []pipeline.Upstream{{Type: "asset", Value: "Task1"}}},
+					{Name: "Task3", Type: pipeline.AssetTypePython},
+				},
+				MetadataPush: pipeline.MetadataPush{Global: false, BigQuery: false},
+			},
+			filter: &Filter{
+				ChangedSince:  "origin/main",
+				ChangedAssets: []*pipeline.Asset{{Name: "Task1"}},
+			},
+			expectedPending: []string{"Task1", "Task2"},
+		},
+		{
+			name: "Changed Since Without Changes Runs Nothing",
+			pipeline: &pipeline.Pipeline{
+				Name: "TestPipeline",
+				Assets: []*pipeline.Asset{
+					{Name: "Task1", Type: pipeline.AssetTypePython},
+				},
+				MetadataPush: pipeline.MetadataPush{Global: false, BigQuery: false},
+			},
+			filter:          &Filter{ChangedSince: "origin/main"},
+			expectedPending: []string{},
+		},
+		{
+			name: "Changed Since Cannot Be Used With Include Tag",
+			pipeline: &pipeline.Pipeline{
+				Name: "TestPipeline",
+				Assets: []*pipeline.Asset{
+					{Name: "Task1", Type: pipeline.AssetTypePython, Tags: []string{"tag1"}},
+				},
+				MetadataPush: pipeline.MetadataPush{Global: false, BigQuery: false},
+			},
+			filter:        &Filter{ChangedSince: "origin/main", IncludeTag: "tag1"},
+			expectError:   true,
+			expectedError: "you cannot use the '--tag' flag together with '--changed-since'",
+		},
 		{
 			name: "Include Tag Matches",
 			pipeline: &pipeline.Pipeline{
@@ -1009,6 +1058,60 @@ func TestApplyFilters(t *testing.T) {
 	}
 }
 
+func TestApplyFilters_MetadataPushForMixedPlatforms(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		metadataPush    pipeline.MetadataPush
+		filter          *Filter
+		expectedPending []string
+	}{
+		{
+			name:            "bigquery flag only pushes the bigquery assets",
+			metadataPush:    pipeline.MetadataPush{BigQuery: true},
+			filter:          &Filter{},
+			expectedPending: []string{"bq_asset", "bq_asset:metadata-push", "duckdb_asset"},
+		},
+		{
+			name:            "duckdb flag only pushes the duckdb assets",
+			metadataPush:    pipeline.MetadataPush{DuckDB: true},
+			filter:          &Filter{},
+			expectedPending: []string{"bq_asset", "duckdb_asset", "duckdb_asset:metadata-push"},
+		},
+		{
+			name:            "push-metadata flag pushes all the assets",
+			metadataPush:    pipeline.MetadataPush{Global: true},
+			filter:          &Filter{PushMetaData: true},
+			expectedPending: []string{"bq_asset", "bq_asset:metadata-push", "duckdb_asset", "duckdb_asset:metadata-push"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &pipeline.Pipeline{
+				Name: "TestPipeline",
+				Assets: []*pipeline.Asset{
+					{Name: "bq_asset", Type: pipeline.AssetTypeBigqueryQuery},
+					{Name: "duckdb_asset", Type: pipeline.AssetTypeDuckDBQuery},
+				},
+				MetadataPush: tt.metadataPush,
+			}
+
+			s := scheduler.NewScheduler(zap.NewNop().Sugar(), p, "test")
+			require.NoError(t, tt.filter.ApplyFiltersAndMarkAssets(p, s))
+
+			pending := make([]string, 0)
+			for _, ti := range s.GetTaskInstancesByStatus(scheduler.Pending) {
+				pending = append(pending, ti.GetHumanID())
+			}
+			assert.ElementsMatch(t, tt.expectedPending, pending)
+			assert.Equal(t, tt.metadataPush.Global, p.MetadataPush.Global)
+		})
+	}
+}
+
 func TestParseDate(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -1053,7 +1156,7 @@ func TestParseDate(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			logger := zaptest.NewLogger(t).Sugar()
-			startDate, endDate, err := ParseDate(tt.startDateStr, tt.endDateStr, logger)
+			startDate, endDate, err := ParseDate(tt.startDateStr, tt.endDateStr, logger, io.Discard)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -1066,6 +1169,58 @@ func TestParseDate(t *testing.T) {
 	}
 }
 
+func TestParseVariableOverrides(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:   "no variables",
+			values: nil,
+			want:   map[string]string{},
+		},
+		{
+			name:   "values can contain equal signs",
+			values: []string{"segment=smb", "filter=a=b", `countries=["US", "FR"]`, "empty="},
+			want: map[string]string{
+				"segment":   "smb",
+				"filter":    "a=b",
+				"countries": `["US", "FR"]`,
+				"empty":     "",
+			},
+		},
+		{
+			name:    "missing value",
+			values:  []string{"segment"},
+			wantErr: true,
+		},
+		{
+			name:    "missing name",
+			values:  []string{"=smb"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseVariableOverrides(tt.values)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestValidation(t *testing.T) {
 	t.Parallel()
 	logger := zaptest.NewLogger(t).Sugar()
@@ -1121,7 +1276,7 @@ func TestValidation(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel() // Enable parallel execution for individual test cases
-			startDate, endDate, path, err := ValidateRunConfig(tt.runConfig, tt.inputPath, logger)
+			startDate, endDate, path, err := ValidateRunConfig(tt.runConfig, tt.inputPath, logger, io.Discard)
 
 			if tt.expectError {
 				require.Error(t, err)
@@ -1166,7 +1321,7 @@ func TestCheckLintFunc(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			logger := zaptest.NewLogger(t).Sugar()
-			err := CheckLint(tt.foundPipeline, tt.pipelinePath, logger, nil)
+			err := CheckLint(context.Background(), tt.foundPipeline, tt.pipelinePath, logger, nil, nil, io.Discard)
 			require.NoError(t, err, "Expected no error but got one")
 		})
 	}
@@ -1279,7 +1434,7 @@ func TestReadState(t *testing.T) {
 			filter := tt.filter
 			runConfig := tt.runConfig
 
-			pipelineState, err := ReadState(fs, tt.statePath, filter)
+			pipelineState, err := ReadState(fs, tt.statePath, filter, io.Discard)
 
 			if tt.expectedError {
 				require.Error(t, err)
@@ -1293,3 +1448,104 @@ func TestReadState(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRunSummary(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{Name: "raw.orders"}
+	newInstance := func(humanID string, status scheduler.TaskInstanceStatus) scheduler.TaskInstance {
+		instance := &scheduler.ColumnCheckInstance{
+			AssetInstance: &scheduler.AssetInstance{Asset: asset, HumanID: humanID},
+			Column:        &pipeline.Column{Name: "id"},
+			Check:         &pipeline.ColumnCheck{Name: "unique"},
+		}
+		instance.MarkAs(status)
+		return instance
+	}
+
+	results := []*scheduler.TaskExecutionResult{
+		{Instance: newInstance("raw.orders:id:not_null", scheduler.Succeeded)},
+		{Instance: newInstance("raw.orders:id:unique", scheduler.Warned), Error: &scheduler.FailingRowsError{
+			Err:    errors.New("column 'id' has 3 non-unique values"),
+			Sample: scheduler.NewRowSample([]string{"id", "duplicate_count"}, [][]interface{}{{int64(7), int64(4)}}),
+		}},
+	}
+
+	errorsInTaskResults, warningsInTaskResults := splitFailedResults(results)
+	assert.Empty(t, errorsInTaskResults)
+	require.Len(t, warningsInTaskResults, 1)
+
+	expectedWarnings := []*taskResultSummary{
+		{
+			Asset:  "raw.orders",
+			Task:   "raw.orders:id:unique",
+			Type:   "column_test",
+			Error:  "column 'id' has 3 non-unique values",
+			Sample: &scheduler.RowSample{Columns: []string{"id", "duplicate_count"}, Rows: [][]interface{}{{int64(7), int64(4)}}},
+		},
+	}
+
+	summary := newRunSummary("pipeline", "run", errorsInTaskResults, warningsInTaskResults, false)
+	assert.Equal(t, &runSummary{
+		Pipeline: "pipeline",
+		RunID:    "run",
+		Status:   "warned",
+		Errors:   []*taskResultSummary{},
+		Warnings: expectedWarnings,
+	}, summary)
+
+	summary = newRunSummary("pipeline", "run", errorsInTaskResults, warningsInTaskResults, true)
+	assert.Equal(t, "failed", summary.Status)
+
+	results = append(results, &scheduler.TaskExecutionResult{
+		Instance: newInstance("raw.orders", scheduler.Failed),
+		Error:    errors.New("query failed"),
+	})
+	errorsInTaskResults, warningsInTaskResults = splitFailedResults(results)
+	assert.Len(t, errorsInTaskResults, 1)
+	assert.Len(t, warningsInTaskResults, 1)
+	assert.Equal(t, "failed", newRunSummary("pipeline", "run", errorsInTaskResults, warningsInTaskResults, false).Status)
+}
+
+func TestRunSummary_AddSchemaDrift(t *testing.T) {
+	t.Parallel()
+
+	summary := newRunSummary("pipeline", "run", nil, nil, false)
+	summary.addSchemaDrift(nil)
+	assert.Equal(t, "succeeded", summary.Status)
+	assert.Nil(t, summary.SchemaDrift)
+
+	summary.addSchemaDrift([]*lint.Issue{
+		{
+			Task:        &pipeline.Asset{Name: "raw.orders"},
+			Description: "Column 'amount' is defined in the asset but does not exist in the table",
+		},
+	})
+	assert.Equal(t, "failed", summary.Status)
+	assert.Equal(t, []*schemaDriftSummary{
+		{Asset: "raw.orders", Description: "Column 'amount' is defined in the asset but does not exist in the table"},
+	}, summary.SchemaDrift)
+}
+
+//nolint:paralleltest
+func TestLogOutput(t *testing.T) {
+	stdout := os.Stdout
+	defer log.SetOutput(os.Stderr)
+
+	logPath := filepath.Join(t.TempDir(), "logs", "run.log")
+	output := bytes.NewBuffer(nil)
+	w, closeLog, err := logOutput(logPath, output)
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("\u001B[94mhello\u001B[0m\n"))
+	require.NoError(t, err)
+	closeLog()
+
+	// the process-wide output is left as is, only the given writer is copied to the log file
+	assert.Same(t, stdout, os.Stdout)
+	assert.Equal(t, "\u001B[94mhello\u001B[0m\n", output.String())
+
+	content, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", string(content))
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/date"
+	"github.com/bruin-data/bruin/pkg/jinja"
+	"github.com/bruin-data/bruin/pkg/path"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/bruin-data/bruin/pkg/sqltest"
+	"github.com/bruin-data/bruin/pkg/telemetry"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v2"
+)
+
+func TestCmd() *cli.Command {
+	return &cli.Command{
+		Name:      "test",
+		Usage:     "run the tests of the SQL assets on DuckDB against the fixtures defined in the assets",
+		ArgsUsage: "[path to an asset or a pipeline]",
+		Flags: []cli.Flag{
+			startDateFlag,
+			endDateFlag,
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "the output type, possible values are: plain, json",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			defer RecoverFromPanic()
+
+			output := c.String("output")
+			inputPath := c.Args().Get(0)
+			if inputPath == "" {
+				inputPath = "."
+			}
+
+			startDate, err := date.ParseTime(c.String("start-date"))
+			if err != nil {
+				printError(err, output, "Please give a valid start date in the YYYY-MM-DD or YYYY-MM-DD HH:MM:SS formats")
+				return cli.Exit("", 1)
+			}
+
+			endDate, err := date.ParseTime(c.String("end-date"))
+			if err != nil {
+				printError(err, output, "Please give a valid end date in the YYYY-MM-DD or YYYY-MM-DD HH:MM:SS formats")
+				return cli.Exit("", 1)
+			}
+
+			pipelinePath := inputPath
+			if isPathReferencingAsset(inputPath) {
+				pipelinePath, err = path.GetPipelineRootFromTask(inputPath, pipelineDefinitionFiles)
+				if err != nil {
+					printError(err, output, "Failed to find the pipeline of the asset")
+					return cli.Exit("", 1)
+				}
+			}
+
+			foundPipeline, err := DefaultPipelineBuilder.CreatePipelineFromPath(pipelinePath, true)
+			if err != nil {
+				printError(err, output, fmt.Sprintf("Failed to build the pipeline at '%s'", pipelinePath))
+				return cli.Exit("", 1)
+			}
+
+			assets := foundPipeline.Assets
+			if isPathReferencingAsset(inputPath) {
+				asset := foundPipeline.GetAssetByPath(inputPath)
+				if asset == nil {
+					printError(errors.Errorf("no asset found at '%s'", inputPath), output, "Failed to find the asset")
+					return cli.Exit("", 1)
+				}
+				assets = []*pipeline.Asset{asset}
+			}
+
+			macros, err := loadMacrosForPath(inputPath)
+			if err != nil {
+				printError(err, output, "Failed to load the macros")
+				return cli.Exit("", 1)
+			}
+
+			parser, err := newSQLParserForPath(inputPath)
+			if err != nil {
+				printError(err, output, "Could not initialize the SQL parser")
+				return cli.Exit("", 1)
+			}
+			defer parser.Close()
+
+			runner := sqltest.NewRunner(&query.WholeFileExtractor{
+				Fs:       fs,
+				Renderer: jinja.NewRendererWithStartEndDates(&startDate, &endDate, foundPipeline.Name, "your-run-id").WithMacros(macros),
+			}, parser)
+
+			results := runSQLTests(c.Context, runner, foundPipeline, assets)
+
+			failed := 0
+			for _, result := range results {
+				if !result.Passed {
+					failed++
+				}
+			}
+
+			if output == "json" {
+				res, err := json.MarshalIndent(struct {
+					Pipeline string            `json:"pipeline"`
+					Passed   int               `json:"passed"`
+					Failed   int               `json:"failed"`
+					Results  []*sqltest.Result `json:"results"`
+				}{
+					Pipeline: foundPipeline.Name,
+					Passed:   len(results) - failed,
+					Failed:   failed,
+					Results:  results,
+				}, "", "  ")
+				if err != nil {
+					printError(err, output, "Failed to marshal the test results")
+					return cli.Exit("", 1)
+				}
+
+				fmt.Println(string(res))
+			} else {
+				printSQLTestResults(results, failed)
+			}
+
+			if failed > 0 {
+				return cli.Exit("", 1)
+			}
+
+			return nil
+		},
+		Before: telemetry.BeforeCommand,
+		After:  telemetry.AfterCommand,
+	}
+}
+
+func runSQLTests(ctx context.Context, runner *sqltest.Runner, p *pipeline.Pipeline, assets []*pipeline.Asset) []*sqltest.Result {
+	sorted := make([]*pipeline.Asset, len(assets))
+	copy(sorted, assets)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	results := make([]*sqltest.Result, 0)
+	for _, asset := range sorted {
+		results = append(results, runner.RunAsset(ctx, p, asset)...)
+	}
+
+	return results
+}
+
+func printSQLTestResults(results []*sqltest.Result, failed int) {
+	if len(results) == 0 {
+		infoPrinter.Println("No assets with tests found.")
+		return
+	}
+
+	for _, result := range results {
+		name := fmt.Sprintf("%s: %s", result.Asset, result.Test)
+		if result.Passed {
+			successPrinter.Printf("  ✓ %s\n", name)
+			continue
+		}
+
+		errorPrinter.Printf("  ✘ %s\n", name)
+		if result.Error != "" {
+			errorPrinter.Printf("      %s\n", result.Error)
+			continue
+		}
+
+		printSQLTestRows("missing", result.Missing, result.Columns)
+		printSQLTestRows("unexpected", result.Unexpected, result.Columns)
+	}
+
+	fmt.Println()
+	summary := fmt.Sprintf("%d passed, %d failed", len(results)-failed, failed)
+	if failed > 0 {
+		errorPrinter.Println(summary)
+	} else {
+		successPrinter.Println(summary)
+	}
+}
+
+func printSQLTestRows(kind string, rows []sqltest.Row, columns []string) {
+	if len(rows) == 0 {
+		return
+	}
+
+	errorPrinter.Printf("      %d %s rows:\n", len(rows), kind)
+	for _, row := range rows {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			values[i] = column + "=" + row[column]
+		}
+		fmt.Printf("        %s\n", strings.Join(values, ", "))
+	}
+}
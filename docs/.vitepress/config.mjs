@@ -140,7 +140,7 @@ export default defineConfig({
                     {
                         text: " Jinja Templating",
                         link: "/assets/templating/templating",
-                        items: [{text: "Filters", link: "/assets/templating/filters"}],
+                        items: [{text: "Filters", link: "/assets/templating/filters"}, {text: "Macros", link: "/assets/templating/macros"}],
                     },
                 ],
             },
@@ -196,6 +196,8 @@ export default defineConfig({
                     },
                     {text: "Column Checks", link: "/quality/available_checks"},
                     {text: "Custom Checks", link: "/quality/custom"},
+                    {text: "Freshness Checks", link: "/quality/freshness"},
+                    {text: "Anomaly Checks", link: "/quality/anomaly"},
                 ],
             },
             {
@@ -209,13 +211,19 @@ export default defineConfig({
                 items: [
                     {text: "Clean", link: "/commands/clean"},
                     {text: "Connections", link: "/commands/connections.md"},
+                    {text: "Docs", link: "/commands/docs"},
                     {text: "Environments", link: "/commands/environments"},
                     {text: "Format", link: "/commands/format"},
+                    {text: "Glossary", link: "/commands/glossary"},
+                    {text: "Impact", link: "/commands/impact"},
                     {text: "Init", link: "/commands/init"},
                     {text: "Lineage", link: "/commands/lineage"},
+                    {text: "Monitor", link: "/commands/monitor"},
                     {text: "Render", link: "/commands/render"},
                     {text: "Run", link: "/commands/run"},
+                    {text: "Test", link: "/commands/test"},
                     {text: "Query", link: "/commands/query"},
+                    {text: "Import", link: "/commands/import"},
                     {text: "Validate", link: "/commands/validate"},
                 ],
 
@@ -63,15 +63,20 @@ func main() {
 			cmd.Lint(&isDebug),
 			cmd.Run(&isDebug),
 			cmd.Render(),
-			cmd.Lineage(),
+			cmd.Lineage(&isDebug),
+			cmd.Impact(),
+			cmd.Monitor(),
+			cmd.TestCmd(),
 			cmd.CleanCmd(),
 			cmd.Format(&isDebug),
 			cmd.Docs(),
+			cmd.Glossary(),
 			cmd.Init(),
 			cmd.Internal(),
 			cmd.Environments(&isDebug),
 			cmd.Connections(),
 			cmd.Query(),
+			cmd.Import(&isDebug),
 			versionCommand,
 		},
 	}
@@ -0,0 +1,274 @@
+package ansisql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/pkg/errors"
+)
+
+// AnomalyMetricQuery returns the query that computes the metric of the anomaly check for the asset.
+func AnomalyMetricQuery(assetName string, check *pipeline.AnomalyCheck) (string, error) {
+	var metric string
+	switch check.Metric {
+	case pipeline.AnomalyMetricRowCount:
+		metric = "count(*)"
+	case pipeline.AnomalyMetricSum:
+		metric = fmt.Sprintf("SUM(%s)", check.Column)
+	case pipeline.AnomalyMetricAvg:
+		// multiplying by 1.0 avoids the integer average some platforms return for integer columns
+		metric = fmt.Sprintf("AVG(%s * 1.0)", check.Column)
+	case pipeline.AnomalyMetricNullRatio:
+		metric = fmt.Sprintf("SUM(CASE WHEN %s IS NULL THEN 1.0 ELSE 0.0 END) / NULLIF(count(*), 0)", check.Column)
+	default:
+		return "", errors.Errorf("unknown anomaly metric '%s', supported metrics are: %s", check.Metric, strings.Join(pipeline.AnomalyMetrics, ", "))
+	}
+
+	if check.MetricNeedsColumn() && check.Column == "" {
+		return "", errors.Errorf("the '%s' metric requires a column", check.Metric)
+	}
+
+	return fmt.Sprintf("SELECT %s FROM %s", metric, assetName), nil
+}
+
+// AnomalyResult is the outcome of comparing the current value of a metric with its history.
+type AnomalyResult struct {
+	Value       float64
+	HistorySize int
+	Mean        float64
+	Stddev      float64
+	Evaluated   bool
+	Anomalous   bool
+	Reason      string
+}
+
+// EvaluateAnomaly compares the value with the trailing window of the previous values, given from the oldest to the
+// newest. The value is not evaluated until there are at least `min_history` previous values.
+func EvaluateAnomaly(check *pipeline.AnomalyCheck, value float64, history []float64) *AnomalyResult {
+	if window := check.WindowSize(); len(history) > window {
+		history = history[len(history)-window:]
+	}
+
+	result := &AnomalyResult{Value: value, HistorySize: len(history)}
+	if len(history) < check.MinHistorySize() {
+		result.Reason = fmt.Sprintf("only %d previous values are recorded, at least %d are needed to detect anomalies", len(history), check.MinHistorySize())
+		return result
+	}
+
+	result.Evaluated = true
+	result.Mean, result.Stddev = meanAndStddev(history)
+
+	// a constant history has no deviation to compare with, the percent change covers that case
+	if check.MaxStddev > 0 && result.Stddev > 0 {
+		deviations := math.Abs(value-result.Mean) / result.Stddev
+		if deviations > check.MaxStddev {
+			result.Anomalous = true
+			result.Reason = fmt.Sprintf(
+				"the value %s is %.2f standard deviations away from the mean %s of the last %d values, the maximum allowed is %s",
+				formatFloat(value), deviations, formatFloat(result.Mean), len(history), formatFloat(check.MaxStddev),
+			)
+			return result
+		}
+	}
+
+	previous := history[len(history)-1]
+	if check.MaxChangePercent > 0 && previous != 0 {
+		change := math.Abs(value-previous) / math.Abs(previous) * 100
+		if change > check.MaxChangePercent {
+			result.Anomalous = true
+			result.Reason = fmt.Sprintf(
+				"the value %s changed %.2f%% compared to the previous value %s, the maximum allowed is %s%%",
+				formatFloat(value), change, formatFloat(previous), formatFloat(check.MaxChangePercent),
+			)
+		}
+	}
+
+	return result
+}
+
+func meanAndStddev(values []float64) (float64, float64) {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	if len(values) < 2 {
+		return mean, 0
+	}
+
+	squares := 0.0
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+
+	return mean, math.Sqrt(squares / float64(len(values)-1))
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// castResultToFloat converts the single value returned by a metric query to a float, an empty result such as the sum
+// of an empty table is returned as zero.
+func castResultToFloat(res [][]interface{}) (float64, error) {
+	if len(res) != 1 || len(res[0]) != 1 {
+		return 0, errors.Errorf("the metric query returned %d rows instead of a single value", len(res))
+	}
+
+	var raw string
+	switch v := res[0][0].(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case interface{ Float64() float64 }:
+		return v.Float64(), nil
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	case fmt.Stringer:
+		raw = v.String()
+	default:
+		return 0, errors.Errorf("unsupported metric value '%v' of type %T", v, v)
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	if err != nil {
+		return 0, errors.Errorf("the metric value '%s' is not a number", raw)
+	}
+
+	return value, nil
+}
+
+// AnomalyCheckOperator computes the metric of an anomaly check, compares it with the normal values recorded in the
+// previous runs and records it in the history.
+type AnomalyCheckOperator struct {
+	conn    connectionFetcher
+	history *MetricHistory
+	now     func() time.Time
+}
+
+func NewAnomalyCheckOperator(conn connectionFetcher, history *MetricHistory) *AnomalyCheckOperator {
+	return &AnomalyCheckOperator{conn: conn, history: history, now: time.Now}
+}
+
+func (o *AnomalyCheckOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error {
+	instance, ok := ti.(*scheduler.AnomalyCheckInstance)
+	if !ok {
+		return errors.New("cannot run a non-anomaly check instance")
+	}
+
+	asset := instance.GetAsset()
+	check := instance.Check
+
+	q, err := AnomalyMetricQuery(asset.Name, check)
+	if err != nil {
+		return err
+	}
+
+	connectionName, err := instance.GetPipeline().GetConnectionNameForAsset(asset)
+	if err != nil {
+		return err
+	}
+
+	conn, err := o.conn.GetConnection(connectionName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get connection '%s' for the anomaly check", connectionName)
+	}
+
+	s, ok := conn.(selector)
+	if !ok {
+		return errors.New("connection does not implement selector interface")
+	}
+
+	res, err := s.Select(ctx, &query.Query{Query: q})
+	if err != nil {
+		return errors.Wrap(err, "failed to run the anomaly check query")
+	}
+
+	value, err := castResultToFloat(res)
+	if err != nil {
+		return err
+	}
+
+	recorded, err := o.history.Values(asset.Name, check.Name)
+	if err != nil {
+		return err
+	}
+
+	runID, _ := ctx.Value(pipeline.RunConfigRunID).(string)
+	previous := make([]MetricValue, 0, len(recorded))
+	for _, v := range recorded {
+		if runID != "" && v.RunID == runID {
+			continue
+		}
+		// the values before the latest baseline reset belong to the previous level of the metric
+		if v.BaselineStart {
+			previous = previous[:0]
+		}
+		previous = append(previous, v)
+	}
+
+	// once the latest values are all anomalous the metric moved to a new level, so they become the new baseline and the
+	// reset is recorded for the later runs
+	streak := 0
+	for i := len(previous) - 1; i >= 0 && previous[i].Anomalous; i-- {
+		streak++
+	}
+	if streak >= check.RecoverAfterSize() {
+		previous = previous[len(previous)-streak:]
+		if err := o.history.ResetBaseline(asset.Name, check.Name, previous[0].RunID); err != nil {
+			return errors.Wrap(err, "failed to reset the baseline of the metric")
+		}
+		for i := range previous {
+			previous[i].Anomalous = false
+		}
+	}
+
+	history := make([]float64, 0, len(previous))
+	for _, v := range previous {
+		// the anomalous values would shift the baseline towards the anomalies, so they are left out
+		if v.Anomalous {
+			continue
+		}
+		history = append(history, v.Value)
+	}
+
+	result := EvaluateAnomaly(check, value, history)
+
+	err = o.history.Record(asset.Name, check.Name, MetricValue{
+		RunID:      runID,
+		RecordedAt: o.now().UTC(),
+		Value:      value,
+		Anomalous:  result.Anomalous,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to record the metric value")
+	}
+
+	if !result.Anomalous {
+		return nil
+	}
+
+	// the checks with the warning severity are not blocking, so the scheduler reports their failures as warnings
+	return errors.Errorf("anomaly detected in '%s': %s", check.Name, result.Reason)
+}
@@ -0,0 +1,376 @@
+package ansisql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnomalyMetricQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		check   *pipeline.AnomalyCheck
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "row count",
+			check: &pipeline.AnomalyCheck{Metric: pipeline.AnomalyMetricRowCount},
+			want:  "SELECT count(*) FROM dataset.orders",
+		},
+		{
+			name:  "sum",
+			check: &pipeline.AnomalyCheck{Metric: pipeline.AnomalyMetricSum, Column: "amount"},
+			want:  "SELECT SUM(amount) FROM dataset.orders",
+		},
+		{
+			name:  "avg",
+			check: &pipeline.AnomalyCheck{Metric: pipeline.AnomalyMetricAvg, Column: "amount"},
+			want:  "SELECT AVG(amount * 1.0) FROM dataset.orders",
+		},
+		{
+			name:  "null ratio",
+			check: &pipeline.AnomalyCheck{Metric: pipeline.AnomalyMetricNullRatio, Column: "email"},
+			want:  "SELECT SUM(CASE WHEN email IS NULL THEN 1.0 ELSE 0.0 END) / NULLIF(count(*), 0) FROM dataset.orders",
+		},
+		{
+			name:    "missing column",
+			check:   &pipeline.AnomalyCheck{Metric: pipeline.AnomalyMetricSum},
+			wantErr: "the 'sum' metric requires a column",
+		},
+		{
+			name:    "unknown metric",
+			check:   &pipeline.AnomalyCheck{Metric: "median"},
+			wantErr: "unknown anomaly metric 'median', supported metrics are: row_count, sum, avg, null_ratio",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := AnomalyMetricQuery("dataset.orders", tt.check)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEvaluateAnomaly(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		check         *pipeline.AnomalyCheck
+		value         float64
+		history       []float64
+		wantEvaluated bool
+		wantAnomalous bool
+		wantReason    string
+	}{
+		{
+			name:       "not enough history",
+			check:      &pipeline.AnomalyCheck{MaxStddev: 3},
+			value:      1000,
+			history:    []float64{100, 101},
+			wantReason: "only 2 previous values are recorded, at least 3 are needed to detect anomalies",
+		},
+		{
+			name:          "value within the standard deviation threshold",
+			check:         &pipeline.AnomalyCheck{MaxStddev: 3},
+			value:         104,
+			history:       []float64{100, 102, 98, 101, 99},
+			wantEvaluated: true,
+		},
+		{
+			name:          "value beyond the standard deviation threshold",
+			check:         &pipeline.AnomalyCheck{MaxStddev: 3},
+			value:         120,
+			history:       []float64{100, 102, 98, 101, 99},
+			wantEvaluated: true,
+			wantAnomalous: true,
+			wantReason:    "the value 120 is 12.65 standard deviations away from the mean 100 of the last 5 values, the maximum allowed is 3",
+		},
+		{
+			name:          "only the trailing window is used",
+			check:         &pipeline.AnomalyCheck{MaxStddev: 3, Window: 3},
+			value:         1010,
+			history:       []float64{100, 1000, 1020, 1005},
+			wantEvaluated: true,
+		},
+		{
+			name:          "constant history is only compared with the percent change",
+			check:         &pipeline.AnomalyCheck{MaxStddev: 3},
+			value:         101,
+			history:       []float64{100, 100, 100},
+			wantEvaluated: true,
+		},
+		{
+			name:          "value beyond the percent change threshold",
+			check:         &pipeline.AnomalyCheck{MaxChangePercent: 50},
+			value:         40,
+			history:       []float64{90, 100, 100},
+			wantEvaluated: true,
+			wantAnomalous: true,
+			wantReason:    "the value 40 changed 60.00% compared to the previous value 100, the maximum allowed is 50%",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := EvaluateAnomaly(tt.check, tt.value, tt.history)
+			assert.Equal(t, tt.wantEvaluated, got.Evaluated)
+			assert.Equal(t, tt.wantAnomalous, got.Anomalous)
+			assert.Equal(t, tt.wantReason, got.Reason)
+		})
+	}
+}
+
+func TestAnomalyCheckOperator_Run(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		value         interface{}
+		check         pipeline.AnomalyCheck
+		wantErr       string
+		wantAnomalous bool
+	}{
+		{
+			name:  "normal value",
+			value: int64(102),
+			check: pipeline.AnomalyCheck{Name: "row_count", Metric: pipeline.AnomalyMetricRowCount, MaxStddev: 3},
+		},
+		{
+			name:          "anomalous value fails the check",
+			value:         "250",
+			check:         pipeline.AnomalyCheck{Name: "row_count", Metric: pipeline.AnomalyMetricRowCount, MaxChangePercent: 50},
+			wantErr:       "anomaly detected in 'row_count': the value 250 changed 150.00% compared to the previous value 100, the maximum allowed is 50%",
+			wantAnomalous: true,
+		},
+		{
+			name:          "anomalous value with warning severity fails the non-blocking check",
+			value:         float64(250),
+			check:         pipeline.AnomalyCheck{Name: "row_count", Metric: pipeline.AnomalyMetricRowCount, MaxChangePercent: 50, Severity: pipeline.AnomalySeverityWarning},
+			wantErr:       "anomaly detected in 'row_count': the value 250 changed 150.00% compared to the previous value 100, the maximum allowed is 50%",
+			wantAnomalous: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			q := new(mockQuerierWithResult)
+			q.On("Select", mock.Anything, &query.Query{Query: "SELECT count(*) FROM dataset.orders"}).Return([][]interface{}{{tt.value}}, nil)
+
+			conn := new(mockConnectionFetcher)
+			conn.On("GetConnection", "gcp").Return(q, nil)
+
+			history := NewMetricHistory(afero.NewMemMapFs(), "logs/metrics/test")
+			for i, v := range []float64{98, 101, 100} {
+				require.NoError(t, history.Record("dataset.orders", "row_count", MetricValue{RunID: string(rune('a' + i)), Value: v}))
+			}
+
+			asset := &pipeline.Asset{Name: "dataset.orders", Type: pipeline.AssetTypeBigqueryQuery, Anomalies: []pipeline.AnomalyCheck{tt.check}}
+			p := &pipeline.Pipeline{
+				DefaultConnections: map[string]string{"google_cloud_platform": "gcp"},
+				Assets:             []*pipeline.Asset{asset},
+			}
+
+			instance := &scheduler.AnomalyCheckInstance{
+				AssetInstance: &scheduler.AssetInstance{Asset: asset, Pipeline: p},
+				Check:         &tt.check,
+			}
+
+			ctx := context.WithValue(context.Background(), pipeline.RunConfigRunID, "current")
+			err := NewAnomalyCheckOperator(conn, history).Run(ctx, instance)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			values, err := history.Values("dataset.orders", "row_count")
+			require.NoError(t, err)
+			require.Len(t, values, 4)
+			assert.Equal(t, "current", values[3].RunID)
+			assert.Equal(t, tt.wantAnomalous, values[3].Anomalous)
+		})
+	}
+}
+
+func TestAnomalyCheckOperator_Run_ExcludesAnomalousValues(t *testing.T) {
+	t.Parallel()
+
+	q := new(mockQuerierWithResult)
+	q.On("Select", mock.Anything, &query.Query{Query: "SELECT count(*) FROM dataset.orders"}).Return([][]interface{}{{int64(250)}}, nil)
+
+	conn := new(mockConnectionFetcher)
+	conn.On("GetConnection", "gcp").Return(q, nil)
+
+	// the previous anomalous value is not a part of the baseline, so the same value is reported again
+	history := NewMetricHistory(afero.NewMemMapFs(), "logs/metrics/test")
+	require.NoError(t, history.Record("dataset.orders", "row_count", MetricValue{RunID: "a", Value: 98}))
+	require.NoError(t, history.Record("dataset.orders", "row_count", MetricValue{RunID: "b", Value: 101}))
+	require.NoError(t, history.Record("dataset.orders", "row_count", MetricValue{RunID: "c", Value: 100}))
+	require.NoError(t, history.Record("dataset.orders", "row_count", MetricValue{RunID: "d", Value: 250, Anomalous: true}))
+
+	check := pipeline.AnomalyCheck{Name: "row_count", Metric: pipeline.AnomalyMetricRowCount, MaxChangePercent: 50}
+	asset := &pipeline.Asset{Name: "dataset.orders", Type: pipeline.AssetTypeBigqueryQuery, Anomalies: []pipeline.AnomalyCheck{check}}
+	p := &pipeline.Pipeline{
+		DefaultConnections: map[string]string{"google_cloud_platform": "gcp"},
+		Assets:             []*pipeline.Asset{asset},
+	}
+
+	instance := &scheduler.AnomalyCheckInstance{
+		AssetInstance: &scheduler.AssetInstance{Asset: asset, Pipeline: p},
+		Check:         &check,
+	}
+
+	ctx := context.WithValue(context.Background(), pipeline.RunConfigRunID, "current")
+	err := NewAnomalyCheckOperator(conn, history).Run(ctx, instance)
+	require.EqualError(t, err, "anomaly detected in 'row_count': the value 250 changed 150.00% compared to the previous value 100, the maximum allowed is 50%")
+}
+
+func TestAnomalyCheckOperator_Run_RecoversAfterConsecutiveAnomalies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		recoverAfter int
+		wantFlagged  []bool
+	}{
+		{
+			name:        "the default streak becomes the new baseline",
+			wantFlagged: []bool{true, true, true, false, false, false, false, false},
+		},
+		{
+			name:         "a longer recover_after flags more runs",
+			recoverAfter: 5,
+			wantFlagged:  []bool{true, true, true, true, true, false, false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			q := new(mockQuerierWithResult)
+			q.On("Select", mock.Anything, &query.Query{Query: "SELECT count(*) FROM dataset.orders"}).Return([][]interface{}{{int64(200)}}, nil)
+
+			conn := new(mockConnectionFetcher)
+			conn.On("GetConnection", "gcp").Return(q, nil)
+
+			// the metric is stable around 100 for a while, then it moves to 200 for good
+			history := NewMetricHistory(afero.NewMemMapFs(), "logs/metrics/test")
+			for i, v := range []float64{98, 101, 100, 102, 99, 100, 97, 103, 100, 101, 99, 100, 98, 102} {
+				require.NoError(t, history.Record("dataset.orders", "row_count", MetricValue{RunID: fmt.Sprintf("old-%d", i), Value: v}))
+			}
+
+			check := pipeline.AnomalyCheck{Name: "row_count", Metric: pipeline.AnomalyMetricRowCount, MaxStddev: 3, MaxChangePercent: 50, RecoverAfter: tt.recoverAfter}
+			asset := &pipeline.Asset{Name: "dataset.orders", Type: pipeline.AssetTypeBigqueryQuery, Anomalies: []pipeline.AnomalyCheck{check}}
+			p := &pipeline.Pipeline{
+				DefaultConnections: map[string]string{"google_cloud_platform": "gcp"},
+				Assets:             []*pipeline.Asset{asset},
+			}
+
+			instance := &scheduler.AnomalyCheckInstance{
+				AssetInstance: &scheduler.AssetInstance{Asset: asset, Pipeline: p},
+				Check:         &check,
+			}
+
+			operator := NewAnomalyCheckOperator(conn, history)
+			for i, wantFlagged := range tt.wantFlagged {
+				ctx := context.WithValue(context.Background(), pipeline.RunConfigRunID, fmt.Sprintf("new-%d", i))
+				err := operator.Run(ctx, instance)
+				if wantFlagged {
+					require.Error(t, err, "run %d", i)
+				} else {
+					require.NoError(t, err, "run %d", i)
+				}
+			}
+
+			// the accepted streak is no longer anomalous, and the baseline starts with its first value
+			values, err := history.Values("dataset.orders", "row_count")
+			require.NoError(t, err)
+			require.Len(t, values, 14+len(tt.wantFlagged))
+			for _, v := range values[14:] {
+				assert.False(t, v.Anomalous, v.RunID)
+				assert.Equal(t, v.RunID == "new-0", v.BaselineStart, v.RunID)
+			}
+		})
+	}
+}
+
+func TestMetricHistory_ResetBaseline(t *testing.T) {
+	t.Parallel()
+
+	history := NewMetricHistory(afero.NewMemMapFs(), "logs/metrics/pipeline")
+	require.NoError(t, history.Record("raw.orders", "row_count", MetricValue{RunID: "a", Value: 100}))
+	require.NoError(t, history.Record("raw.orders", "row_count", MetricValue{RunID: "b", Value: 200, Anomalous: true}))
+	require.NoError(t, history.Record("raw.orders", "row_count", MetricValue{RunID: "c", Value: 200, Anomalous: true}))
+
+	require.EqualError(t, history.ResetBaseline("raw.orders", "row_count", "missing"), "there is no value recorded for the run 'missing' of the check 'row_count'")
+	require.NoError(t, history.ResetBaseline("raw.orders", "row_count", "b"))
+
+	values, err := history.Values("raw.orders", "row_count")
+	require.NoError(t, err)
+	assert.Equal(t, []MetricValue{
+		{RunID: "a", Value: 100},
+		{RunID: "b", Value: 200, BaselineStart: true},
+		{RunID: "c", Value: 200},
+	}, values)
+}
+
+func TestMetricHistory_Record(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+	history := NewMetricHistory(fs, "logs/metrics/pipeline")
+	recordedAt := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	values, err := history.Values("raw.orders", "row_count")
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	require.NoError(t, history.Record("raw.orders", "row_count", MetricValue{RunID: "run1", RecordedAt: recordedAt, Value: 10}))
+	require.NoError(t, history.Record("raw.orders", "row_count", MetricValue{RunID: "run2", RecordedAt: recordedAt, Value: 20}))
+	require.NoError(t, history.Record("raw.orders", "sum_amount", MetricValue{RunID: "run2", RecordedAt: recordedAt, Value: 5.5}))
+	require.NoError(t, history.Record("raw.orders", "row_count", MetricValue{RunID: "run2", RecordedAt: recordedAt, Value: 25}))
+
+	exists, err := afero.Exists(fs, "logs/metrics/pipeline/raw.orders.json")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	values, err = NewMetricHistory(fs, "logs/metrics/pipeline").Values("raw.orders", "row_count")
+	require.NoError(t, err)
+	assert.Equal(t, []MetricValue{
+		{RunID: "run1", RecordedAt: recordedAt, Value: 10},
+		{RunID: "run2", RecordedAt: recordedAt, Value: 25},
+	}, values)
+
+	for i := range metricHistoryLimit + 5 {
+		require.NoError(t, history.Record("raw.orders", "sum_amount", MetricValue{RunID: time.Duration(i).String(), Value: float64(i)}))
+	}
+
+	values, err = history.Values("raw.orders", "sum_amount")
+	require.NoError(t, err)
+	assert.Len(t, values, metricHistoryLimit)
+	assert.InDelta(t, float64(metricHistoryLimit+4), values[len(values)-1].Value, 0)
+}
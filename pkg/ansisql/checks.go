@@ -3,9 +3,12 @@ package ansisql
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/bruin-data/bruin/pkg/helpers"
 	"github.com/bruin-data/bruin/pkg/jinja"
+	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/query"
 	"github.com/bruin-data/bruin/pkg/scheduler"
 	"github.com/pkg/errors"
@@ -19,12 +22,21 @@ type selector interface {
 	Select(ctx context.Context, query *query.Query) ([][]interface{}, error)
 }
 
+type schemaSelector interface {
+	SelectWithSchema(ctx context.Context, queryObj *query.Query) (*query.QueryResult, error)
+}
+
+type limiter interface {
+	Limit(query string, limit int64) string
+}
+
 type CountableQueryCheck struct {
 	conn                connectionFetcher
 	expectedQueryResult int64
 	queryInstance       *query.Query
 	checkName           string
 	customError         func(count int64) error
+	failingRowsQuery    string
 }
 
 func NewCountableQueryCheck(conn connectionFetcher, expectedQueryResult int64, queryInstance *query.Query, checkName string, customError func(count int64) error) *CountableQueryCheck {
@@ -37,6 +49,13 @@ func NewCountableQueryCheck(conn connectionFetcher, expectedQueryResult int64, q
 	}
 }
 
+// WithFailingRowsQuery sets the query that returns the rows failing the check, a sample of them is fetched when the
+// check fails and sampling is enabled for the run.
+func (c *CountableQueryCheck) WithFailingRowsQuery(failingRowsQuery string) *CountableQueryCheck {
+	c.failingRowsQuery = failingRowsQuery
+	return c
+}
+
 func (c *CountableQueryCheck) Check(ctx context.Context, ti *scheduler.ColumnCheckInstance) error {
 	conn, err := ti.Pipeline.GetConnectionNameForAsset(ti.GetAsset())
 	if err != nil {
@@ -77,12 +96,53 @@ func (c *CountableQueryCheck) check(ctx context.Context, connectionName string)
 	}
 
 	if count != c.expectedQueryResult {
-		return c.customError(count)
+		checkErr := c.customError(count)
+
+		// the sample is only there to help debugging the failure, the check error is returned as is if it cannot be fetched
+		sample, err := c.sampleFailingRows(ctx, q)
+		if err != nil || sample == nil || len(sample.Rows) == 0 {
+			return checkErr
+		}
+
+		return &scheduler.FailingRowsError{Err: checkErr, Sample: sample}
 	}
 
 	return nil
 }
 
+func (c *CountableQueryCheck) sampleFailingRows(ctx context.Context, conn interface{}) (*scheduler.RowSample, error) {
+	limit, ok := ctx.Value(pipeline.RunConfigFailingRowsSample).(int64)
+	if !ok || limit <= 0 || c.failingRowsQuery == "" {
+		return nil, nil
+	}
+
+	q := &query.Query{Query: fmt.Sprintf("SELECT * FROM (\n%s\n) as t LIMIT %d", c.failingRowsQuery, limit)}
+	if l, ok := conn.(limiter); ok {
+		q = &query.Query{Query: l.Limit(c.failingRowsQuery, limit)}
+	}
+
+	if s, ok := conn.(schemaSelector); ok {
+		res, err := s.SelectWithSchema(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+
+		return scheduler.NewRowSample(res.Columns, res.Rows), nil
+	}
+
+	s, ok := conn.(selector)
+	if !ok {
+		return nil, errors.New("connection does not implement selector interface")
+	}
+
+	rows, err := s.Select(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduler.NewRowSample(nil, rows), nil
+}
+
 type NotNullCheck struct {
 	conn connectionFetcher
 }
@@ -102,6 +162,7 @@ func (c *NotNullCheck) Check(ctx context.Context, ti *scheduler.ColumnCheckInsta
 		customError: func(count int64) error {
 			return errors.Errorf("column '%s' has %d null values", ti.Column.Name, count)
 		},
+		failingRowsQuery: fmt.Sprintf("SELECT * FROM %s WHERE %s IS NULL", ti.GetAsset().Name, ti.Column.Name),
 	}).Check(ctx, ti)
 }
 
@@ -122,9 +183,18 @@ func (c *UniqueCheck) Check(ctx context.Context, ti *scheduler.ColumnCheckInstan
 		customError: func(count int64) error {
 			return errors.Errorf("column '%s' has %d non-unique values", ti.Column.Name, count)
 		},
+		failingRowsQuery: DuplicateValuesQuery(ti.GetAsset().Name, ti.Column.Name),
 	}).Check(ctx, ti)
 }
 
+// DuplicateValuesQuery returns the duplicated values of the column along with the number of times they occur.
+func DuplicateValuesQuery(assetName, columnName string) string {
+	return fmt.Sprintf(
+		"SELECT %s, COUNT(*) AS duplicate_count FROM %s WHERE %s IS NOT NULL GROUP BY %s HAVING COUNT(*) > 1",
+		columnName, assetName, columnName, columnName,
+	)
+}
+
 type PositiveCheck struct {
 	conn connectionFetcher
 }
@@ -185,8 +255,119 @@ func (c *NegativeCheck) Check(ctx context.Context, ti *scheduler.ColumnCheckInst
 	}).Check(ctx, ti)
 }
 
+// BoundaryCheck ensures that none of the values in a column are beyond a boundary, it backs the `min` and `max`
+// checks for numeric, date and timestamp columns.
+type BoundaryCheck struct {
+	conn        connectionFetcher
+	checkName   string
+	operator    string
+	description string
+}
+
+// NewMinCheck returns a check that fails if any of the values in the column are less than the check value.
+func NewMinCheck(conn connectionFetcher) *BoundaryCheck {
+	return &BoundaryCheck{conn: conn, checkName: "min", operator: "<", description: "less than the minimum"}
+}
+
+// NewMaxCheck returns a check that fails if any of the values in the column are greater than the check value.
+func NewMaxCheck(conn connectionFetcher) *BoundaryCheck {
+	return &BoundaryCheck{conn: conn, checkName: "max", operator: ">", description: "greater than the maximum"}
+}
+
+func (c *BoundaryCheck) Check(ctx context.Context, ti *scheduler.ColumnCheckInstance) error {
+	boundary, err := BoundaryLiteral(ti.Check.Value, ti.Column.Type)
+	if err != nil {
+		return errors.Wrapf(err, "invalid value for '%s' check", c.checkName)
+	}
+
+	qq := fmt.Sprintf("SELECT count(*) FROM %s WHERE %s %s %s", ti.GetAsset().Name, ti.Column.Name, c.operator, boundary)
+	return NewCountableQueryCheck(c.conn, 0, &query.Query{Query: qq}, c.checkName, func(count int64) error {
+		return errors.Errorf("column '%s' has %d values %s %s", ti.Column.Name, count, c.description, ti.Check.Value.ToString())
+	}).Check(ctx, ti)
+}
+
+// BoundaryLiteral returns the SQL literal for the value of a `min` or `max` check. Numbers are used as is, while
+// strings are quoted and cast to the column type if the column declares one, so that dates and timestamps are
+// compared as such on the platforms that do not coerce string literals.
+func BoundaryLiteral(value pipeline.ColumnCheckValue, columnType string) (string, error) {
+	switch {
+	case value.Int != nil:
+		return strconv.Itoa(*value.Int), nil
+	case value.Float != nil:
+		return strconv.FormatFloat(*value.Float, 'f', -1, 64), nil
+	case value.String != nil && *value.String != "":
+		literal := "'" + strings.ReplaceAll(*value.String, "'", "''") + "'"
+		if columnType = strings.TrimSpace(columnType); columnType != "" {
+			return fmt.Sprintf("CAST(%s AS %s)", literal, columnType), nil
+		}
+		return literal, nil
+	default:
+		return "", errors.Errorf("the value must be a number, a date or a timestamp, got '%s'", value.ToString())
+	}
+}
+
+// RelationshipsCheck ensures that every non-null value in a column exists in a column of another asset, it backs
+// the `relationships` and `references` checks.
+type RelationshipsCheck struct {
+	conn connectionFetcher
+}
+
+func NewRelationshipsCheck(conn connectionFetcher) *RelationshipsCheck {
+	return &RelationshipsCheck{conn: conn}
+}
+
+func (c *RelationshipsCheck) Check(ctx context.Context, ti *scheduler.ColumnCheckInstance) error {
+	p := ti.GetPipeline()
+	referencedAsset, referencedColumn, err := p.ColumnCheckReference(ti.GetAsset(), ti.Column, ti.Check)
+	if err != nil {
+		return errors.Wrapf(err, "invalid value for '%s' check", ti.Check.Name)
+	}
+
+	referencedTable, err := p.ResolveRef(referencedAsset)
+	if err != nil {
+		return err
+	}
+
+	qq := fmt.Sprintf(
+		"SELECT count(*) FROM %s WHERE %s IS NOT NULL AND %s NOT IN (SELECT %s FROM %s WHERE %s IS NOT NULL)",
+		ti.GetAsset().Name, ti.Column.Name, ti.Column.Name, referencedColumn, referencedTable, referencedColumn,
+	)
+	return NewCountableQueryCheck(c.conn, 0, &query.Query{Query: qq}, ti.Check.Name, func(count int64) error {
+		return errors.Errorf("column '%s' has %d values that do not exist in '%s.%s'", ti.Column.Name, count, referencedAsset, referencedColumn)
+	}).Check(ctx, ti)
+}
+
+// MatchesUpstreamCountCheck ensures that the asset has the same number of rows as one of its upstream assets. The row
+// counts of the whole tables are compared, the column the check is defined on is not used.
+type MatchesUpstreamCountCheck struct {
+	conn connectionFetcher
+}
+
+func NewMatchesUpstreamCountCheck(conn connectionFetcher) *MatchesUpstreamCountCheck {
+	return &MatchesUpstreamCountCheck{conn: conn}
+}
+
+func (c *MatchesUpstreamCountCheck) Check(ctx context.Context, ti *scheduler.ColumnCheckInstance) error {
+	p := ti.GetPipeline()
+	upstreamAsset, _, err := p.ColumnCheckReference(ti.GetAsset(), ti.Column, ti.Check)
+	if err != nil {
+		return errors.Wrapf(err, "invalid value for '%s' check", ti.Check.Name)
+	}
+
+	upstreamTable, err := p.ResolveRef(upstreamAsset)
+	if err != nil {
+		return err
+	}
+
+	qq := fmt.Sprintf("SELECT (SELECT count(*) FROM %s) - (SELECT count(*) FROM %s)", ti.GetAsset().Name, upstreamTable)
+	return NewCountableQueryCheck(c.conn, 0, &query.Query{Query: qq}, ti.Check.Name, func(count int64) error {
+		return errors.Errorf("the row count of '%s' differs from its upstream '%s' by %d", ti.GetAsset().Name, upstreamAsset, count)
+	}).Check(ctx, ti)
+}
+
 type renderer interface {
 	Render(query string) (string, error)
+	WithContext(values jinja.Context) jinja.RendererInterface
 }
 
 type CustomCheck struct {
@@ -201,7 +382,12 @@ func NewCustomCheck(conn connectionFetcher) *CustomCheck {
 func (c *CustomCheck) Check(ctx context.Context, ti *scheduler.CustomCheckInstance) error {
 	qq := ti.Check.Query
 	if c.renderer != nil {
-		rendered, err := c.renderer.Render(qq)
+		ctx, err := WithTargetTableState(ctx, c.conn, ti.GetPipeline(), ti.GetAsset(), qq)
+		if err != nil {
+			return err
+		}
+
+		rendered, err := c.renderer.WithContext(pipeline.AssetRenderContext(ctx, ti.GetPipeline(), ti.GetAsset())).Render(qq)
 		if err != nil {
 			return errors.Wrap(err, "failed to render custom check query")
 		}
@@ -250,7 +436,7 @@ type CustomCheckOperator struct {
 	checkRunner CustomCheckRunner
 }
 
-func NewCustomCheckOperator(manager connectionFetcher, r *jinja.Renderer) *CustomCheckOperator {
+func NewCustomCheckOperator(manager connectionFetcher, r renderer) *CustomCheckOperator {
 	return &CustomCheckOperator{
 		checkRunner: &CustomCheck{conn: manager, renderer: r},
 	}
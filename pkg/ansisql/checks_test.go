@@ -8,8 +8,10 @@ import (
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/query"
 	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type mockQuerierWithResult struct {
@@ -63,6 +65,103 @@ func TestNotNullCheck_Check(t *testing.T) {
 	)
 }
 
+type mockSchemaQuerier struct {
+	mockQuerierWithResult
+}
+
+func (m *mockSchemaQuerier) SelectWithSchema(ctx context.Context, q *query.Query) (*query.QueryResult, error) {
+	args := m.Called(ctx, q)
+	get := args.Get(0)
+	if get == nil {
+		return nil, args.Error(1)
+	}
+
+	return get.(*query.QueryResult), args.Error(1)
+}
+
+func TestCountableQueryCheck_FailingRowsSample(t *testing.T) {
+	t.Parallel()
+
+	countQuery := &query.Query{Query: "SELECT count(*) FROM dataset.test_asset WHERE test_column IS NULL"}
+	sampleQuery := &query.Query{Query: "SELECT * FROM (\nSELECT * FROM dataset.test_asset WHERE test_column IS NULL\n) as t LIMIT 2"}
+
+	tests := []struct {
+		name       string
+		sampleSize int64
+		setup      func(q *mockSchemaQuerier)
+		wantSample *scheduler.RowSample
+	}{
+		{
+			name:       "sampling is disabled",
+			sampleSize: 0,
+		},
+		{
+			name:       "sample is fetched with the column names",
+			sampleSize: 2,
+			setup: func(q *mockSchemaQuerier) {
+				q.On("SelectWithSchema", mock.Anything, sampleQuery).Return(&query.QueryResult{
+					Columns: []string{"id", "test_column"},
+					Rows:    [][]interface{}{{int64(1), nil}, {[]byte("2"), nil}},
+				}, nil)
+			},
+			wantSample: &scheduler.RowSample{
+				Columns: []string{"id", "test_column"},
+				Rows:    [][]interface{}{{int64(1), nil}, {"2", nil}},
+			},
+		},
+		{
+			name:       "failure to fetch the sample keeps the check error",
+			sampleSize: 2,
+			setup: func(q *mockSchemaQuerier) {
+				q.On("SelectWithSchema", mock.Anything, sampleQuery).Return(nil, errors.New("permission denied"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			q := new(mockSchemaQuerier)
+			q.On("Select", mock.Anything, countQuery).Return([][]interface{}{{int64(5)}}, nil)
+			if tt.setup != nil {
+				tt.setup(q)
+			}
+
+			conn := new(mockConnectionFetcher)
+			conn.On("GetConnection", "test").Return(q, nil)
+
+			ctx := context.WithValue(context.Background(), pipeline.RunConfigFailingRowsSample, tt.sampleSize)
+			err := NewNotNullCheck(conn).Check(ctx, &scheduler.ColumnCheckInstance{
+				AssetInstance: &scheduler.AssetInstance{
+					Asset: &pipeline.Asset{Name: "dataset.test_asset", Type: pipeline.AssetTypeBigqueryQuery},
+					Pipeline: &pipeline.Pipeline{
+						Name: "test",
+						DefaultConnections: map[string]string{
+							"google_cloud_platform": "test",
+						},
+					},
+				},
+				Column: &pipeline.Column{Name: "test_column"},
+				Check:  &pipeline.ColumnCheck{Name: "not_null"},
+			})
+
+			require.EqualError(t, err, "column 'test_column' has 5 null values")
+			assert.Equal(t, tt.wantSample, scheduler.FailingRowsSample(err))
+			q.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDuplicateValuesQuery(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(
+		t,
+		"SELECT id, COUNT(*) AS duplicate_count FROM dataset.orders WHERE id IS NOT NULL GROUP BY id HAVING COUNT(*) > 1",
+		DuplicateValuesQuery("dataset.orders", "id"),
+	)
+}
+
 func TestPositiveCheck_Check(t *testing.T) {
 	t.Parallel()
 
@@ -117,6 +216,140 @@ func TestUniqueCheck_Check(t *testing.T) {
 	)
 }
 
+func TestMinCheck_Check(t *testing.T) {
+	t.Parallel()
+
+	runTestsFoCountZeroCheck(
+		t,
+		func(q *mockQuerierWithResult) CheckRunner {
+			conn := new(mockConnectionFetcher)
+			conn.On("GetConnection", "test").Return(q, nil)
+			return NewMinCheck(conn)
+		},
+		"SELECT count(*) FROM dataset.test_asset WHERE test_column < 3",
+		"column 'test_column' has 5 values less than the minimum 3",
+		&pipeline.ColumnCheck{
+			Name:  "min",
+			Value: pipeline.ColumnCheckValue{Int: &[]int{3}[0]},
+		},
+	)
+}
+
+func TestMaxCheck_Check(t *testing.T) {
+	t.Parallel()
+
+	runTestsFoCountZeroCheck(
+		t,
+		func(q *mockQuerierWithResult) CheckRunner {
+			conn := new(mockConnectionFetcher)
+			conn.On("GetConnection", "test").Return(q, nil)
+			return NewMaxCheck(conn)
+		},
+		"SELECT count(*) FROM dataset.test_asset WHERE test_column > 'z''s'",
+		"column 'test_column' has 5 values greater than the maximum z's",
+		&pipeline.ColumnCheck{
+			Name:  "max",
+			Value: pipeline.ColumnCheckValue{String: &[]string{"z's"}[0]},
+		},
+	)
+}
+
+func TestRelationshipsCheck_Check(t *testing.T) {
+	t.Parallel()
+
+	runTestsFoCountZeroCheck(
+		t,
+		func(q *mockQuerierWithResult) CheckRunner {
+			conn := new(mockConnectionFetcher)
+			conn.On("GetConnection", "test").Return(q, nil)
+			return NewRelationshipsCheck(conn)
+		},
+		"SELECT count(*) FROM dataset.test_asset WHERE test_column IS NOT NULL AND test_column NOT IN (SELECT id FROM dataset.referenced_asset WHERE id IS NOT NULL)",
+		"column 'test_column' has 5 values that do not exist in 'dataset.referenced_asset.id'",
+		&pipeline.ColumnCheck{
+			Name:  "relationships",
+			Value: pipeline.ColumnCheckValue{String: &[]string{"dataset.referenced_asset.id"}[0]},
+		},
+	)
+}
+
+func TestMatchesUpstreamCountCheck_Check(t *testing.T) {
+	t.Parallel()
+
+	runTestsFoCountZeroCheck(
+		t,
+		func(q *mockQuerierWithResult) CheckRunner {
+			conn := new(mockConnectionFetcher)
+			conn.On("GetConnection", "test").Return(q, nil)
+			return NewMatchesUpstreamCountCheck(conn)
+		},
+		"SELECT (SELECT count(*) FROM dataset.test_asset) - (SELECT count(*) FROM dataset.referenced_asset)",
+		"the row count of 'dataset.test_asset' differs from its upstream 'dataset.referenced_asset' by 5",
+		&pipeline.ColumnCheck{
+			Name:  "matches_upstream_count",
+			Value: pipeline.ColumnCheckValue{String: &[]string{"dataset.referenced_asset"}[0]},
+		},
+	)
+}
+
+func TestBoundaryLiteral(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		value      pipeline.ColumnCheckValue
+		columnType string
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:  "integer",
+			value: pipeline.ColumnCheckValue{Int: &[]int{-5}[0]},
+			want:  "-5",
+		},
+		{
+			name:  "float",
+			value: pipeline.ColumnCheckValue{Float: &[]float64{3.14}[0]},
+			want:  "3.14",
+		},
+		{
+			name:  "date without a column type",
+			value: pipeline.ColumnCheckValue{String: &[]string{"2024-01-01"}[0]},
+			want:  "'2024-01-01'",
+		},
+		{
+			name:       "timestamp is cast to the column type",
+			value:      pipeline.ColumnCheckValue{String: &[]string{"2024-01-01 00:00:00"}[0]},
+			columnType: " TIMESTAMP ",
+			want:       "CAST('2024-01-01 00:00:00' AS TIMESTAMP)",
+		},
+		{
+			name:    "empty string",
+			value:   pipeline.ColumnCheckValue{String: &[]string{""}[0]},
+			wantErr: true,
+		},
+		{
+			name:    "arrays are not supported",
+			value:   pipeline.ColumnCheckValue{IntArray: &[]int{1, 2}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := BoundaryLiteral(tt.value, tt.columnType)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func runTestsFoCountZeroCheck(t *testing.T, instanceBuilder func(q *mockQuerierWithResult) CheckRunner, expectedQueryString string, expectedErrorMessage string, checkInstance *pipeline.ColumnCheck) {
 	expectedQuery := &query.Query{Query: expectedQueryString}
 	setupFunc := func(val [][]interface{}, err error) func(n *mockQuerierWithResult) {
@@ -194,6 +427,7 @@ func runTestsFoCountZeroCheck(t *testing.T, instanceBuilder func(q *mockQuerierW
 						DefaultConnections: map[string]string{
 							"google_cloud_platform": "test",
 						},
+						Assets: []*pipeline.Asset{{Name: "dataset.referenced_asset"}},
 					},
 				},
 				Column: &pipeline.Column{
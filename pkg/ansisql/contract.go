@@ -0,0 +1,80 @@
+package ansisql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/pkg/errors"
+)
+
+// ContractColumnDefinitions builds the column definitions of a table whose contract is enforced, e.g.
+// "id INT64 NOT NULL, name STRING". Columns with a `not_null` check are declared as NOT NULL.
+func ContractColumnDefinitions(asset *pipeline.Asset) (string, error) {
+	if err := validateContractColumns(asset); err != nil {
+		return "", err
+	}
+
+	definitions := make([]string, 0, len(asset.Columns))
+	for _, col := range asset.Columns {
+		definition := fmt.Sprintf("%s %s", col.Name, col.Type)
+		if col.HasCheck("not_null") {
+			definition += " NOT NULL"
+		}
+
+		definitions = append(definitions, definition)
+	}
+
+	return strings.Join(definitions, ", "), nil
+}
+
+// ContractSelectQuery wraps the given query so that its output is cast to the declared columns of the asset. The query
+// fails if it does not return one of the declared columns, or if a value cannot be cast to the declared type.
+func ContractSelectQuery(asset *pipeline.Asset, query string) (string, error) {
+	if err := validateContractColumns(asset); err != nil {
+		return "", err
+	}
+
+	projections := make([]string, 0, len(asset.Columns))
+	for _, col := range asset.Columns {
+		projections = append(projections, fmt.Sprintf("CAST(%s AS %s) AS %s", col.Name, col.Type, col.Name))
+	}
+
+	query = strings.TrimSuffix(strings.TrimSpace(query), ";")
+	return fmt.Sprintf("SELECT %s FROM (\n%s\n) AS __bruin_contract", strings.Join(projections, ", "), query), nil
+}
+
+// ContractCreateReplaceQuery creates the table with the declared columns first, and then inserts the casted output of
+// the query into it in a single transaction, so that the table always matches the contract of the asset.
+func ContractCreateReplaceQuery(asset *pipeline.Asset, query string) (string, error) {
+	columnDefinitions, err := ContractColumnDefinitions(asset)
+	if err != nil {
+		return "", err
+	}
+
+	query, err = ContractSelectQuery(asset, query)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		`BEGIN TRANSACTION;
+DROP TABLE IF EXISTS %s;
+CREATE TABLE %s (%s);
+INSERT INTO %s (%s) %s;
+COMMIT;`, asset.Name, asset.Name, columnDefinitions, asset.Name, strings.Join(asset.ColumnNames(), ", "), query), nil
+}
+
+func validateContractColumns(asset *pipeline.Asset) error {
+	if len(asset.Columns) == 0 {
+		return errors.Errorf("asset '%s' has an enforced contract but does not declare any columns", asset.Name)
+	}
+
+	for _, col := range asset.Columns {
+		if strings.TrimSpace(col.Type) == "" {
+			return errors.Errorf("asset '%s' has an enforced contract but the column '%s' does not have a type", asset.Name, col.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package ansisql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/pkg/errors"
+)
+
+// FreshnessResult is the outcome of evaluating the freshness check of an asset.
+type FreshnessResult struct {
+	Asset      string     `json:"asset"`
+	Column     string     `json:"column"`
+	LatestAt   *time.Time `json:"latest_at"`
+	Age        string     `json:"age,omitempty"`
+	WarnAfter  string     `json:"warn_after,omitempty"`
+	ErrorAfter string     `json:"error_after,omitempty"`
+	Status     string     `json:"status"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// FreshnessEvaluator finds the latest value of the freshness column of an asset and compares its age with the
+// thresholds of the check.
+type FreshnessEvaluator struct {
+	conn connectionFetcher
+	now  func() time.Time
+}
+
+func NewFreshnessEvaluator(conn connectionFetcher) *FreshnessEvaluator {
+	return &FreshnessEvaluator{conn: conn, now: time.Now}
+}
+
+func (e *FreshnessEvaluator) Evaluate(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) (*FreshnessResult, error) {
+	check := asset.Freshness
+	if check == nil {
+		return nil, errors.Errorf("asset '%s' does not have a freshness check", asset.Name)
+	}
+
+	connectionName, err := p.GetConnectionNameForAsset(asset)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := e.conn.GetConnection(connectionName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get connection '%s' for the freshness check", connectionName)
+	}
+
+	s, ok := conn.(selector)
+	if !ok {
+		return nil, errors.New("connection does not implement selector interface")
+	}
+
+	res, err := s.Select(ctx, &query.Query{Query: fmt.Sprintf("SELECT MAX(%s) FROM %s", check.Column, asset.Name)})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to run the freshness check query")
+	}
+
+	if len(res) != 1 || len(res[0]) != 1 {
+		return nil, errors.Errorf("the freshness check query returned %d rows instead of a single value", len(res))
+	}
+
+	result := &FreshnessResult{
+		Asset:      asset.Name,
+		Column:     check.Column,
+		WarnAfter:  check.WarnAfter,
+		ErrorAfter: check.ErrorAfter,
+		Status:     pipeline.FreshnessStatusError,
+	}
+
+	if res[0][0] == nil {
+		result.Error = fmt.Sprintf("column '%s' has no values", check.Column)
+		return result, nil
+	}
+
+	latest, err := parseTimestamp(res[0][0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the latest value of column '%s'", check.Column)
+	}
+
+	age := e.now().Sub(latest)
+	if age < 0 {
+		age = 0
+	}
+
+	result.Status, err = check.Status(age)
+	if err != nil {
+		return nil, err
+	}
+
+	result.LatestAt = &latest
+	result.Age = age.Truncate(time.Second).String()
+
+	return result, nil
+}
+
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	time.DateOnly,
+}
+
+// parseTimestamp converts the values returned by the different database drivers for date and timestamp columns to a
+// time, values without a timezone are assumed to be in UTC.
+func parseTimestamp(value interface{}) (time.Time, error) {
+	var raw string
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case *time.Time:
+		return *v, nil
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	case fmt.Stringer:
+		raw = v.String()
+	default:
+		return time.Time{}, errors.Errorf("unsupported value '%v' of type %T", value, value)
+	}
+
+	raw = strings.TrimSpace(raw)
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, raw); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, errors.Errorf("'%s' is not a date or a timestamp", raw)
+}
+
+// FreshnessCheckOperator runs the freshness check of an asset, it fails if the latest value is older than
+// `error_after` and returns a non-blocking warning if it is older than `warn_after`.
+type FreshnessCheckOperator struct {
+	evaluator *FreshnessEvaluator
+}
+
+func NewFreshnessCheckOperator(conn connectionFetcher) *FreshnessCheckOperator {
+	return &FreshnessCheckOperator{evaluator: NewFreshnessEvaluator(conn)}
+}
+
+func (o *FreshnessCheckOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error {
+	instance, ok := ti.(*scheduler.FreshnessCheckInstance)
+	if !ok {
+		return errors.New("cannot run a non-freshness check instance")
+	}
+
+	result, err := o.evaluator.Evaluate(ctx, instance.GetPipeline(), instance.GetAsset())
+	if err != nil {
+		return err
+	}
+
+	if result.Error != "" {
+		return errors.New(result.Error)
+	}
+
+	switch result.Status {
+	case pipeline.FreshnessStatusError:
+		return errors.Errorf("the latest value of column '%s' is %s old, which is older than the error threshold of %s", result.Column, result.Age, result.ErrorAfter)
+	case pipeline.FreshnessStatusWarn:
+		return &scheduler.WarningError{
+			Err: errors.Errorf("the latest value of column '%s' is %s old, which is older than the warning threshold of %s", result.Column, result.Age, result.WarnAfter),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package ansisql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreshnessEvaluator_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	latest := func(hoursAgo int) *time.Time {
+		v := now.Add(-time.Duration(hoursAgo) * time.Hour)
+		return &v
+	}
+
+	tests := []struct {
+		name    string
+		result  [][]interface{}
+		want    *FreshnessResult
+		wantErr bool
+	}{
+		{
+			name:   "fresh timestamp",
+			result: [][]interface{}{{now.Add(-time.Hour)}},
+			want:   &FreshnessResult{LatestAt: latest(1), Age: "1h0m0s", Status: pipeline.FreshnessStatusFresh},
+		},
+		{
+			name:   "string timestamp older than warn_after",
+			result: [][]interface{}{{"2024-05-09 20:00:00"}},
+			want:   &FreshnessResult{LatestAt: latest(16), Age: "16h0m0s", Status: pipeline.FreshnessStatusWarn},
+		},
+		{
+			name:   "date older than error_after",
+			result: [][]interface{}{{"2024-05-08"}},
+			want:   &FreshnessResult{LatestAt: latest(60), Age: "60h0m0s", Status: pipeline.FreshnessStatusError},
+		},
+		{
+			name:   "empty table",
+			result: [][]interface{}{{nil}},
+			want:   &FreshnessResult{Status: pipeline.FreshnessStatusError, Error: "column 'updated_at' has no values"},
+		},
+		{
+			name:    "value that is not a timestamp",
+			result:  [][]interface{}{{"yesterday"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			q := new(mockQuerierWithResult)
+			q.On("Select", mock.Anything, &query.Query{Query: "SELECT MAX(updated_at) FROM raw.orders"}).Return(tt.result, nil)
+
+			conn := new(mockConnectionFetcher)
+			conn.On("GetConnection", "gcp").Return(q, nil)
+
+			evaluator := NewFreshnessEvaluator(conn)
+			evaluator.now = func() time.Time { return now }
+
+			p := &pipeline.Pipeline{DefaultConnections: map[string]string{"google_cloud_platform": "gcp"}}
+			asset := &pipeline.Asset{
+				Name:      "raw.orders",
+				Type:      pipeline.AssetTypeBigquerySource,
+				Freshness: &pipeline.FreshnessCheck{Column: "updated_at", WarnAfter: "12h", ErrorAfter: "2d"},
+			}
+
+			got, err := evaluator.Evaluate(context.Background(), p, asset)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			tt.want.Asset = "raw.orders"
+			tt.want.Column = "updated_at"
+			tt.want.WarnAfter = "12h"
+			tt.want.ErrorAfter = "2d"
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFreshnessCheckOperator_Run(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		latest      time.Time
+		wantErr     bool
+		wantWarning bool
+	}{
+		{
+			name:   "fresh data passes",
+			latest: now.Add(-time.Hour),
+		},
+		{
+			name:        "data older than warn_after warns",
+			latest:      now.Add(-16 * time.Hour),
+			wantErr:     true,
+			wantWarning: true,
+		},
+		{
+			name:    "data older than error_after fails",
+			latest:  now.Add(-60 * time.Hour),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			q := new(mockQuerierWithResult)
+			q.On("Select", mock.Anything, &query.Query{Query: "SELECT MAX(updated_at) FROM raw.orders"}).Return([][]interface{}{{tt.latest}}, nil)
+
+			conn := new(mockConnectionFetcher)
+			conn.On("GetConnection", "gcp").Return(q, nil)
+
+			asset := &pipeline.Asset{
+				Name:      "raw.orders",
+				Type:      pipeline.AssetTypeBigquerySource,
+				Freshness: &pipeline.FreshnessCheck{Column: "updated_at", WarnAfter: "12h", ErrorAfter: "2d"},
+			}
+			p := &pipeline.Pipeline{
+				DefaultConnections: map[string]string{"google_cloud_platform": "gcp"},
+				Assets:             []*pipeline.Asset{asset},
+			}
+
+			instance := &scheduler.FreshnessCheckInstance{
+				AssetInstance: &scheduler.AssetInstance{Asset: asset, Pipeline: p},
+				Check:         asset.Freshness,
+			}
+
+			err := NewFreshnessCheckOperator(conn).Run(context.Background(), instance)
+			if !tt.wantErr {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Equal(t, tt.wantWarning, scheduler.IsWarning(err))
+		})
+	}
+}
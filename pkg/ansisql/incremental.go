@@ -0,0 +1,46 @@
+package ansisql
+
+import (
+	"context"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/pkg/errors"
+)
+
+// WithTargetTableState checks whether the target table of the asset already exists when the given query refers to
+// `is_incremental`, and records the result in the context the query is rendered with. The table is assumed to be
+// missing if the connection cannot introspect tables.
+func WithTargetTableState(ctx context.Context, conn connectionFetcher, p *pipeline.Pipeline, t *pipeline.Asset, content string) (context.Context, error) {
+	if !pipeline.UsesIncrementalVariable(content) {
+		return ctx, nil
+	}
+
+	if fullRefresh, ok := ctx.Value(pipeline.RunConfigFullRefresh).(bool); ok && fullRefresh {
+		return ctx, nil
+	}
+
+	connName, err := p.GetConnectionNameForAsset(t)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := conn.GetConnection(connName)
+	if err != nil {
+		return nil, err
+	}
+
+	fetcher, ok := client.(TableSchemaFetcher)
+	if !ok {
+		return ctx, nil
+	}
+
+	_, err = fetcher.GetTableColumns(ctx, t.Name)
+	if errors.Is(err, ErrTableNotFound) {
+		return pipeline.WithTargetTableExists(ctx, false), nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to check if the table '%s' exists", t.Name)
+	}
+
+	return pipeline.WithTargetTableExists(ctx, true), nil
+}
@@ -0,0 +1,155 @@
+package ansisql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/jinja"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSchemaFetcherWithResult struct {
+	mockQuerierWithResult
+}
+
+func (m *mockSchemaFetcherWithResult) GetTableColumns(ctx context.Context, tableName string) ([]*DBColumn, error) {
+	args := m.Called(ctx, tableName)
+	get := args.Get(0)
+	if get == nil {
+		return nil, args.Error(1)
+	}
+
+	return get.([]*DBColumn), args.Error(1)
+}
+
+func TestWithTargetTableState(t *testing.T) {
+	t.Parallel()
+
+	p := &pipeline.Pipeline{
+		Name: "test",
+		DefaultConnections: map[string]string{
+			"google_cloud_platform": "test",
+		},
+	}
+	asset := &pipeline.Asset{
+		Name: "dataset.test_asset",
+		Type: pipeline.AssetTypeBigqueryQuery,
+	}
+
+	tests := []struct {
+		name            string
+		content         string
+		fullRefresh     bool
+		setup           func(conn *mockConnectionFetcher)
+		wantIncremental bool
+		wantErr         bool
+	}{
+		{
+			name:    "the table is not checked if the query does not use is_incremental",
+			content: "SELECT * FROM {{ this }}",
+		},
+		{
+			name:        "the table is not checked on full refresh",
+			content:     "{% if is_incremental %}SELECT 1{% endif %}",
+			fullRefresh: true,
+		},
+		{
+			name:    "existing table makes the run incremental",
+			content: "{% if is_incremental %}SELECT 1{% endif %}",
+			setup: func(conn *mockConnectionFetcher) {
+				client := new(mockSchemaFetcherWithResult)
+				client.On("GetTableColumns", mock.Anything, "dataset.test_asset").Return([]*DBColumn{{Name: "id"}}, nil)
+				conn.On("GetConnection", "test").Return(client, nil)
+			},
+			wantIncremental: true,
+		},
+		{
+			name:    "missing table is not incremental",
+			content: "{% if is_incremental %}SELECT 1{% endif %}",
+			setup: func(conn *mockConnectionFetcher) {
+				client := new(mockSchemaFetcherWithResult)
+				client.On("GetTableColumns", mock.Anything, "dataset.test_asset").Return(nil, ErrTableNotFound)
+				conn.On("GetConnection", "test").Return(client, nil)
+			},
+		},
+		{
+			name:    "connections that cannot introspect tables are not incremental",
+			content: "{% if is_incremental %}SELECT 1{% endif %}",
+			setup: func(conn *mockConnectionFetcher) {
+				conn.On("GetConnection", "test").Return(new(mockQuerierWithResult), nil)
+			},
+		},
+		{
+			name:    "introspection errors are returned",
+			content: "{% if is_incremental %}SELECT 1{% endif %}",
+			setup: func(conn *mockConnectionFetcher) {
+				client := new(mockSchemaFetcherWithResult)
+				client.On("GetTableColumns", mock.Anything, "dataset.test_asset").Return(nil, assert.AnError)
+				conn.On("GetConnection", "test").Return(client, nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conn := new(mockConnectionFetcher)
+			if tt.setup != nil {
+				tt.setup(conn)
+			}
+
+			ctx := context.WithValue(context.Background(), pipeline.RunConfigFullRefresh, tt.fullRefresh)
+			ctx, err := WithTargetTableState(ctx, conn, p, asset, tt.content)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantIncremental, pipeline.AssetRenderContext(ctx, p, asset)["is_incremental"])
+			conn.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCustomCheck_RendersTheAssetContext(t *testing.T) {
+	t.Parallel()
+
+	client := new(mockSchemaFetcherWithResult)
+	client.On("GetTableColumns", mock.Anything, "dataset.test_asset").Return([]*DBColumn{{Name: "id"}}, nil)
+	client.On("Select", mock.Anything, &query.Query{Query: "SELECT count(*) FROM dataset.test_asset WHERE dt > 'x'"}).
+		Return([][]interface{}{{int64(0)}}, nil)
+
+	conn := new(mockConnectionFetcher)
+	conn.On("GetConnection", "test").Return(client, nil)
+
+	check := &CustomCheck{conn: conn, renderer: jinja.NewRendererWithYesterday("your-pipeline-name", "your-run-id")}
+	err := check.Check(context.Background(), &scheduler.CustomCheckInstance{
+		AssetInstance: &scheduler.AssetInstance{
+			Asset: &pipeline.Asset{
+				Name: "dataset.test_asset",
+				Type: pipeline.AssetTypeBigqueryQuery,
+			},
+			Pipeline: &pipeline.Pipeline{
+				Name: "test",
+				DefaultConnections: map[string]string{
+					"google_cloud_platform": "test",
+				},
+			},
+		},
+		Check: &pipeline.CustomCheck{
+			Name:  "check1",
+			Value: 0,
+			Query: "SELECT count(*) FROM {{ this }}{% if is_incremental %} WHERE dt > 'x'{% endif %}",
+		},
+	})
+
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+}
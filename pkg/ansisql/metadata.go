@@ -0,0 +1,109 @@
+package ansisql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/pkg/errors"
+)
+
+type queryRunner interface {
+	RunQueryWithoutResult(ctx context.Context, query *query.Query) error
+}
+
+// MetadataQueryBuilder returns the statements that push the metadata of the asset to the platform, the column
+// classification policies are given by the column names.
+type MetadataQueryBuilder func(asset *pipeline.Asset, policies map[string]*pipeline.ClassificationPolicy) ([]string, error)
+
+// MetadataPushOperator pushes the metadata of the assets for the platforms that store it through SQL statements,
+// e.g. `COMMENT ON` statements or table properties.
+type MetadataPushOperator struct {
+	connection connectionFetcher
+	queries    MetadataQueryBuilder
+}
+
+func NewMetadataPushOperator(conn connectionFetcher, queries MetadataQueryBuilder) *MetadataPushOperator {
+	return &MetadataPushOperator{
+		connection: conn,
+		queries:    queries,
+	}
+}
+
+func (o *MetadataPushOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error {
+	p := ti.GetPipeline()
+	asset := ti.GetAsset()
+	if !p.MetadataPush.IsEnabledForAsset(asset) {
+		return nil
+	}
+
+	queries, err := o.queries(asset, p.ColumnPolicies(asset))
+	if err != nil {
+		return err
+	}
+
+	if len(queries) == 0 {
+		return nil
+	}
+
+	connName, err := p.GetConnectionNameForAsset(asset)
+	if err != nil {
+		return err
+	}
+
+	conn, err := o.connection.GetConnection(connName)
+	if err != nil {
+		return err
+	}
+
+	runner, ok := conn.(queryRunner)
+	if !ok {
+		return errors.Errorf("connection '%s' cannot be used to push the metadata", connName)
+	}
+
+	for _, q := range queries {
+		if err := runner.RunQueryWithoutResult(ctx, &query.Query{Query: q}); err != nil {
+			return errors.Wrap(err, "failed to push the metadata")
+		}
+	}
+
+	return nil
+}
+
+// TableComment returns the description of the asset, followed by its owner and tags if there are any, e.g.
+// "The customers. [owner: data-team; tags: core, crm]".
+func TableComment(asset *pipeline.Asset) string {
+	labels := make([]string, 0, 2)
+	if asset.Owner != "" {
+		labels = append(labels, "owner: "+asset.Owner)
+	}
+	if len(asset.Tags) > 0 {
+		labels = append(labels, "tags: "+strings.Join(asset.Tags, ", "))
+	}
+
+	return withLabels(asset.Description, labels)
+}
+
+// ColumnComment returns the description of the column, followed by its classification and tags if there are any, e.g.
+// "The e-mail address of the customer. [classification: pii; tags: gdpr]".
+func ColumnComment(col pipeline.Column) string {
+	labels := make([]string, 0, 2)
+	if col.Classification != "" {
+		labels = append(labels, "classification: "+col.Classification)
+	}
+	if len(col.Tags) > 0 {
+		labels = append(labels, "tags: "+strings.Join(col.Tags, ", "))
+	}
+
+	return withLabels(col.Description, labels)
+}
+
+func withLabels(description string, labels []string) string {
+	if len(labels) == 0 {
+		return description
+	}
+
+	return strings.TrimSpace(description + " [" + strings.Join(labels, "; ") + "]")
+}
@@ -0,0 +1,127 @@
+package ansisql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataPushOperator_Run(t *testing.T) {
+	t.Parallel()
+
+	queries := func(asset *pipeline.Asset, _ map[string]*pipeline.ClassificationPolicy) ([]string, error) {
+		if asset.Description == "" {
+			return nil, nil
+		}
+
+		return []string{"COMMENT ON TABLE " + asset.Name + " IS '" + asset.Description + "'"}, nil
+	}
+
+	tests := []struct {
+		name         string
+		metadataPush pipeline.MetadataPush
+		description  string
+		setup        func(conn *mockConnectionFetcher, q *mockQuerierWithResult)
+		wantErr      string
+	}{
+		{
+			name:        "disabled for the platform",
+			description: "the customers",
+		},
+		{
+			name:         "no metadata to push",
+			metadataPush: pipeline.MetadataPush{Postgres: true},
+		},
+		{
+			name:         "queries are run",
+			metadataPush: pipeline.MetadataPush{Postgres: true},
+			description:  "the customers",
+			setup: func(conn *mockConnectionFetcher, q *mockQuerierWithResult) {
+				conn.On("GetConnection", "pg-conn").Return(q, nil)
+				q.On("RunQueryWithoutResult", mock.Anything, &query.Query{Query: "COMMENT ON TABLE customers IS 'the customers'"}).Return(nil)
+			},
+		},
+		{
+			name:         "query errors are returned",
+			metadataPush: pipeline.MetadataPush{Global: true},
+			description:  "the customers",
+			setup: func(conn *mockConnectionFetcher, q *mockQuerierWithResult) {
+				conn.On("GetConnection", "pg-conn").Return(q, nil)
+				q.On("RunQueryWithoutResult", mock.Anything, mock.Anything).Return(errors.New("permission denied"))
+			},
+			wantErr: "failed to push the metadata: permission denied",
+		},
+		{
+			name:         "connection cannot run queries",
+			metadataPush: pipeline.MetadataPush{Postgres: true},
+			description:  "the customers",
+			setup: func(conn *mockConnectionFetcher, q *mockQuerierWithResult) {
+				conn.On("GetConnection", "pg-conn").Return("not a client", nil)
+			},
+			wantErr: "connection 'pg-conn' cannot be used to push the metadata",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conn := new(mockConnectionFetcher)
+			q := new(mockQuerierWithResult)
+			if tt.setup != nil {
+				tt.setup(conn, q)
+			}
+
+			asset := &pipeline.Asset{
+				Name:        "customers",
+				Type:        pipeline.AssetTypePostgresQuery,
+				Description: tt.description,
+			}
+			ti := &scheduler.MetadataPushInstance{
+				AssetInstance: &scheduler.AssetInstance{
+					Asset: asset,
+					Pipeline: &pipeline.Pipeline{
+						MetadataPush:       tt.metadataPush,
+						DefaultConnections: map[string]string{"postgres": "pg-conn"},
+					},
+				},
+			}
+
+			err := NewMetadataPushOperator(conn, queries).Run(context.Background(), ti)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			conn.AssertExpectations(t)
+			q.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTableAndColumnComments(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, TableComment(&pipeline.Asset{}))
+	assert.Equal(t, "the customers", TableComment(&pipeline.Asset{Description: "the customers"}))
+	assert.Equal(t, "the customers [owner: data-team; tags: core, crm]", TableComment(&pipeline.Asset{
+		Description: "the customers",
+		Owner:       "data-team",
+		Tags:        []string{"core", "crm"},
+	}))
+	assert.Equal(t, "[tags: core]", TableComment(&pipeline.Asset{Tags: []string{"core"}}))
+
+	assert.Empty(t, ColumnComment(pipeline.Column{Name: "id"}))
+	assert.Equal(t, "the e-mail [classification: pii; tags: gdpr]", ColumnComment(pipeline.Column{
+		Description:    "the e-mail",
+		Classification: "pii",
+		Tags:           []string{"gdpr"},
+	}))
+}
@@ -0,0 +1,132 @@
+package ansisql
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/helpers"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// metricHistoryLimit is the number of values kept per check, the older values are dropped from the history.
+const metricHistoryLimit = 365
+
+// MetricValue is a metric recorded by an anomaly check in a single run, the anomalous values are kept for reference
+// but they are not a part of the baseline of the later runs. The values before the latest value that starts a new
+// baseline are kept for reference as well.
+type MetricValue struct {
+	RunID         string    `json:"run_id"`
+	RecordedAt    time.Time `json:"recorded_at"`
+	Value         float64   `json:"value"`
+	Anomalous     bool      `json:"anomalous,omitempty"`
+	BaselineStart bool      `json:"baseline_start,omitempty"`
+}
+
+type assetMetricHistory struct {
+	Checks map[string][]MetricValue `json:"checks"`
+}
+
+// MetricHistory stores the metrics recorded by the anomaly checks in a JSON file per asset under the given folder.
+type MetricHistory struct {
+	fs   afero.Fs
+	path string
+	mu   sync.Mutex
+}
+
+func NewMetricHistory(fs afero.Fs, path string) *MetricHistory {
+	return &MetricHistory{fs: fs, path: path}
+}
+
+// Values returns the values recorded for the check of the asset, from the oldest to the newest.
+func (h *MetricHistory) Values(assetName, checkName string) ([]MetricValue, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	history, err := h.read(assetName)
+	if err != nil {
+		return nil, err
+	}
+
+	return history.Checks[checkName], nil
+}
+
+// Record appends the value to the history of the check, replacing the value that was already recorded in the same run.
+func (h *MetricHistory) Record(assetName, checkName string, value MetricValue) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	history, err := h.read(assetName)
+	if err != nil {
+		return err
+	}
+
+	values := make([]MetricValue, 0, len(history.Checks[checkName])+1)
+	for _, v := range history.Checks[checkName] {
+		if v.RunID != value.RunID {
+			values = append(values, v)
+		}
+	}
+	values = append(values, value)
+
+	if len(values) > metricHistoryLimit {
+		values = values[len(values)-metricHistoryLimit:]
+	}
+
+	history.Checks[checkName] = values
+
+	return helpers.WriteJSONToFile(h.fs, history, h.fileFor(assetName))
+}
+
+// ResetBaseline starts a new baseline with the value recorded in the given run: the value and the ones after it are no
+// longer anomalous, and the values before it are left out of the later comparisons.
+func (h *MetricHistory) ResetBaseline(assetName, checkName, runID string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	history, err := h.read(assetName)
+	if err != nil {
+		return err
+	}
+
+	values := history.Checks[checkName]
+	start := -1
+	for i, v := range values {
+		if v.RunID == runID {
+			start = i
+			break
+		}
+	}
+	if start == -1 {
+		return errors.Errorf("there is no value recorded for the run '%s' of the check '%s'", runID, checkName)
+	}
+
+	for i := start; i < len(values); i++ {
+		values[i].Anomalous = false
+		values[i].BaselineStart = i == start
+	}
+
+	return helpers.WriteJSONToFile(h.fs, history, h.fileFor(assetName))
+}
+
+func (h *MetricHistory) read(assetName string) (*assetMetricHistory, error) {
+	history := &assetMetricHistory{}
+
+	err := helpers.ReadJSONToFile(h.fs, h.fileFor(assetName), history)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "failed to read the metric history of asset '%s'", assetName)
+	}
+
+	if history.Checks == nil {
+		history.Checks = make(map[string][]MetricValue)
+	}
+
+	return history, nil
+}
+
+func (h *MetricHistory) fileFor(assetName string) string {
+	return filepath.Join(h.path, strings.ReplaceAll(assetName, string(filepath.Separator), "_")+".json")
+}
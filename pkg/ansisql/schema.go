@@ -0,0 +1,246 @@
+package ansisql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/pkg/errors"
+)
+
+// ErrTableNotFound is returned by the schema introspection methods when the requested table does not exist.
+var ErrTableNotFound = errors.New("table not found")
+
+// DBColumn is a column of a table as reported by the database itself.
+type DBColumn struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Nullable    bool   `json:"nullable"`
+	Description string `json:"description"`
+}
+
+// TableSchemaFetcher is implemented by the database clients that can introspect the columns of an existing table.
+type TableSchemaFetcher interface {
+	GetTableColumns(ctx context.Context, tableName string) ([]*DBColumn, error)
+}
+
+// TableLister is implemented by the database clients that can list the tables in a schema.
+type TableLister interface {
+	GetTables(ctx context.Context, schemaName string) ([]string, error)
+}
+
+// PrimaryKeyFetcher is implemented by the database clients that can report the primary key columns of a table.
+type PrimaryKeyFetcher interface {
+	GetPrimaryKeyColumns(ctx context.Context, tableName string) ([]string, error)
+}
+
+// StringsFromRows returns the first value of every row as a string, which is useful for single-column queries.
+func StringsFromRows(rows [][]interface{}) []string {
+	values := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+		values = append(values, stringValue(row[0]))
+	}
+
+	return values
+}
+
+// ColumnsFromInformationSchemaRows converts rows in the form of (column_name, data_type, is_nullable, comment) into columns,
+// which is the shape every information_schema based introspection query in the platform packages returns.
+func ColumnsFromInformationSchemaRows(rows [][]interface{}) ([]*DBColumn, error) {
+	columns := make([]*DBColumn, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 2 {
+			return nil, errors.Errorf("unexpected column metadata row with %d values", len(row))
+		}
+
+		col := &DBColumn{
+			Name:     stringValue(row[0]),
+			Type:     stringValue(row[1]),
+			Nullable: true,
+		}
+
+		if len(row) > 2 && row[2] != nil {
+			switch v := row[2].(type) {
+			case bool:
+				col.Nullable = v
+			default:
+				col.Nullable = !strings.EqualFold(stringValue(v), "NO")
+			}
+		}
+
+		if len(row) > 3 {
+			col.Description = stringValue(row[3])
+		}
+
+		columns = append(columns, col)
+	}
+
+	return columns, nil
+}
+
+func stringValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case []byte:
+		return string(val)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+// EscapeSQLString escapes single quotes in a string so that it can be used inside a single-quoted SQL literal.
+func EscapeSQLString(s string) string {
+	return strings.ReplaceAll(s, "'", "''")
+}
+
+// ColumnTypeMismatch describes a column whose declared type does not match the type in the database.
+type ColumnTypeMismatch struct {
+	Column       string `json:"column"`
+	DeclaredType string `json:"declared_type"`
+	ActualType   string `json:"actual_type"`
+}
+
+// SchemaDrift is the difference between the columns declared on an asset and the columns of the table in the database.
+type SchemaDrift struct {
+	MissingInDefinition []*DBColumn          `json:"missing_in_definition"`
+	MissingInDatabase   []string             `json:"missing_in_database"`
+	TypeMismatches      []ColumnTypeMismatch `json:"type_mismatches"`
+}
+
+func (d *SchemaDrift) HasDrift() bool {
+	return len(d.MissingInDefinition) > 0 || len(d.MissingInDatabase) > 0 || len(d.TypeMismatches) > 0
+}
+
+// CompareColumns compares the declared columns of an asset with the actual columns of the table. Column names are
+// compared case-insensitively, and the types are only compared if the column declares a type.
+func CompareColumns(declared []pipeline.Column, actual []*DBColumn) *SchemaDrift {
+	drift := &SchemaDrift{
+		MissingInDefinition: make([]*DBColumn, 0),
+		MissingInDatabase:   make([]string, 0),
+		TypeMismatches:      make([]ColumnTypeMismatch, 0),
+	}
+
+	actualByName := make(map[string]*DBColumn, len(actual))
+	for _, col := range actual {
+		actualByName[strings.ToLower(col.Name)] = col
+	}
+
+	declaredNames := make(map[string]bool, len(declared))
+	for _, col := range declared {
+		declaredNames[strings.ToLower(col.Name)] = true
+
+		actualCol, ok := actualByName[strings.ToLower(col.Name)]
+		if !ok {
+			drift.MissingInDatabase = append(drift.MissingInDatabase, col.Name)
+			continue
+		}
+
+		if col.Type == "" {
+			continue
+		}
+
+		if !ColumnTypesMatch(col.Type, actualCol.Type) {
+			drift.TypeMismatches = append(drift.TypeMismatches, ColumnTypeMismatch{
+				Column:       col.Name,
+				DeclaredType: col.Type,
+				ActualType:   actualCol.Type,
+			})
+		}
+	}
+
+	for _, col := range actual {
+		if !declaredNames[strings.ToLower(col.Name)] {
+			drift.MissingInDefinition = append(drift.MissingInDefinition, col)
+		}
+	}
+
+	return drift
+}
+
+var typeFamilies = map[string]string{
+	"int": "integer", "int2": "integer", "int4": "integer", "int8": "integer", "int16": "integer", "int32": "integer",
+	"int64": "integer", "integer": "integer", "bigint": "integer", "smallint": "integer", "tinyint": "integer",
+	"byteint": "integer", "hugeint": "integer", "ubigint": "integer", "uinteger": "integer", "usmallint": "integer",
+	"utinyint": "integer", "long": "integer", "short": "integer", "serial": "integer", "bigserial": "integer",
+
+	"numeric": "numeric", "decimal": "numeric", "number": "numeric", "bignumeric": "numeric", "bigdecimal": "numeric",
+	"money": "numeric",
+
+	"float": "float", "float4": "float", "float8": "float", "float64": "float", "double": "float",
+	"double precision": "float", "real": "float",
+
+	"string": "string", "text": "string", "varchar": "string", "char": "string", "character": "string",
+	"character varying": "string", "nvarchar": "string", "nchar": "string", "ntext": "string", "bpchar": "string",
+	"uuid": "string", "uniqueidentifier": "string",
+
+	"bool": "boolean", "boolean": "boolean", "bit": "boolean",
+
+	"date": "date",
+
+	"time": "time", "time without time zone": "time",
+
+	"timestamp": "timestamp", "datetime": "timestamp", "datetime2": "timestamp", "timestamp_ntz": "timestamp",
+	"timestamp without time zone": "timestamp", "smalldatetime": "timestamp",
+
+	"timestamptz": "timestamptz", "timestamp_tz": "timestamptz", "timestamp_ltz": "timestamptz",
+	"timestamp with time zone": "timestamptz", "datetimeoffset": "timestamptz",
+
+	"bytes": "binary", "binary": "binary", "varbinary": "binary", "blob": "binary", "bytea": "binary",
+
+	"json": "json", "jsonb": "json", "variant": "json", "object": "json", "struct": "json", "record": "json",
+	"map": "json",
+
+	"array": "array",
+}
+
+// NormalizeColumnType converts a type name into a platform-independent type family, e.g. both `INT64` and `bigint`
+// become `integer`. Type parameters such as lengths and precisions are ignored. Unknown types are returned lowercased.
+func NormalizeColumnType(t string) string {
+	t = strings.ToLower(strings.TrimSpace(t))
+	if strings.HasSuffix(t, "[]") || strings.HasPrefix(t, "array<") || strings.HasPrefix(t, "array(") {
+		return "array"
+	}
+
+	if strings.HasPrefix(t, "struct<") || strings.HasPrefix(t, "struct(") || strings.HasPrefix(t, "map<") || strings.HasPrefix(t, "map(") {
+		return "json"
+	}
+
+	if idx := strings.Index(t, "("); idx != -1 {
+		end := strings.LastIndex(t, ")")
+		if end > idx {
+			t = strings.TrimSpace(t[:idx] + t[end+1:])
+		} else {
+			t = strings.TrimSpace(t[:idx])
+		}
+	}
+
+	t = strings.Join(strings.Fields(t), " ")
+	if family, ok := typeFamilies[t]; ok {
+		return family
+	}
+
+	return t
+}
+
+// ColumnTypesMatch reports whether the declared type and the database type belong to the same type family.
+// Integers and numerics are considered compatible since some platforms, e.g. Snowflake, report integers as NUMBER.
+func ColumnTypesMatch(declared, actual string) bool {
+	d := NormalizeColumnType(declared)
+	a := NormalizeColumnType(actual)
+	if d == a {
+		return true
+	}
+
+	isNumber := func(family string) bool {
+		return family == "integer" || family == "numeric"
+	}
+
+	return isNumber(d) && isNumber(a)
+}
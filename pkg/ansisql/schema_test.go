@@ -0,0 +1,85 @@
+package ansisql
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnsFromInformationSchemaRows(t *testing.T) {
+	t.Parallel()
+
+	columns, err := ColumnsFromInformationSchemaRows([][]interface{}{
+		{"id", "NUMBER", "NO", nil},
+		{"name", []byte("TEXT"), "YES", "the name"},
+		{"active", "BOOLEAN", true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*DBColumn{
+		{Name: "id", Type: "NUMBER", Nullable: false},
+		{Name: "name", Type: "TEXT", Nullable: true, Description: "the name"},
+		{Name: "active", Type: "BOOLEAN", Nullable: true},
+	}, columns)
+
+	_, err = ColumnsFromInformationSchemaRows([][]interface{}{{"id"}})
+	require.Error(t, err)
+}
+
+func TestColumnTypesMatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		declared string
+		actual   string
+		want     bool
+	}{
+		{"integer", "INT64", true},
+		{"int", "NUMBER(38,0)", true},
+		{"varchar(255)", "TEXT", true},
+		{"string", "character varying", true},
+		{"timestamp", "TIMESTAMP_NTZ", true},
+		{"timestamp", "TIMESTAMP WITH TIME ZONE", false},
+		{"float", "DOUBLE PRECISION", true},
+		{"array<string>", "ARRAY<STRING>", true},
+		{"string", "INT64", false},
+		{"date", "timestamp", false},
+		{"geography", "GEOGRAPHY", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.declared+"_"+tt.actual, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, ColumnTypesMatch(tt.declared, tt.actual))
+		})
+	}
+}
+
+func TestCompareColumns(t *testing.T) {
+	t.Parallel()
+
+	drift := CompareColumns(
+		[]pipeline.Column{
+			{Name: "ID", Type: "integer"},
+			{Name: "name", Type: "string"},
+			{Name: "created_at", Type: "date"},
+			{Name: "untyped"},
+			{Name: "removed", Type: "string"},
+		},
+		[]*DBColumn{
+			{Name: "id", Type: "INT64"},
+			{Name: "name", Type: "STRING"},
+			{Name: "created_at", Type: "TIMESTAMP"},
+			{Name: "untyped", Type: "BOOL"},
+			{Name: "added", Type: "STRING"},
+		},
+	)
+
+	assert.True(t, drift.HasDrift())
+	assert.Equal(t, []string{"removed"}, drift.MissingInDatabase)
+	assert.Equal(t, []*DBColumn{{Name: "added", Type: "STRING"}}, drift.MissingInDefinition)
+	assert.Equal(t, []ColumnTypeMismatch{{Column: "created_at", DeclaredType: "date", ActualType: "TIMESTAMP"}}, drift.TypeMismatches)
+
+	noDrift := CompareColumns([]pipeline.Column{{Name: "id", Type: "int"}}, []*DBColumn{{Name: "ID", Type: "BIGINT"}})
+	assert.False(t, noDrift.HasDrift())
+}
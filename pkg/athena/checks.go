@@ -41,11 +41,12 @@ func (c *AcceptedValuesCheck) Check(ctx context.Context, ti *scheduler.ColumnChe
 	res := strings.Join(val, "','")
 	res = fmt.Sprintf("'%s'", res)
 
-	qq := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE CAST(%s as VARCHAR) NOT IN (%s)", ti.GetAsset().Name, ti.Column.Name, res)
+	filter := fmt.Sprintf("CAST(%s as VARCHAR) NOT IN (%s)", ti.Column.Name, res)
+	qq := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", ti.GetAsset().Name, filter)
 
 	return ansisql.NewCountableQueryCheck(c.conn, 0, &query.Query{Query: qq}, "accepted_values", func(count int64) error {
 		return errors.Errorf("column '%s' has %d rows that are not in the accepted values", ti.Column.Name, count)
-	}).Check(ctx, ti)
+	}).WithFailingRowsQuery(fmt.Sprintf("SELECT * FROM %s WHERE %s", ti.GetAsset().Name, filter)).Check(ctx, ti)
 }
 
 type PatternCheck struct {
@@ -57,14 +58,14 @@ func (c *PatternCheck) Check(ctx context.Context, ti *scheduler.ColumnCheckInsta
 		return errors.Errorf("unexpected value %s for pattern check, the value must be a string", ti.Check.Value.ToString())
 	}
 
-	qq := fmt.Sprintf(
-		"SELECT count(*) FROM %s WHERE NOT REGEXP_LIKE(%s, '%s')",
-		ti.GetAsset().Name,
+	filter := fmt.Sprintf(
+		"NOT REGEXP_LIKE(%s, '%s')",
 		ti.Column.Name,
 		*ti.Check.Value.String,
 	)
+	qq := fmt.Sprintf("SELECT count(*) FROM %s WHERE %s", ti.GetAsset().Name, filter)
 
 	return ansisql.NewCountableQueryCheck(c.conn, 0, &query.Query{Query: qq}, "pattern", func(count int64) error {
 		return errors.Errorf("column %s has %d values that don't satisfy the pattern %s", ti.Column.Name, count, *ti.Check.Value.String)
-	}).Check(ctx, ti)
+	}).WithFailingRowsQuery(fmt.Sprintf("SELECT * FROM %s WHERE %s", ti.GetAsset().Name, filter)).Check(ctx, ti)
 }
@@ -26,6 +26,7 @@ type Client interface {
 
 type queryExtractor interface {
 	ExtractQueriesFromString(content string) ([]*query.Query, error)
+	CloneForAsset(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) query.QueryExtractor
 }
 
 type connectionFetcher interface {
@@ -52,7 +53,13 @@ func (o BasicOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error
 }
 
 func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) error {
-	queries, err := o.extractor.ExtractQueriesFromString(t.ExecutableFile.Content)
+	ctx, err := ansisql.WithTargetTableState(ctx, o.connection, p, t, t.ExecutableFile.Content)
+	if err != nil {
+		return err
+	}
+
+	extractor := o.extractor.CloneForAsset(ctx, p, t)
+	queries, err := extractor.ExtractQueriesFromString(t.ExecutableFile.Content)
 	if err != nil {
 		return errors.Wrap(err, "cannot extract queries from the task file")
 	}
@@ -94,13 +101,18 @@ func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pip
 
 func NewColumnCheckOperator(manager connectionFetcher) *ansisql.ColumnCheckOperator {
 	return ansisql.NewColumnCheckOperator(map[string]ansisql.CheckRunner{
-		"not_null":        ansisql.NewNotNullCheck(manager),
-		"unique":          ansisql.NewUniqueCheck(manager),
-		"positive":        ansisql.NewPositiveCheck(manager),
-		"non_negative":    ansisql.NewNonNegativeCheck(manager),
-		"negative":        ansisql.NewNegativeCheck(manager),
-		"accepted_values": &AcceptedValuesCheck{conn: manager},
-		"pattern":         &PatternCheck{conn: manager},
+		"not_null":               ansisql.NewNotNullCheck(manager),
+		"unique":                 ansisql.NewUniqueCheck(manager),
+		"positive":               ansisql.NewPositiveCheck(manager),
+		"non_negative":           ansisql.NewNonNegativeCheck(manager),
+		"negative":               ansisql.NewNegativeCheck(manager),
+		"min":                    ansisql.NewMinCheck(manager),
+		"max":                    ansisql.NewMaxCheck(manager),
+		"relationships":          ansisql.NewRelationshipsCheck(manager),
+		"references":             ansisql.NewRelationshipsCheck(manager),
+		"matches_upstream_count": ansisql.NewMatchesUpstreamCountCheck(manager),
+		"accepted_values":        &AcceptedValuesCheck{conn: manager},
+		"pattern":                &PatternCheck{conn: manager},
 	})
 }
 
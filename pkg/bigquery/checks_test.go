@@ -38,8 +38,8 @@ func (m *mockQuerierWithResult) Ping(ctx context.Context) error {
 	return args.Error(0)
 }
 
-func (m *mockQuerierWithResult) UpdateTableMetadataIfNotExist(ctx context.Context, asset *pipeline.Asset) error {
-	args := m.Called(ctx, asset)
+func (m *mockQuerierWithResult) UpdateTableMetadataIfNotExist(ctx context.Context, asset *pipeline.Asset, policies map[string]*pipeline.ClassificationPolicy) error {
+	args := m.Called(ctx, asset, policies)
 	return args.Error(0)
 }
 
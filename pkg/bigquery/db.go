@@ -3,10 +3,12 @@ package bigquery
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 	"sync"
 
 	"cloud.google.com/go/bigquery"
+	"github.com/bruin-data/bruin/pkg/ansisql"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/query"
 	"github.com/pkg/errors"
@@ -31,7 +33,7 @@ type Selector interface {
 }
 
 type MetadataUpdater interface {
-	UpdateTableMetadataIfNotExist(ctx context.Context, asset *pipeline.Asset) error
+	UpdateTableMetadataIfNotExist(ctx context.Context, asset *pipeline.Asset, policies map[string]*pipeline.ClassificationPolicy) error
 }
 
 type TableManager interface {
@@ -114,8 +116,9 @@ func (d *Client) IsValid(ctx context.Context, query *query.Query) (bool, error)
 	return true, nil
 }
 
-func (d *Client) RunQueryWithoutResult(ctx context.Context, query *query.Query) error {
-	q := d.client.Query(query.String())
+func (d *Client) RunQueryWithoutResult(ctx context.Context, queryObj *query.Query) error {
+	q := d.client.Query(queryObj.String())
+	q.Labels = labelsFromAnnotations(query.AnnotationsFromContext(ctx))
 	_, err := q.Read(ctx)
 	if err != nil {
 		return formatError(err)
@@ -124,8 +127,9 @@ func (d *Client) RunQueryWithoutResult(ctx context.Context, query *query.Query)
 	return nil
 }
 
-func (d *Client) Select(ctx context.Context, query *query.Query) ([][]interface{}, error) {
-	q := d.client.Query(query.String())
+func (d *Client) Select(ctx context.Context, queryObj *query.Query) ([][]interface{}, error) {
+	q := d.client.Query(queryObj.String())
+	q.Labels = labelsFromAnnotations(query.AnnotationsFromContext(ctx))
 	rows, err := q.Read(ctx)
 	if err != nil {
 		return nil, formatError(err)
@@ -155,6 +159,7 @@ func (d *Client) Select(ctx context.Context, query *query.Query) ([][]interface{
 
 func (d *Client) SelectWithSchema(ctx context.Context, queryObj *query.Query) (*query.QueryResult, error) {
 	q := d.client.Query(queryObj.String())
+	q.Labels = labelsFromAnnotations(query.AnnotationsFromContext(ctx))
 	rows, err := q.Read(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initiate query read: %w", err)
@@ -193,6 +198,37 @@ func (d *Client) SelectWithSchema(ctx context.Context, queryObj *query.Query) (*
 	return result, nil
 }
 
+var invalidLabelCharacters = regexp.MustCompile(`[^a-z0-9_-]`)
+
+// labelsFromAnnotations converts the query annotations to BigQuery job labels. Labels only allow lowercase letters,
+// numbers, underscores and dashes, keys must start with a letter, and both keys and values are limited to 63 characters.
+func labelsFromAnnotations(annotations query.Annotations) map[string]string {
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	labels := make(map[string]string, len(annotations))
+	for key, value := range annotations {
+		key = sanitizeLabel(key)
+		if key == "" || key[0] < 'a' || key[0] > 'z' {
+			continue
+		}
+
+		labels[key] = sanitizeLabel(value)
+	}
+
+	return labels
+}
+
+func sanitizeLabel(value string) string {
+	value = invalidLabelCharacters.ReplaceAllString(strings.ToLower(value), "_")
+	if len(value) > 63 {
+		value = value[:63]
+	}
+
+	return value
+}
+
 type NoMetadataUpdatedError struct{}
 
 func (m NoMetadataUpdatedError) Error() string {
@@ -217,7 +253,9 @@ func (d *Client) getTableRef(tableName string) (*bigquery.Table, error) {
 	}
 }
 
-func (d *Client) UpdateTableMetadataIfNotExist(ctx context.Context, asset *pipeline.Asset) error {
+// UpdateTableMetadataIfNotExist pushes the descriptions and the primary keys of the asset to its table, and attaches
+// the policy tags of the column classification policies, given by the column names, to the columns.
+func (d *Client) UpdateTableMetadataIfNotExist(ctx context.Context, asset *pipeline.Asset, policies map[string]*pipeline.ClassificationPolicy) error {
 	anyColumnHasDescription := false
 	colsByName := make(map[string]*pipeline.Column, len(asset.Columns))
 	for _, col := range asset.Columns {
@@ -227,7 +265,14 @@ func (d *Client) UpdateTableMetadataIfNotExist(ctx context.Context, asset *pipel
 		}
 	}
 
-	if asset.Description == "" && (len(asset.Columns) == 0 || !anyColumnHasDescription) {
+	policyTags := make(map[string]string, len(policies))
+	for columnName, policy := range policies {
+		if policy != nil && policy.BigQueryPolicyTag != "" {
+			policyTags[columnName] = policy.BigQueryPolicyTag
+		}
+	}
+
+	if asset.Description == "" && len(policyTags) == 0 && (len(asset.Columns) == 0 || !anyColumnHasDescription) {
 		return NoMetadataUpdatedError{}
 	}
 	tableRef, err := d.getTableRef(asset.Name)
@@ -250,6 +295,10 @@ func (d *Client) UpdateTableMetadataIfNotExist(ctx context.Context, asset *pipel
 			field.Description = col.Description
 			colsChanged = true
 		}
+		if tag, ok := policyTags[field.Name]; ok {
+			field.PolicyTags = &bigquery.PolicyTagList{Names: []string{tag}}
+			colsChanged = true
+		}
 	}
 
 	update := bigquery.TableMetadataToUpdate{}
@@ -441,3 +490,75 @@ func (d *Client) DropTableOnMismatch(ctx context.Context, tableName string, asse
 	}
 	return nil
 }
+
+// GetTableColumns returns the columns of the given table as they exist in BigQuery.
+func (d *Client) GetTableColumns(ctx context.Context, tableName string) ([]*ansisql.DBColumn, error) {
+	tableRef, err := d.getTableRef(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := tableRef.Metadata(ctx)
+	if err != nil {
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code == 404 {
+			return nil, ansisql.ErrTableNotFound
+		}
+		return nil, errors.Wrapf(err, "failed to get the metadata for table '%s'", tableName)
+	}
+
+	columns := make([]*ansisql.DBColumn, 0, len(meta.Schema))
+	for _, field := range meta.Schema {
+		fieldType := string(field.Type)
+		if field.Repeated {
+			fieldType = "ARRAY<" + fieldType + ">"
+		}
+
+		columns = append(columns, &ansisql.DBColumn{
+			Name:        field.Name,
+			Type:        fieldType,
+			Nullable:    !field.Required,
+			Description: field.Description,
+		})
+	}
+
+	return columns, nil
+}
+
+// GetTables returns the names of the tables and views in the given dataset of the configured project.
+func (d *Client) GetTables(ctx context.Context, schemaName string) ([]string, error) {
+	tables := make([]string, 0)
+	it := d.client.DatasetInProject(d.config.ProjectID, schemaName).Tables(ctx)
+	for {
+		table, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list the tables in dataset %s", schemaName)
+		}
+
+		tables = append(tables, table.TableID)
+	}
+
+	return tables, nil
+}
+
+// GetPrimaryKeyColumns returns the columns of the primary key of the given table, if any.
+func (d *Client) GetPrimaryKeyColumns(ctx context.Context, tableName string) ([]string, error) {
+	tableRef, err := d.getTableRef(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := tableRef.Metadata(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the metadata for table '%s'", tableName)
+	}
+
+	if meta.TableConstraints == nil || meta.TableConstraints.PrimaryKey == nil {
+		return []string{}, nil
+	}
+
+	return meta.TableConstraints.PrimaryKey.Columns, nil
+}
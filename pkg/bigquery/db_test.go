@@ -480,6 +480,7 @@ func TestDB_UpdateTableMetadataIfNotExists(t *testing.T) {
 	tests := []struct {
 		name          string
 		asset         *pipeline.Asset
+		policies      map[string]*pipeline.ClassificationPolicy
 		tableResponse *bigquery2.Table
 		err           error
 	}{
@@ -488,6 +489,36 @@ func TestDB_UpdateTableMetadataIfNotExists(t *testing.T) {
 			asset: &pipeline.Asset{},
 			err:   NoMetadataUpdatedError{},
 		},
+		{
+			name: "policies without a policy tag are not pushed",
+			asset: &pipeline.Asset{
+				Name:    assetName,
+				Columns: []pipeline.Column{{Name: "email", Classification: "pii"}},
+			},
+			policies: map[string]*pipeline.ClassificationPolicy{"email": {SnowflakeTag: "governance.tags.pii"}},
+			err:      NoMetadataUpdatedError{},
+		},
+		{
+			name: "classified columns get their policy tags",
+			asset: &pipeline.Asset{
+				Name: assetName,
+				Columns: []pipeline.Column{
+					{Name: "id"},
+					{Name: "email", Classification: "pii"},
+				},
+			},
+			policies: map[string]*pipeline.ClassificationPolicy{
+				"email": {BigQueryPolicyTag: "projects/p/locations/us/taxonomies/1/policyTags/2"},
+			},
+			tableResponse: &bigquery2.Table{
+				Schema: &bigquery2.TableSchema{
+					Fields: []*bigquery2.TableFieldSchema{
+						{Name: "id"},
+						{Name: "email"},
+					},
+				},
+			},
+		},
 		{
 			name: "asset has description",
 			asset: &pipeline.Asset{
@@ -601,12 +632,24 @@ func TestDB_UpdateTableMetadataIfNotExists(t *testing.T) {
 							}
 						}
 
+						// ensure the policy tags of the classified columns are set, and only theirs
+						for _, col := range table.Schema.Fields {
+							if policy, ok := tt.policies[col.Name]; ok {
+								require.NotNil(t, col.PolicyTags)
+								assert.Equal(t, []string{policy.BigQueryPolicyTag}, col.PolicyTags.Names)
+							} else {
+								assert.Nil(t, col.PolicyTags)
+							}
+						}
+
 						// ensure we didn't drop any columns that we didn't have documented
 						assert.Equal(t, len(tt.tableResponse.Schema.Fields), len(table.Schema.Fields))
 
 						// ensure the primary keys are set correctly
 						primaryKeys := tt.asset.ColumnNamesWithPrimaryKey()
-						assert.Equal(t, primaryKeys, table.TableConstraints.PrimaryKey.Columns)
+						if len(primaryKeys) > 0 {
+							assert.Equal(t, primaryKeys, table.TableConstraints.PrimaryKey.Columns)
+						}
 					} else {
 						assert.Nil(t, tt.tableResponse.Schema)
 					}
@@ -646,7 +689,7 @@ func TestDB_UpdateTableMetadataIfNotExists(t *testing.T) {
 				},
 			}
 
-			err = d.UpdateTableMetadataIfNotExist(context.Background(), tt.asset)
+			err = d.UpdateTableMetadataIfNotExist(context.Background(), tt.asset, tt.policies)
 			if tt.err == nil {
 				require.NoError(t, err)
 			} else {
@@ -1059,6 +1102,27 @@ func TestClient_getTableRef_TableNameValidation(t *testing.T) {
 	}
 }
 
+func TestLabelsFromAnnotations(t *testing.T) {
+	t.Parallel()
+
+	got := labelsFromAnnotations(query.Annotations{
+		"pipeline":    "My Pipeline",
+		"asset":       "dataset.Table_1",
+		"run_id":      "2024_01_01_00_00_00",
+		"1invalid":    "dropped",
+		"environment": strings.Repeat("x", 70),
+	})
+
+	assert.Equal(t, map[string]string{
+		"pipeline":    "my_pipeline",
+		"asset":       "dataset_table_1",
+		"run_id":      "2024_01_01_00_00_00",
+		"environment": strings.Repeat("x", 63),
+	}, got)
+
+	assert.Nil(t, labelsFromAnnotations(nil))
+}
+
 func TestIsSamePartitioning(t *testing.T) {
 	t.Parallel()
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/bruin-data/bruin/pkg/ansisql"
 	"github.com/bruin-data/bruin/pkg/helpers"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 )
@@ -142,5 +143,19 @@ func buildCreateReplaceQuery(asset *pipeline.Asset, query string) (string, error
 		clusterByClause = "CLUSTER BY " + strings.Join(mat.ClusterBy, ", ")
 	}
 
+	if asset.IsContractEnforced() {
+		columnDefinitions, err := ansisql.ContractColumnDefinitions(asset)
+		if err != nil {
+			return "", err
+		}
+
+		query, err = ansisql.ContractSelectQuery(asset, query)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("CREATE OR REPLACE TABLE %s (%s) %s %s AS\n%s", asset.Name, columnDefinitions, partitionClause, clusterByClause, query), nil
+	}
+
 	return fmt.Sprintf("CREATE OR REPLACE TABLE %s %s %s AS\n%s", asset.Name, partitionClause, clusterByClause, query), nil
 }
@@ -265,6 +265,52 @@ func TestMaterializer_Render(t *testing.T) {
 				"WHEN MATCHED THEN UPDATE SET target\\.value = source\\.value\n" +
 				"WHEN NOT MATCHED THEN INSERT\\(dt, event_type, value, value2\\) VALUES\\(dt, event_type, value, value2\\);",
 		},
+		{
+			name: "create+replace with an enforced contract declares and casts the columns",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:        pipeline.MaterializationTypeTable,
+					PartitionBy: "dt",
+					ClusterBy:   []string{"id"},
+				},
+				Contract: pipeline.ContractModeEnforced,
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "INT64", Checks: []pipeline.ColumnCheck{{Name: "not_null"}}},
+					{Name: "name", Type: "STRING"},
+				},
+			},
+			query: "SELECT 1 AS id, 'a' AS name;",
+			want:  "^CREATE OR REPLACE TABLE my\\.asset \\(id INT64 NOT NULL, name STRING\\) PARTITION BY dt CLUSTER BY id AS\nSELECT CAST\\(id AS INT64\\) AS id, CAST\\(name AS STRING\\) AS name FROM \\(\nSELECT 1 AS id, 'a' AS name\n\\) AS __bruin_contract$",
+		},
+		{
+			name: "an enforced contract requires every column to have a type",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type: pipeline.MaterializationTypeTable,
+				},
+				Contract: pipeline.ContractModeEnforced,
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "INT64"},
+					{Name: "name"},
+				},
+			},
+			query:   "SELECT 1 AS id, 'a' AS name;",
+			wantErr: true,
+		},
+		{
+			name: "an enforced contract requires the columns to be declared",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type: pipeline.MaterializationTypeTable,
+				},
+				Contract: pipeline.ContractModeEnforced,
+			},
+			query:   "SELECT 1 AS id, 'a' AS name;",
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -19,6 +19,7 @@ type materializer interface {
 
 type queryExtractor interface {
 	ExtractQueriesFromString(filepath string) ([]*query.Query, error)
+	CloneForAsset(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) query.QueryExtractor
 }
 
 type connectionFetcher interface {
@@ -45,7 +46,13 @@ func (o BasicOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error
 }
 
 func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) error {
-	queries, err := o.extractor.ExtractQueriesFromString(t.ExecutableFile.Content)
+	ctx, err := ansisql.WithTargetTableState(ctx, o.connection, p, t, t.ExecutableFile.Content)
+	if err != nil {
+		return err
+	}
+
+	extractor := o.extractor.CloneForAsset(ctx, p, t)
+	queries, err := extractor.ExtractQueriesFromString(t.ExecutableFile.Content)
 	if err != nil {
 		return errors.Wrap(err, "cannot extract queries from the task file")
 	}
@@ -98,13 +105,18 @@ type ColumnCheckOperator struct {
 func NewColumnCheckOperator(manager connectionFetcher) (*ColumnCheckOperator, error) {
 	return &ColumnCheckOperator{
 		checkRunners: map[string]checkRunner{
-			"not_null":        ansisql.NewNotNullCheck(manager),
-			"unique":          ansisql.NewUniqueCheck(manager),
-			"positive":        ansisql.NewPositiveCheck(manager),
-			"non_negative":    ansisql.NewNonNegativeCheck(manager),
-			"negative":        ansisql.NewNegativeCheck(manager),
-			"accepted_values": &AcceptedValuesCheck{conn: manager},
-			"pattern":         &PatternCheck{conn: manager},
+			"not_null":               ansisql.NewNotNullCheck(manager),
+			"unique":                 ansisql.NewUniqueCheck(manager),
+			"positive":               ansisql.NewPositiveCheck(manager),
+			"non_negative":           ansisql.NewNonNegativeCheck(manager),
+			"negative":               ansisql.NewNegativeCheck(manager),
+			"min":                    ansisql.NewMinCheck(manager),
+			"max":                    ansisql.NewMaxCheck(manager),
+			"relationships":          ansisql.NewRelationshipsCheck(manager),
+			"references":             ansisql.NewRelationshipsCheck(manager),
+			"matches_upstream_count": ansisql.NewMatchesUpstreamCountCheck(manager),
+			"accepted_values":        &AcceptedValuesCheck{conn: manager},
+			"pattern":                &PatternCheck{conn: manager},
 		},
 	}, nil
 }
@@ -149,7 +161,7 @@ func (o *MetadataPushOperator) Run(ctx context.Context, ti scheduler.TaskInstanc
 		return errors.New("no writer found in context, please create an issue for this: https://github.com/bruin-data/bruin/issues")
 	}
 
-	err = client.UpdateTableMetadataIfNotExist(ctx, ti.GetAsset())
+	err = client.UpdateTableMetadataIfNotExist(ctx, ti.GetAsset(), ti.GetPipeline().ColumnPolicies(ti.GetAsset()))
 	if err != nil {
 		var noMetadata NoMetadataUpdatedError
 		if errors.As(err, &noMetadata) {
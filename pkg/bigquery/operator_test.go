@@ -23,6 +23,10 @@ func (m *mockExtractor) ExtractQueriesFromString(content string) ([]*query.Query
 	return res.Get(0).([]*query.Query), res.Error(1)
 }
 
+func (m *mockExtractor) CloneForAsset(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) query.QueryExtractor {
+	return m
+}
+
 type mockMaterializer struct {
 	mock.Mock
 }
@@ -250,7 +254,7 @@ func TestMetadataPushOperator_Run(t *testing.T) {
 		{
 			name: "no metadata to push",
 			setup: func(f *fields) {
-				f.q.On("UpdateTableMetadataIfNotExist", mock.Anything, asset).
+				f.q.On("UpdateTableMetadataIfNotExist", mock.Anything, asset, map[string]*pipeline.ClassificationPolicy{}).
 					Return(NoMetadataUpdatedError{})
 			},
 			t:       asset,
@@ -259,7 +263,7 @@ func TestMetadataPushOperator_Run(t *testing.T) {
 		{
 			name: "other errors are propagated",
 			setup: func(f *fields) {
-				f.q.On("UpdateTableMetadataIfNotExist", mock.Anything, asset).
+				f.q.On("UpdateTableMetadataIfNotExist", mock.Anything, asset, map[string]*pipeline.ClassificationPolicy{}).
 					Return(errors.New("something failed"))
 			},
 			t:       asset,
@@ -268,7 +272,7 @@ func TestMetadataPushOperator_Run(t *testing.T) {
 		{
 			name: "metadata is pushed successfully",
 			setup: func(f *fields) {
-				f.q.On("UpdateTableMetadataIfNotExist", mock.Anything, asset).
+				f.q.On("UpdateTableMetadataIfNotExist", mock.Anything, asset, map[string]*pipeline.ClassificationPolicy{}).
 					Return(nil)
 			},
 			t:       asset,
@@ -0,0 +1,485 @@
+package catalog
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/glossary"
+	"github.com/bruin-data/bruin/pkg/jinja"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+//go:embed templates static
+var files embed.FS
+
+var unsafeFileNameCharacters = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+type renderer interface {
+	WithContext(values jinja.Context) jinja.RendererInterface
+}
+
+// Generator builds a static HTML catalog of the pipelines that works without any network access.
+type Generator struct {
+	fs       afero.Fs
+	renderer renderer
+	now      func() time.Time
+}
+
+func NewGenerator(fs afero.Fs, renderer renderer) *Generator {
+	return &Generator{
+		fs:       fs,
+		renderer: renderer,
+		now:      time.Now,
+	}
+}
+
+type link struct {
+	Name string
+	URL  string
+}
+
+type keyValue struct {
+	Key   string
+	Value string
+}
+
+type columnInfo struct {
+	Name        string
+	Type        string
+	Description string
+	PrimaryKey  bool
+	Checks      []string
+	Entity      *link
+}
+
+type customCheckInfo struct {
+	Name        string
+	Description string
+	Value       int64
+	Blocking    bool
+	Query       string
+}
+
+type assetInfo struct {
+	Name            string
+	Type            string
+	Description     string
+	Summary         string
+	Owner           string
+	Connection      string
+	Tags            []string
+	URL             string
+	Pipeline        string
+	PipelineURL     string
+	Materialization []keyValue
+	Parameters      []keyValue
+	Columns         []columnInfo
+	CustomChecks    []customCheckInfo
+	Upstreams       []link
+	Downstreams     []link
+	Code            string
+	Rendered        bool
+	RenderError     string
+}
+
+type pipelineInfo struct {
+	Name     string
+	Schedule string
+	URL      string
+	Assets   []*assetInfo
+}
+
+type attributeInfo struct {
+	Name        string
+	Type        string
+	Description string
+	UsedBy      []link
+}
+
+type entityInfo struct {
+	Name        string
+	Description string
+	Anchor      string
+	Attributes  []*attributeInfo
+}
+
+type searchEntry struct {
+	Title string `json:"title"`
+	Kind  string `json:"kind"`
+	URL   string `json:"url"`
+	Text  string `json:"text"`
+}
+
+type page struct {
+	Root        string
+	Title       string
+	GeneratedAt string
+	Pipelines   []*pipelineInfo
+	Pipeline    *pipelineInfo
+	Asset       *assetInfo
+	Entities    []*entityInfo
+}
+
+// Generate writes the catalog of the given pipelines and glossary to the output folder.
+func (g *Generator) Generate(outputDir string, pipelines []*pipeline.Pipeline, gl *glossary.Glossary) error {
+	templates, err := template.ParseFS(files, "templates/*.html")
+	if err != nil {
+		return errors.Wrap(err, "failed to parse the catalog templates")
+	}
+
+	if gl == nil {
+		gl = &glossary.Glossary{}
+	}
+
+	entities := buildEntities(gl)
+	entitiesByName := make(map[string]*entityInfo, len(entities))
+	for _, entity := range entities {
+		entitiesByName[entity.Name] = entity
+	}
+
+	sortedPipelines := make([]*pipeline.Pipeline, len(pipelines))
+	copy(sortedPipelines, pipelines)
+	sort.Slice(sortedPipelines, func(i, j int) bool {
+		return sortedPipelines[i].Name < sortedPipelines[j].Name
+	})
+
+	infos := make([]*pipelineInfo, 0, len(sortedPipelines))
+	index := make([]searchEntry, 0)
+	for _, p := range sortedPipelines {
+		info := g.buildPipeline(p, entitiesByName)
+		infos = append(infos, info)
+
+		index = append(index, searchEntry{
+			Title: info.Name,
+			Kind:  "pipeline",
+			URL:   info.URL,
+			Text:  strings.ToLower(info.Name),
+		})
+		for _, asset := range info.Assets {
+			index = append(index, assetSearchEntry(asset))
+		}
+	}
+
+	for _, entity := range entities {
+		text := []string{entity.Name, entity.Description}
+		for _, attr := range entity.Attributes {
+			text = append(text, attr.Name, attr.Description)
+		}
+		index = append(index, searchEntry{
+			Title: entity.Name,
+			Kind:  "entity",
+			URL:   "glossary.html#" + entity.Anchor,
+			Text:  strings.ToLower(strings.Join(text, " ")),
+		})
+	}
+
+	generatedAt := g.now().UTC().Format(time.RFC3339)
+	newPage := func(root, title string) page {
+		return page{Root: root, Title: title, GeneratedAt: generatedAt, Pipelines: infos, Entities: entities}
+	}
+
+	if err := g.writeTemplate(templates, "index.html", filepath.Join(outputDir, "index.html"), newPage("", "Pipelines")); err != nil {
+		return err
+	}
+
+	if err := g.writeTemplate(templates, "glossary.html", filepath.Join(outputDir, "glossary.html"), newPage("", "Glossary")); err != nil {
+		return err
+	}
+
+	for _, info := range infos {
+		pipelinePage := newPage("../", info.Name)
+		pipelinePage.Pipeline = info
+		if err := g.writeTemplate(templates, "pipeline.html", filepath.Join(outputDir, filepath.FromSlash(info.URL)), pipelinePage); err != nil {
+			return err
+		}
+
+		for _, asset := range info.Assets {
+			assetPage := newPage("../../", asset.Name)
+			assetPage.Asset = asset
+			if err := g.writeTemplate(templates, "asset.html", filepath.Join(outputDir, filepath.FromSlash(asset.URL)), assetPage); err != nil {
+				return err
+			}
+		}
+	}
+
+	return g.writeStaticFiles(outputDir, index)
+}
+
+func (g *Generator) buildPipeline(p *pipeline.Pipeline, entities map[string]*entityInfo) *pipelineInfo {
+	info := &pipelineInfo{
+		Name:     p.Name,
+		Schedule: string(p.Schedule),
+		URL:      "pipelines/" + fileName(p.Name) + ".html",
+		Assets:   make([]*assetInfo, 0, len(p.Assets)),
+	}
+
+	assetURL := func(a *pipeline.Asset) string {
+		return "assets/" + fileName(p.Name) + "/" + fileName(a.Name) + ".html"
+	}
+
+	for _, asset := range p.Assets {
+		a := &assetInfo{
+			Name:        asset.Name,
+			Type:        string(asset.Type),
+			Description: asset.Description,
+			Summary:     summary(asset.Description),
+			Owner:       asset.Owner,
+			Connection:  asset.Connection,
+			Tags:        asset.Tags,
+			URL:         assetURL(asset),
+			Pipeline:    p.Name,
+			PipelineURL: info.URL,
+		}
+
+		a.Materialization = materializationProperties(asset.Materialization)
+		a.Parameters = sortedKeyValues(asset.Parameters)
+
+		for _, upstream := range asset.Upstreams {
+			if upstream.Type == "uri" {
+				a.Upstreams = append(a.Upstreams, link{Name: upstream.Value})
+				continue
+			}
+
+			upstreamAsset := p.GetAssetByName(upstream.Value)
+			if upstreamAsset == nil {
+				a.Upstreams = append(a.Upstreams, link{Name: upstream.Value})
+				continue
+			}
+			a.Upstreams = append(a.Upstreams, link{Name: upstreamAsset.Name, URL: "../../" + assetURL(upstreamAsset)})
+		}
+
+		for _, downstream := range asset.GetDownstream() {
+			a.Downstreams = append(a.Downstreams, link{Name: downstream.Name, URL: "../../" + assetURL(downstream)})
+		}
+
+		for _, column := range asset.Columns {
+			c := columnInfo{
+				Name:        column.Name,
+				Type:        column.Type,
+				Description: column.Description,
+				PrimaryKey:  column.PrimaryKey,
+			}
+
+			for _, check := range column.Checks {
+				value := check.Value.ToString()
+				if value != "" {
+					c.Checks = append(c.Checks, fmt.Sprintf("%s: %s", check.Name, value))
+				} else {
+					c.Checks = append(c.Checks, check.Name)
+				}
+			}
+
+			if column.EntityAttribute != nil {
+				if entity, ok := entities[column.EntityAttribute.Entity]; ok {
+					c.Entity = &link{
+						Name: column.EntityAttribute.Entity + "." + column.EntityAttribute.Attribute,
+						URL:  "glossary.html#" + entity.Anchor,
+					}
+
+					for _, attr := range entity.Attributes {
+						if attr.Name == column.EntityAttribute.Attribute {
+							attr.UsedBy = append(attr.UsedBy, link{Name: asset.Name + "." + column.Name, URL: a.URL + "#column-" + column.Name})
+						}
+					}
+				}
+			}
+
+			a.Columns = append(a.Columns, c)
+		}
+
+		for _, check := range asset.CustomChecks {
+			a.CustomChecks = append(a.CustomChecks, customCheckInfo{
+				Name:        check.Name,
+				Description: check.Description,
+				Value:       check.Value,
+				Blocking:    check.Blocking.Bool(),
+				Query:       check.Query,
+			})
+		}
+
+		g.setCode(a, p, asset)
+
+		info.Assets = append(info.Assets, a)
+	}
+
+	sort.Slice(info.Assets, func(i, j int) bool {
+		return info.Assets[i].Name < info.Assets[j].Name
+	})
+
+	return info
+}
+
+// setCode sets the rendered query for the SQL assets, and the source code for the rest.
+func (g *Generator) setCode(a *assetInfo, p *pipeline.Pipeline, asset *pipeline.Asset) {
+	content := strings.TrimSpace(asset.ExecutableFile.Content)
+	if content == "" {
+		return
+	}
+
+	a.Code = content
+	if !asset.IsSQLAsset() || g.renderer == nil {
+		return
+	}
+
+	rendered, err := g.renderer.WithContext(pipeline.AssetRenderContext(context.Background(), p, asset)).Render(content)
+	if err != nil {
+		a.RenderError = err.Error()
+		return
+	}
+
+	a.Code = strings.TrimSpace(rendered)
+	a.Rendered = true
+}
+
+func (g *Generator) writeTemplate(templates *template.Template, name, path string, data page) error {
+	if err := g.fs.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return errors.Wrapf(err, "failed to create the folder for '%s'", path)
+	}
+
+	file, err := g.fs.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create '%s'", path)
+	}
+	defer file.Close()
+
+	if err := templates.ExecuteTemplate(file, name, data); err != nil {
+		return errors.Wrapf(err, "failed to render '%s'", path)
+	}
+
+	return nil
+}
+
+func (g *Generator) writeStaticFiles(outputDir string, index []searchEntry) error {
+	staticDir := filepath.Join(outputDir, "static")
+	if err := g.fs.MkdirAll(staticDir, 0o755); err != nil {
+		return errors.Wrap(err, "failed to create the static folder")
+	}
+
+	err := fs.WalkDir(files, "static", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		content, err := files.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		return afero.WriteFile(g.fs, filepath.Join(outputDir, filepath.FromSlash(path)), content, 0o644)
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to copy the static files")
+	}
+
+	// the index is loaded as a script rather than fetched as JSON, browsers do not allow fetching local files
+	jsonIndex, err := json.Marshal(index)
+	if err != nil {
+		return errors.Wrap(err, "failed to build the search index")
+	}
+
+	return afero.WriteFile(g.fs, filepath.Join(staticDir, "search-index.js"), []byte("window.bruinSearchIndex = "+string(jsonIndex)+";\n"), 0o644)
+}
+
+func buildEntities(gl *glossary.Glossary) []*entityInfo {
+	entities := make([]*entityInfo, 0, len(gl.Entities))
+	for _, entity := range gl.Entities {
+		info := &entityInfo{
+			Name:        entity.Name,
+			Description: entity.Description,
+			Anchor:      "entity-" + fileName(entity.Name),
+			Attributes:  make([]*attributeInfo, 0, len(entity.Attributes)),
+		}
+
+		for _, attr := range entity.Attributes {
+			info.Attributes = append(info.Attributes, &attributeInfo{
+				Name:        attr.Name,
+				Type:        attr.Type,
+				Description: attr.Description,
+			})
+		}
+		sort.Slice(info.Attributes, func(i, j int) bool {
+			return info.Attributes[i].Name < info.Attributes[j].Name
+		})
+
+		entities = append(entities, info)
+	}
+
+	sort.Slice(entities, func(i, j int) bool {
+		return entities[i].Name < entities[j].Name
+	})
+
+	return entities
+}
+
+func assetSearchEntry(asset *assetInfo) searchEntry {
+	text := []string{asset.Name, asset.Pipeline, asset.Type, asset.Owner, asset.Description}
+	text = append(text, asset.Tags...)
+	for _, column := range asset.Columns {
+		text = append(text, column.Name, column.Description)
+	}
+
+	return searchEntry{
+		Title: asset.Name,
+		Kind:  "asset",
+		URL:   asset.URL,
+		Text:  strings.ToLower(strings.Join(text, " ")),
+	}
+}
+
+func materializationProperties(m pipeline.Materialization) []keyValue {
+	properties := make([]keyValue, 0)
+	add := func(key, value string) {
+		if value != "" {
+			properties = append(properties, keyValue{Key: key, Value: value})
+		}
+	}
+
+	add("Materialization", string(m.Type))
+	add("Strategy", string(m.Strategy))
+	add("Partition by", m.PartitionBy)
+	add("Cluster by", strings.Join(m.ClusterBy, ", "))
+	add("Incremental key", m.IncrementalKey)
+	add("Time granularity", m.TimeGranularity)
+
+	return properties
+}
+
+func sortedKeyValues(values map[string]string) []keyValue {
+	result := make([]keyValue, 0, len(values))
+	for key, value := range values {
+		result = append(result, keyValue{Key: key, Value: value})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+
+	return result
+}
+
+// summary returns the first line of the description, shortened to fit in a table cell.
+func summary(description string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(description), "\n")
+	const maxLength = 140
+	if len(line) > maxLength {
+		return strings.TrimSpace(line[:maxLength]) + "..."
+	}
+
+	return line
+}
+
+// fileName makes the given name safe to use in file names and URLs.
+func fileName(name string) string {
+	return unsafeFileNameCharacters.ReplaceAllString(name, "-")
+}
@@ -0,0 +1,119 @@
+package catalog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/glossary"
+	"github.com/bruin-data/bruin/pkg/jinja"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_Generate(t *testing.T) {
+	t.Parallel()
+
+	orders := &pipeline.Asset{
+		Name:        "raw.orders",
+		Type:        pipeline.AssetTypeIngestr,
+		Description: "Orders from the shop",
+		Owner:       "data@example.com",
+		Upstreams:   []pipeline.Upstream{{Type: "uri", Value: "s3://bucket/orders.csv"}},
+		Parameters:  map[string]string{"source_connection": "shop"},
+	}
+	revenue := &pipeline.Asset{
+		Name:        "mart.revenue",
+		Type:        pipeline.AssetTypeBigqueryQuery,
+		Description: "Daily revenue\nper customer",
+		Tags:        []string{"finance"},
+		Materialization: pipeline.Materialization{
+			Type:     pipeline.MaterializationTypeTable,
+			Strategy: pipeline.MaterializationStrategyDeleteInsert,
+		},
+		Columns: []pipeline.Column{
+			{
+				Name:            "customer_id",
+				Type:            "INTEGER",
+				PrimaryKey:      true,
+				EntityAttribute: &pipeline.EntityAttribute{Entity: "Customer", Attribute: "ID"},
+				Checks:          []pipeline.ColumnCheck{{Name: "not_null"}},
+			},
+		},
+		CustomChecks: []pipeline.CustomCheck{{Name: "has rows", Value: 1, Query: "SELECT count(*) > 0 FROM {{ this }}"}},
+		Upstreams:    []pipeline.Upstream{{Type: "asset", Value: "raw.orders"}},
+		ExecutableFile: pipeline.ExecutableFile{
+			Content: "SELECT customer_id FROM raw.orders WHERE dt = '{{ start_date }}'",
+		},
+	}
+	revenue.AddUpstream(orders)
+	orders.AddDownstream(revenue)
+
+	p := &pipeline.Pipeline{
+		Name:     "sales",
+		Schedule: "daily",
+		Assets:   []*pipeline.Asset{orders, revenue},
+	}
+	gl := &glossary.Glossary{
+		Entities: []*glossary.Entity{
+			{
+				Name:        "Customer",
+				Description: "A customer of the shop",
+				Attributes: map[string]*glossary.Attribute{
+					"ID": {Name: "ID", Type: "integer", Description: "The unique identifier"},
+				},
+			},
+		},
+	}
+
+	fs := afero.NewMemMapFs()
+	generator := NewGenerator(fs, jinja.NewRendererWithYesterday("sales", "run-id"))
+	generator.now = func() time.Time {
+		return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	}
+
+	require.NoError(t, generator.Generate("/out", []*pipeline.Pipeline{p}, gl))
+
+	read := func(path string) string {
+		content, err := afero.ReadFile(fs, path)
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	index := read("/out/index.html")
+	assert.Contains(t, index, `<a href="pipelines/sales.html">sales</a>`)
+	assert.Contains(t, index, "Generated by Bruin at 2024-01-02T03:04:05Z")
+
+	pipelinePage := read("/out/pipelines/sales.html")
+	assert.Contains(t, pipelinePage, `<a href="../assets/sales/mart.revenue.html">mart.revenue</a>`)
+	assert.Contains(t, pipelinePage, "<td>Daily revenue</td>")
+
+	revenuePage := read("/out/assets/sales/mart.revenue.html")
+	assert.Contains(t, revenuePage, `<a href="../../assets/sales/raw.orders.html">raw.orders</a>`)
+	assert.Contains(t, revenuePage, `<tr><th>Strategy</th><td>delete&#43;insert</td></tr>`)
+	assert.Contains(t, revenuePage, `<span class="check">not_null</span>`)
+	assert.Contains(t, revenuePage, `<a href="../../glossary.html#entity-Customer">Customer.ID</a>`)
+	assert.Contains(t, revenuePage, "SELECT count(*) &gt; 0 FROM {{ this }}")
+	assert.Contains(t, revenuePage, "Rendered query")
+	assert.NotContains(t, revenuePage, "{{ start_date }}")
+
+	ordersPage := read("/out/assets/sales/raw.orders.html")
+	assert.Contains(t, ordersPage, `<span class="external">s3://bucket/orders.csv</span>`)
+	assert.Contains(t, ordersPage, `<a href="../../assets/sales/mart.revenue.html">mart.revenue</a>`)
+	assert.Contains(t, ordersPage, "<tr><th>source_connection</th><td>shop</td></tr>")
+
+	glossaryPage := read("/out/glossary.html")
+	assert.Contains(t, glossaryPage, `<section id="entity-Customer">`)
+	assert.Contains(t, glossaryPage, `<a href="assets/sales/mart.revenue.html#column-customer_id">mart.revenue.customer_id</a>`)
+
+	searchIndex := read("/out/static/search-index.js")
+	assert.Contains(t, searchIndex, `{"title":"mart.revenue","kind":"asset","url":"assets/sales/mart.revenue.html"`)
+	assert.Contains(t, searchIndex, `{"title":"Customer","kind":"entity","url":"glossary.html#entity-Customer"`)
+
+	for _, static := range []string{"/out/static/style.css", "/out/static/search.js"} {
+		exists, err := afero.Exists(fs, static)
+		require.NoError(t, err)
+		assert.True(t, exists, static)
+	}
+}
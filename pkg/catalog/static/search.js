@@ -0,0 +1,39 @@
+(function () {
+  var input = document.getElementById("search");
+  var results = document.getElementById("search-results");
+  var root = document.body.getAttribute("data-root") || "";
+  var index = window.bruinSearchIndex || [];
+
+  function render(query) {
+    results.innerHTML = "";
+    var terms = query.toLowerCase().split(/\s+/).filter(Boolean);
+    if (terms.length === 0) {
+      return;
+    }
+
+    var matches = index.filter(function (entry) {
+      return terms.every(function (term) {
+        return entry.text.indexOf(term) !== -1;
+      });
+    }).slice(0, 30);
+
+    matches.forEach(function (entry) {
+      var item = document.createElement("li");
+      var link = document.createElement("a");
+      link.href = root + entry.url;
+
+      var kind = document.createElement("span");
+      kind.className = "kind";
+      kind.textContent = entry.kind;
+
+      link.appendChild(kind);
+      link.appendChild(document.createTextNode(entry.title));
+      item.appendChild(link);
+      results.appendChild(item);
+    });
+  }
+
+  input.addEventListener("input", function () {
+    render(input.value);
+  });
+})();
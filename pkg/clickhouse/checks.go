@@ -44,11 +44,12 @@ func (c *AcceptedValuesCheck) Check(ctx context.Context, ti *scheduler.ColumnChe
 
 	res := strings.Join(val, "','")
 	res = fmt.Sprintf("'%s'", res)
-	qq := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE CAST(%s as TEXT) NOT IN (%s)", ti.GetAsset().Name, ti.Column.Name, res)
+	filter := fmt.Sprintf("CAST(%s as TEXT) NOT IN (%s)", ti.Column.Name, res)
+	qq := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", ti.GetAsset().Name, filter)
 
 	return ansisql.NewCountableQueryCheck(c.conn, 0, &query.Query{Query: qq}, "positive", func(count int64) error {
 		return errors.Errorf("column '%s' has %d rows that are not in the accepted values", ti.Column.Name, count)
-	}).Check(ctx, ti)
+	}).WithFailingRowsQuery(fmt.Sprintf("SELECT * FROM %s WHERE %s", ti.GetAsset().Name, filter)).Check(ctx, ti)
 }
 
 type PatternCheck struct {
@@ -59,26 +60,31 @@ func (c *PatternCheck) Check(ctx context.Context, ti *scheduler.ColumnCheckInsta
 	if ti.Check.Value.String == nil {
 		return errors.Errorf("unexpected value %s for pattern check, the value must be a string", ti.Check.Value.ToString())
 	}
-	qq := fmt.Sprintf(
-		"SELECT count(*) FROM %s WHERE NOT match(%s,'%s')",
-		ti.GetAsset().Name,
+	filter := fmt.Sprintf(
+		"NOT match(%s,'%s')",
 		ti.Column.Name,
 		*ti.Check.Value.String,
 	)
+	qq := fmt.Sprintf("SELECT count(*) FROM %s WHERE %s", ti.GetAsset().Name, filter)
 
 	return ansisql.NewCountableQueryCheck(c.conn, 0, &query.Query{Query: qq}, "pattern", func(count int64) error {
 		return errors.Errorf("column %s has %d values that don't satisfy the pattern %s", ti.Column.Name, count, *ti.Check.Value.String)
-	}).Check(ctx, ti)
+	}).WithFailingRowsQuery(fmt.Sprintf("SELECT * FROM %s WHERE %s", ti.GetAsset().Name, filter)).Check(ctx, ti)
 }
 
 func NewColumnCheckOperator(manager connectionFetcher) *ansisql.ColumnCheckOperator {
 	return ansisql.NewColumnCheckOperator(map[string]ansisql.CheckRunner{
-		"not_null":        ansisql.NewNotNullCheck(manager),
-		"unique":          ansisql.NewUniqueCheck(manager),
-		"positive":        ansisql.NewPositiveCheck(manager),
-		"non_negative":    ansisql.NewNonNegativeCheck(manager),
-		"negative":        ansisql.NewNegativeCheck(manager),
-		"accepted_values": &AcceptedValuesCheck{conn: manager},
-		"pattern":         &PatternCheck{conn: manager},
+		"not_null":               ansisql.NewNotNullCheck(manager),
+		"unique":                 ansisql.NewUniqueCheck(manager),
+		"positive":               ansisql.NewPositiveCheck(manager),
+		"non_negative":           ansisql.NewNonNegativeCheck(manager),
+		"negative":               ansisql.NewNegativeCheck(manager),
+		"min":                    ansisql.NewMinCheck(manager),
+		"max":                    ansisql.NewMaxCheck(manager),
+		"relationships":          ansisql.NewRelationshipsCheck(manager),
+		"references":             ansisql.NewRelationshipsCheck(manager),
+		"matches_upstream_count": ansisql.NewMatchesUpstreamCountCheck(manager),
+		"accepted_values":        &AcceptedValuesCheck{conn: manager},
+		"pattern":                &PatternCheck{conn: manager},
 	})
 }
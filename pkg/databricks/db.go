@@ -2,8 +2,10 @@ package databricks
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
+	"github.com/bruin-data/bruin/pkg/ansisql"
 	"github.com/bruin-data/bruin/pkg/query"
 	_ "github.com/databricks/databricks-sql-go"
 	"github.com/jmoiron/sqlx"
@@ -24,13 +26,13 @@ func NewDB(c *Config) (*DB, error) {
 	return &DB{conn: conn, config: c}, nil
 }
 
-func (db *DB) RunQueryWithoutResult(ctx context.Context, query *query.Query) error {
-	_, err := db.Select(ctx, query)
+func (db *DB) RunQueryWithoutResult(ctx context.Context, queryObj *query.Query) error {
+	_, err := db.Select(ctx, queryObj)
 	return err
 }
 
-func (db *DB) Select(ctx context.Context, query *query.Query) ([][]interface{}, error) {
-	queryString := query.String()
+func (db *DB) Select(ctx context.Context, queryObj *query.Query) ([][]interface{}, error) {
+	queryString := query.AnnotateQueryWithComment(ctx, queryObj.String())
 	rows, err := db.conn.QueryContext(ctx, queryString)
 	if err == nil {
 		err = rows.Err()
@@ -85,3 +87,49 @@ func (db *DB) Ping(ctx context.Context) error {
 
 	return nil
 }
+
+// GetTableColumns returns the columns of the given table in the order they are defined in the current catalog.
+func (db *DB) GetTableColumns(ctx context.Context, tableName string) ([]*ansisql.DBColumn, error) {
+	tableComponents := strings.Split(strings.ToLower(tableName), ".")
+	if len(tableComponents) != 2 {
+		return nil, errors.Errorf("table name must be in schema.table format, '%s' given", tableName)
+	}
+
+	queryStr := fmt.Sprintf(
+		`SELECT column_name, data_type, is_nullable, comment
+FROM information_schema.columns
+WHERE table_schema = '%s' AND table_name = '%s'
+ORDER BY ordinal_position`,
+		ansisql.EscapeSQLString(tableComponents[0]),
+		ansisql.EscapeSQLString(tableComponents[1]),
+	)
+
+	rows, err := db.Select(ctx, &query.Query{Query: queryStr})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query column metadata for %s", tableName)
+	}
+
+	if len(rows) == 0 {
+		return nil, ansisql.ErrTableNotFound
+	}
+
+	return ansisql.ColumnsFromInformationSchemaRows(rows)
+}
+
+// GetTables returns the names of the tables and views in the given schema of the current catalog.
+func (db *DB) GetTables(ctx context.Context, schemaName string) ([]string, error) {
+	queryStr := fmt.Sprintf(
+		`SELECT table_name
+FROM information_schema.tables
+WHERE table_schema = '%s'
+ORDER BY table_name`,
+		ansisql.EscapeSQLString(strings.ToLower(schemaName)),
+	)
+
+	rows, err := db.Select(ctx, &query.Query{Query: queryStr})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the tables in schema %s", schemaName)
+	}
+
+	return ansisql.StringsFromRows(rows), nil
+}
@@ -2,6 +2,8 @@ package databricks
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/bruin-data/bruin/pkg/ansisql"
 	"github.com/bruin-data/bruin/pkg/pipeline"
@@ -22,6 +24,7 @@ type Client interface {
 
 type queryExtractor interface {
 	ExtractQueriesFromString(content string) ([]*query.Query, error)
+	CloneForAsset(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) query.QueryExtractor
 }
 
 type connectionFetcher interface {
@@ -48,7 +51,13 @@ func (o BasicOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error
 }
 
 func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) error {
-	queries, err := o.extractor.ExtractQueriesFromString(t.ExecutableFile.Content)
+	ctx, err := ansisql.WithTargetTableState(ctx, o.connection, p, t, t.ExecutableFile.Content)
+	if err != nil {
+		return err
+	}
+
+	extractor := o.extractor.CloneForAsset(ctx, p, t)
+	queries, err := extractor.ExtractQueriesFromString(t.ExecutableFile.Content)
 	if err != nil {
 		return errors.Wrap(err, "cannot extract queries from the task file")
 	}
@@ -90,12 +99,55 @@ func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pip
 
 func NewColumnCheckOperator(manager connectionFetcher) *ansisql.ColumnCheckOperator {
 	return ansisql.NewColumnCheckOperator(map[string]ansisql.CheckRunner{
-		"not_null":        ansisql.NewNotNullCheck(manager),
-		"unique":          ansisql.NewUniqueCheck(manager),
-		"positive":        ansisql.NewPositiveCheck(manager),
-		"non_negative":    ansisql.NewNonNegativeCheck(manager),
-		"negative":        ansisql.NewNegativeCheck(manager),
-		"accepted_values": &AcceptedValuesCheck{conn: manager},
-		"pattern":         &PatternCheck{conn: manager},
+		"not_null":               ansisql.NewNotNullCheck(manager),
+		"unique":                 ansisql.NewUniqueCheck(manager),
+		"positive":               ansisql.NewPositiveCheck(manager),
+		"non_negative":           ansisql.NewNonNegativeCheck(manager),
+		"negative":               ansisql.NewNegativeCheck(manager),
+		"min":                    ansisql.NewMinCheck(manager),
+		"max":                    ansisql.NewMaxCheck(manager),
+		"relationships":          ansisql.NewRelationshipsCheck(manager),
+		"references":             ansisql.NewRelationshipsCheck(manager),
+		"matches_upstream_count": ansisql.NewMatchesUpstreamCountCheck(manager),
+		"accepted_values":        &AcceptedValuesCheck{conn: manager},
+		"pattern":                &PatternCheck{conn: manager},
 	})
 }
+
+// NewMetadataPushOperator pushes the metadata of the assets as comments and table properties.
+func NewMetadataPushOperator(conn connectionFetcher) *ansisql.MetadataPushOperator {
+	return ansisql.NewMetadataPushOperator(conn, MetadataQueries)
+}
+
+// MetadataQueries returns the queries that comment the table with its description and its columns with their
+// descriptions, classifications and tags, the owner and the tags of the asset are set as the `bruin.owner` and
+// `bruin.tags` table properties. The views are skipped since their columns cannot be altered.
+func MetadataQueries(asset *pipeline.Asset, _ map[string]*pipeline.ClassificationPolicy) ([]string, error) {
+	if asset.Materialization.Type == pipeline.MaterializationTypeView {
+		return nil, nil
+	}
+
+	queries := make([]string, 0)
+	if asset.Description != "" {
+		queries = append(queries, fmt.Sprintf("COMMENT ON TABLE %s IS '%s'", asset.Name, ansisql.EscapeSQLString(asset.Description)))
+	}
+
+	properties := make([]string, 0, 2)
+	if asset.Owner != "" {
+		properties = append(properties, fmt.Sprintf("'bruin.owner' = '%s'", ansisql.EscapeSQLString(asset.Owner)))
+	}
+	if len(asset.Tags) > 0 {
+		properties = append(properties, fmt.Sprintf("'bruin.tags' = '%s'", ansisql.EscapeSQLString(strings.Join(asset.Tags, ","))))
+	}
+	if len(properties) > 0 {
+		queries = append(queries, fmt.Sprintf("ALTER TABLE %s SET TBLPROPERTIES (%s)", asset.Name, strings.Join(properties, ", ")))
+	}
+
+	for _, col := range asset.Columns {
+		if comment := ansisql.ColumnComment(col); comment != "" {
+			queries = append(queries, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s COMMENT '%s'", asset.Name, col.Name, ansisql.EscapeSQLString(comment)))
+		}
+	}
+
+	return queries, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type mockExtractor struct {
@@ -20,6 +21,10 @@ func (m *mockExtractor) ExtractQueriesFromString(content string) ([]*query.Query
 	return res.Get(0).([]*query.Query), res.Error(1)
 }
 
+func (m *mockExtractor) CloneForAsset(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) query.QueryExtractor {
+	return m
+}
+
 type mockMaterializer struct {
 	mock.Mock
 }
@@ -214,3 +219,59 @@ func TestBasicOperator_RunTask(t *testing.T) {
 		})
 	}
 }
+
+func TestMetadataQueries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		asset *pipeline.Asset
+		want  []string
+	}{
+		{
+			name: "assets without metadata are skipped",
+			asset: &pipeline.Asset{
+				Name:    "sales.customers",
+				Columns: []pipeline.Column{{Name: "id"}},
+			},
+			want: []string{},
+		},
+		{
+			name: "descriptions, owner, classifications and tags",
+			asset: &pipeline.Asset{
+				Name:        "sales.customers",
+				Description: "the customer's details",
+				Owner:       "data-team",
+				Tags:        []string{"core", "crm"},
+				Columns: []pipeline.Column{
+					{Name: "id", Description: "the id"},
+					{Name: "email", Description: "the e-mail address.", Classification: "pii", Tags: []string{"gdpr"}},
+				},
+			},
+			want: []string{
+				"COMMENT ON TABLE sales.customers IS 'the customer''s details'",
+				"ALTER TABLE sales.customers SET TBLPROPERTIES ('bruin.owner' = 'data-team', 'bruin.tags' = 'core,crm')",
+				"ALTER TABLE sales.customers ALTER COLUMN id COMMENT 'the id'",
+				"ALTER TABLE sales.customers ALTER COLUMN email COMMENT 'the e-mail address. [classification: pii; tags: gdpr]'",
+			},
+		},
+		{
+			name: "views are skipped",
+			asset: &pipeline.Asset{
+				Name:            "sales.active_customers",
+				Description:     "the active customers",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeView},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := MetadataQueries(tt.asset, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
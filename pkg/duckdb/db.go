@@ -3,9 +3,13 @@ package duck
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 
+	"github.com/bruin-data/bruin/pkg/ansisql"
 	"github.com/bruin-data/bruin/pkg/query"
 	_ "github.com/marcboeker/go-duckdb"
+	"github.com/pkg/errors"
 )
 
 type Client struct {
@@ -34,10 +38,10 @@ func NewClient(c DuckDBConfig) (*Client, error) {
 	return &Client{connection: conn, config: c}, nil
 }
 
-func (c *Client) RunQueryWithoutResult(ctx context.Context, query *query.Query) error {
+func (c *Client) RunQueryWithoutResult(ctx context.Context, queryObj *query.Query) error {
 	LockDatabase(c.config.ToDBConnectionURI())
 	defer UnlockDatabase(c.config.ToDBConnectionURI())
-	_, err := c.connection.ExecContext(ctx, query.String())
+	_, err := c.connection.ExecContext(ctx, query.AnnotateQueryWithComment(ctx, queryObj.String()))
 	if err != nil {
 		return err
 	}
@@ -54,11 +58,11 @@ func (c *Client) GetDBConnectionURI() (string, error) {
 }
 
 // Select runs a query and returns the results.
-func (c *Client) Select(ctx context.Context, query *query.Query) ([][]interface{}, error) {
+func (c *Client) Select(ctx context.Context, queryObj *query.Query) ([][]interface{}, error) {
 	LockDatabase(c.config.ToDBConnectionURI())
 	defer UnlockDatabase(c.config.ToDBConnectionURI())
 
-	rows, err := c.connection.QueryContext(ctx, query.String())
+	rows, err := c.connection.QueryContext(ctx, query.AnnotateQueryWithComment(ctx, queryObj.String()))
 	if err != nil {
 		return nil, err
 	}
@@ -97,7 +101,7 @@ func (c *Client) SelectWithSchema(ctx context.Context, queryObject *query.Query)
 	LockDatabase(c.config.ToDBConnectionURI())
 	defer UnlockDatabase(c.config.ToDBConnectionURI())
 
-	rows, err := c.connection.QueryContext(ctx, queryObject.String())
+	rows, err := c.connection.QueryContext(ctx, query.AnnotateQueryWithComment(ctx, queryObject.String()))
 	if err != nil {
 		return nil, err
 	}
@@ -138,3 +142,85 @@ func (c *Client) SelectWithSchema(ctx context.Context, queryObject *query.Query)
 
 	return result, nil
 }
+
+// GetTableColumns returns the columns of the given table in the order they are defined in the database. Tables without
+// a schema are looked up in the "main" schema.
+func (c *Client) GetTableColumns(ctx context.Context, tableName string) ([]*ansisql.DBColumn, error) {
+	schemaName, table, err := splitTableName(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	queryStr := fmt.Sprintf(
+		`SELECT column_name, data_type, is_nullable, comment
+FROM duckdb_columns()
+WHERE schema_name = '%s' AND table_name = '%s'
+ORDER BY column_index`,
+		ansisql.EscapeSQLString(schemaName),
+		ansisql.EscapeSQLString(table),
+	)
+
+	rows, err := c.Select(ctx, &query.Query{Query: queryStr})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query column metadata for %s", tableName)
+	}
+
+	if len(rows) == 0 {
+		return nil, ansisql.ErrTableNotFound
+	}
+
+	return ansisql.ColumnsFromInformationSchemaRows(rows)
+}
+
+// GetTables returns the names of the tables and views in the given schema.
+func (c *Client) GetTables(ctx context.Context, schemaName string) ([]string, error) {
+	queryStr := fmt.Sprintf(
+		`SELECT table_name
+FROM information_schema.tables
+WHERE table_schema = '%s'
+ORDER BY table_name`,
+		ansisql.EscapeSQLString(schemaName),
+	)
+
+	rows, err := c.Select(ctx, &query.Query{Query: queryStr})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the tables in schema %s", schemaName)
+	}
+
+	return ansisql.StringsFromRows(rows), nil
+}
+
+// GetPrimaryKeyColumns returns the columns of the primary key of the given table, if any.
+func (c *Client) GetPrimaryKeyColumns(ctx context.Context, tableName string) ([]string, error) {
+	schemaName, table, err := splitTableName(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	queryStr := fmt.Sprintf(
+		`SELECT unnest(constraint_column_names)
+FROM duckdb_constraints()
+WHERE constraint_type = 'PRIMARY KEY' AND schema_name = '%s' AND table_name = '%s'`,
+		ansisql.EscapeSQLString(schemaName),
+		ansisql.EscapeSQLString(table),
+	)
+
+	rows, err := c.Select(ctx, &query.Query{Query: queryStr})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query the primary key of %s", tableName)
+	}
+
+	return ansisql.StringsFromRows(rows), nil
+}
+
+func splitTableName(tableName string) (string, string, error) {
+	tableComponents := strings.Split(tableName, ".")
+	switch len(tableComponents) {
+	case 1:
+		return "main", tableComponents[0], nil
+	case 2:
+		return tableComponents[0], tableComponents[1], nil
+	default:
+		return "", "", errors.Errorf("table name must be in table or schema.table format, '%s' given", tableName)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/bruin-data/bruin/pkg/ansisql"
 	"github.com/bruin-data/bruin/pkg/helpers"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 )
@@ -66,6 +67,10 @@ func buildIncrementalQuery(task *pipeline.Asset, query string) (string, error) {
 }
 
 func buildCreateReplaceQuery(task *pipeline.Asset, query string) (string, error) {
+	if task.IsContractEnforced() {
+		return ansisql.ContractCreateReplaceQuery(task, query)
+	}
+
 	query = strings.TrimSuffix(query, ";")
 	return fmt.Sprintf(
 		`BEGIN TRANSACTION;
@@ -2,6 +2,7 @@ package duck
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/bruin-data/bruin/pkg/ansisql"
 	"github.com/bruin-data/bruin/pkg/pipeline"
@@ -16,6 +17,7 @@ type materializer interface {
 
 type queryExtractor interface {
 	ExtractQueriesFromString(content string) ([]*query.Query, error)
+	CloneForAsset(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) query.QueryExtractor
 }
 
 type DuckDBClient interface {
@@ -48,7 +50,13 @@ func (o BasicOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error
 }
 
 func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) error {
-	queries, err := o.extractor.ExtractQueriesFromString(t.ExecutableFile.Content)
+	ctx, err := ansisql.WithTargetTableState(ctx, o.connection, p, t, t.ExecutableFile.Content)
+	if err != nil {
+		return err
+	}
+
+	extractor := o.extractor.CloneForAsset(ctx, p, t)
+	queries, err := extractor.ExtractQueriesFromString(t.ExecutableFile.Content)
 	if err != nil {
 		return errors.Wrap(err, "cannot extract queries from the task file")
 	}
@@ -83,12 +91,50 @@ func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pip
 
 func NewColumnCheckOperator(manager connectionFetcher) *ansisql.ColumnCheckOperator {
 	return ansisql.NewColumnCheckOperator(map[string]ansisql.CheckRunner{
-		"not_null":        ansisql.NewNotNullCheck(manager),
-		"unique":          ansisql.NewUniqueCheck(manager),
-		"positive":        ansisql.NewPositiveCheck(manager),
-		"non_negative":    ansisql.NewNonNegativeCheck(manager),
-		"negative":        ansisql.NewNegativeCheck(manager),
-		"accepted_values": &AcceptedValuesCheck{conn: manager},
-		"pattern":         &PatternCheck{conn: manager},
+		"not_null":               ansisql.NewNotNullCheck(manager),
+		"unique":                 ansisql.NewUniqueCheck(manager),
+		"positive":               ansisql.NewPositiveCheck(manager),
+		"non_negative":           ansisql.NewNonNegativeCheck(manager),
+		"negative":               ansisql.NewNegativeCheck(manager),
+		"min":                    ansisql.NewMinCheck(manager),
+		"max":                    ansisql.NewMaxCheck(manager),
+		"relationships":          ansisql.NewRelationshipsCheck(manager),
+		"references":             ansisql.NewRelationshipsCheck(manager),
+		"matches_upstream_count": ansisql.NewMatchesUpstreamCountCheck(manager),
+		"accepted_values":        &AcceptedValuesCheck{conn: manager},
+		"pattern":                &PatternCheck{conn: manager},
 	})
 }
+
+// NewMetadataPushOperator pushes the metadata of the assets as comments on the tables and their columns.
+func NewMetadataPushOperator(conn connectionFetcher) *ansisql.MetadataPushOperator {
+	return ansisql.NewMetadataPushOperator(conn, MetadataQueries)
+}
+
+// MetadataQueries returns the queries that comment the table with its description, owner and tags, and its columns
+// with their descriptions, classifications and tags.
+func MetadataQueries(asset *pipeline.Asset, _ map[string]*pipeline.ClassificationPolicy) ([]string, error) {
+	schemaName, tableName, err := splitTableName(asset.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	table := schemaName + "." + tableName
+	queries := make([]string, 0)
+	if comment := ansisql.TableComment(asset); comment != "" {
+		objectType := "TABLE"
+		if asset.Materialization.Type == pipeline.MaterializationTypeView {
+			objectType = "VIEW"
+		}
+
+		queries = append(queries, fmt.Sprintf("COMMENT ON %s %s IS '%s'", objectType, table, ansisql.EscapeSQLString(comment)))
+	}
+
+	for _, col := range asset.Columns {
+		if comment := ansisql.ColumnComment(col); comment != "" {
+			queries = append(queries, fmt.Sprintf("COMMENT ON COLUMN %s.%s IS '%s'", table, col.Name, ansisql.EscapeSQLString(comment)))
+		}
+	}
+
+	return queries, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type mockExtractor struct {
@@ -20,6 +21,10 @@ func (m *mockExtractor) ExtractQueriesFromString(content string) ([]*query.Query
 	return res.Get(0).([]*query.Query), res.Error(1)
 }
 
+func (m *mockExtractor) CloneForAsset(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) query.QueryExtractor {
+	return m
+}
+
 type mockMaterializer struct {
 	mock.Mock
 }
@@ -214,3 +219,69 @@ func TestBasicOperator_RunTask(t *testing.T) {
 		})
 	}
 }
+
+func TestMetadataQueries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		asset   *pipeline.Asset
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "assets without metadata are skipped",
+			asset: &pipeline.Asset{
+				Name:    "customers",
+				Columns: []pipeline.Column{{Name: "id"}},
+			},
+			want: []string{},
+		},
+		{
+			name: "descriptions, owner, classifications and tags",
+			asset: &pipeline.Asset{
+				Name:        "customers",
+				Description: "the customers",
+				Owner:       "data-team",
+				Tags:        []string{"core"},
+				Columns: []pipeline.Column{
+					{Name: "id", Description: "the customer's id"},
+					{Name: "email", Classification: "pii", Tags: []string{"gdpr"}},
+				},
+			},
+			want: []string{
+				"COMMENT ON TABLE main.customers IS 'the customers [owner: data-team; tags: core]'",
+				"COMMENT ON COLUMN main.customers.id IS 'the customer''s id'",
+				"COMMENT ON COLUMN main.customers.email IS '[classification: pii; tags: gdpr]'",
+			},
+		},
+		{
+			name: "views are commented as views",
+			asset: &pipeline.Asset{
+				Name:            "sales.active_customers",
+				Description:     "the active customers",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeView},
+			},
+			want: []string{"COMMENT ON VIEW sales.active_customers IS 'the active customers'"},
+		},
+		{
+			name:    "invalid table name",
+			asset:   &pipeline.Asset{Name: "a.b.c"},
+			wantErr: "table name must be in table or schema.table format, 'a.b.c' given",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := MetadataQueries(tt.asset, nil)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
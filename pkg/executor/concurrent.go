@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
 	"sync"
 	"time"
 
@@ -23,7 +22,8 @@ var (
 		color.FgGreen + color.Faint,
 		color.FgYellow,
 	}
-	faint = color.New(color.Faint).SprintFunc()
+	faint          = color.New(color.Faint).SprintFunc()
+	warningPrinter = color.New(color.FgYellow, color.Bold)
 )
 
 type contextKey int
@@ -45,6 +45,7 @@ func NewConcurrent(
 	logger *zap.SugaredLogger,
 	taskTypeMap map[pipeline.AssetType]Config,
 	workerCount int,
+	output io.Writer,
 ) (*Concurrent, error) {
 	executor := &Sequential{
 		TaskTypeMap: taskTypeMap,
@@ -60,6 +61,7 @@ func NewConcurrent(
 			logger:    logger,
 			printer:   color.New(colors[i%len(colors)]),
 			printLock: &printLock,
+			output:    output,
 		}
 	}
 
@@ -81,18 +83,19 @@ type worker struct {
 	logger    *zap.SugaredLogger
 	printer   *color.Color
 	printLock *sync.Mutex
+	output    io.Writer
 }
 
 func (w worker) run(ctx context.Context, taskChannel <-chan scheduler.TaskInstance, results chan<- *scheduler.TaskExecutionResult) {
 	for task := range taskChannel {
 		w.printLock.Lock()
-		w.printer.Printf("[%s] Starting: %s\n", time.Now().Format(timeFormat), task.GetHumanID())
+		w.printer.Fprintf(w.output, "[%s] Starting: %s\n", time.Now().Format(timeFormat), task.GetHumanID())
 		w.printLock.Unlock()
 
 		start := time.Now()
 
 		printer := &workerWriter{
-			w:           os.Stdout,
+			w:           w.output,
 			task:        task.GetAsset(),
 			sprintfFunc: w.printer.SprintfFunc(),
 			worker:      w.id,
@@ -107,11 +110,17 @@ func (w worker) run(ctx context.Context, taskChannel <-chan scheduler.TaskInstan
 		w.printLock.Lock()
 
 		res := "Finished"
+		statusPrinter := w.printer
 		if err != nil {
 			res = "Failed"
+			// the scheduler marks these tasks as warned, they do not fail the run
+			if scheduler.IsWarning(err) || scheduler.WarnsOnFailure(task) {
+				res = "Warning"
+				statusPrinter = warningPrinter
+			}
 		}
 
-		w.printer.Printf("[%s] %s: %s %s\n", time.Now().Format(timeFormat), res, task.GetHumanID(), faint(durationString))
+		statusPrinter.Fprintf(w.output, "[%s] %s: %s %s\n", time.Now().Format(timeFormat), res, task.GetHumanID(), faint(durationString))
 		w.printLock.Unlock()
 
 		results <- &scheduler.TaskExecutionResult{
@@ -1,11 +1,16 @@
 package executor
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"io"
+	"sync"
 	"testing"
 
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/scheduler"
+	"github.com/fatih/color"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -73,7 +78,7 @@ func TestConcurrent_Start(t *testing.T) {
 		},
 	}
 
-	ex, err := NewConcurrent(logger, ops, 8)
+	ex, err := NewConcurrent(logger, ops, 8, io.Discard)
 	require.NoError(t, err)
 	ex.Start(context.Background(), s.WorkQueue, s.Results)
 
@@ -82,3 +87,74 @@ func TestConcurrent_Start(t *testing.T) {
 
 	mockOperator.AssertExpectations(t)
 }
+
+func TestWorker_PrintsTheStateOfTheTask(t *testing.T) {
+	t.Parallel()
+
+	nonBlocking := false
+	asset := &pipeline.Asset{Name: "asset1", Type: "test"}
+	check := &pipeline.ColumnCheck{Name: "not_null", Blocking: pipeline.DefaultTrueBool{Value: &nonBlocking}}
+
+	tests := []struct {
+		name  string
+		task  scheduler.TaskInstance
+		err   error
+		state string
+	}{
+		{
+			name:  "succeeded task",
+			task:  &scheduler.AssetInstance{HumanID: "asset1", Asset: asset},
+			state: "Finished: asset1",
+		},
+		{
+			name:  "failed task",
+			task:  &scheduler.AssetInstance{HumanID: "asset1", Asset: asset},
+			err:   errors.New("failed"),
+			state: "Failed: asset1",
+		},
+		{
+			name:  "task that returned a warning",
+			task:  &scheduler.AssetInstance{HumanID: "asset1", Asset: asset},
+			err:   &scheduler.WarningError{Err: errors.New("stale")},
+			state: "Warning: asset1",
+		},
+		{
+			name: "failed non-blocking check",
+			task: &scheduler.ColumnCheckInstance{
+				AssetInstance: &scheduler.AssetInstance{HumanID: "asset1:col1:not_null", Asset: asset},
+				Column:        &pipeline.Column{Name: "col1"},
+				Check:         check,
+			},
+			err:   errors.New("check failed"),
+			state: "Warning: asset1:col1:not_null",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			op := new(mockOperator)
+			op.On("Run", mock.Anything, tt.task).Return(tt.err).Once()
+
+			var output bytes.Buffer
+			w := worker{
+				id:        "worker-0",
+				executor:  &Sequential{TaskTypeMap: map[pipeline.AssetType]Config{"test": {tt.task.GetType(): op}}},
+				logger:    zap.NewNop().Sugar(),
+				printer:   color.New(color.FgBlue),
+				printLock: &sync.Mutex{},
+				output:    &output,
+			}
+
+			tasks := make(chan scheduler.TaskInstance, 1)
+			results := make(chan *scheduler.TaskExecutionResult, 1)
+			tasks <- tt.task
+			close(tasks)
+
+			w.run(context.Background(), tasks, results)
+
+			assert.Contains(t, output.String(), tt.state)
+			assert.Equal(t, tt.err, (<-results).Error)
+		})
+	}
+}
@@ -2,12 +2,16 @@ package executor
 
 import (
 	"context"
+	"strings"
 
 	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
 	"github.com/bruin-data/bruin/pkg/scheduler"
 	"github.com/pkg/errors"
 )
 
+const annotationMetadataPrefix = "query_tag."
+
 type Operator interface {
 	Run(ctx context.Context, ti scheduler.TaskInstance) error
 }
@@ -34,5 +38,44 @@ func (s Sequential) RunSingleTask(ctx context.Context, instance scheduler.TaskIn
 		return errors.New("there is no executor configured for the asset class: " + instance.GetType().String())
 	}
 
+	// every query the asset runs is annotated, including the checks and the metadata push
+	ctx = query.WithAnnotations(ctx, annotationsForAsset(ctx, instance.GetPipeline(), task))
 	return executor.Run(ctx, instance)
 }
+
+// annotationsForAsset builds the annotations for the queries executed on behalf of the given asset. The run ID and the
+// environment are read from the run context, and any asset metadata key prefixed with "query_tag." is added as well,
+// e.g. `query_tag.team: growth` in the asset metadata becomes `team: growth`.
+func annotationsForAsset(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) query.Annotations {
+	annotations := query.Annotations{
+		"tool":  "bruin",
+		"asset": t.Name,
+	}
+
+	if p != nil && p.Name != "" {
+		annotations["pipeline"] = p.Name
+	}
+
+	if runID, ok := ctx.Value(pipeline.RunConfigRunID).(string); ok && runID != "" {
+		annotations["run_id"] = runID
+	}
+
+	if env, ok := ctx.Value(pipeline.RunConfigEnvironment).(string); ok && env != "" {
+		annotations["environment"] = env
+	}
+
+	for key, value := range t.Metadata {
+		if !strings.HasPrefix(key, annotationMetadataPrefix) {
+			continue
+		}
+
+		name := strings.TrimPrefix(key, annotationMetadataPrefix)
+		if name == "" {
+			continue
+		}
+
+		annotations[name] = value
+	}
+
+	return annotations
+}
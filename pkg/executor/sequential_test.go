@@ -5,8 +5,10 @@ import (
 	"testing"
 
 	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
 	"github.com/bruin-data/bruin/pkg/scheduler"
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -92,3 +94,71 @@ func TestLocal_RunSingleTask(t *testing.T) {
 		mockOperator.AssertExpectations(t)
 	})
 }
+
+func TestLocal_RunSingleTask_AnnotatesQueries(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name: "task1",
+		Type: "test",
+	}
+	instance := &scheduler.ColumnCheckInstance{
+		AssetInstance: &scheduler.AssetInstance{
+			Asset:    asset,
+			Pipeline: &pipeline.Pipeline{Name: "pipeline1"},
+		},
+	}
+
+	mockOperator := new(mockOperator)
+	mockOperator.On("Run", mock.MatchedBy(func(ctx context.Context) bool {
+		annotations := query.AnnotationsFromContext(ctx)
+		return annotations["asset"] == "task1" && annotations["pipeline"] == "pipeline1"
+	}), instance).Return(nil)
+
+	l := Sequential{
+		TaskTypeMap: map[pipeline.AssetType]Config{
+			"test": {
+				scheduler.TaskInstanceTypeColumnCheck: mockOperator,
+			},
+		},
+	}
+
+	err := l.RunSingleTask(context.Background(), instance)
+
+	require.NoError(t, err)
+	mockOperator.AssertExpectations(t)
+}
+
+func TestAnnotationsForAsset(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.WithValue(context.Background(), pipeline.RunConfigRunID, "2024_01_01_00_00_00")
+	ctx = context.WithValue(ctx, pipeline.RunConfigEnvironment, "dev")
+
+	p := &pipeline.Pipeline{Name: "analytics"}
+	asset := &pipeline.Asset{
+		Name: "dashboard.users",
+		Metadata: pipeline.EmptyStringMap{
+			"query_tag.team": "growth",
+			"query_tag.":     "ignored",
+			"owner_team":     "not-a-tag",
+		},
+	}
+
+	got := annotationsForAsset(ctx, p, asset)
+	assert.Equal(t, query.Annotations{
+		"tool":        "bruin",
+		"pipeline":    "analytics",
+		"asset":       "dashboard.users",
+		"run_id":      "2024_01_01_00_00_00",
+		"environment": "dev",
+		"team":        "growth",
+	}, got)
+}
+
+func TestAnnotationsForAsset_NoRunContext(t *testing.T) {
+	t.Parallel()
+
+	got := annotationsForAsset(context.Background(), nil, &pipeline.Asset{Name: "asset1"})
+	assert.Equal(t, query.Annotations{"tool": "bruin", "asset": "asset1"}, got)
+}
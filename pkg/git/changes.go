@@ -0,0 +1,67 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ChangedFiles returns the absolute paths of the files that changed in the repository since the given ref. The
+// comparison is made against the merge base of the ref and HEAD, so that the changes made on the ref afterward are not
+// included, and it covers the uncommitted and untracked files as well.
+func ChangedFiles(repoPath, ref string) ([]string, error) {
+	base, err := runGit(repoPath, "merge-base", ref, "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the merge base of '%s' and HEAD: %w", ref, err)
+	}
+
+	diff, err := runGit(repoPath, "diff", "--name-only", "--no-renames", strings.TrimSpace(base), "--")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the files changed since '%s': %w", ref, err)
+	}
+
+	untracked, err := runGit(repoPath, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the untracked files: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	files := make([]string, 0)
+	for _, line := range strings.Split(diff+"\n"+untracked, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		file := filepath.Join(repoPath, filepath.FromSlash(line))
+		if seen[file] {
+			continue
+		}
+
+		seen[file] = true
+		files = append(files, file)
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+func runGit(dir string, args ...string) (string, error) {
+	var stderr bytes.Buffer
+	command := exec.Command("git", args...)
+	command.Dir = dir
+	command.Stderr = &stderr
+
+	res, err := command.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+
+	return string(res), nil
+}
@@ -0,0 +1,63 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangedFiles(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		command := exec.Command("git", args...)
+		command.Dir = repo
+		command.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		out, err := command.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	write := func(name, content string) {
+		t.Helper()
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(repo, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(repo, name), []byte(content), 0o600))
+	}
+
+	run("init", "-q", "-b", "main")
+	write("assets/a.sql", "select 1")
+	write("assets/b.sql", "select 2")
+	write("assets/c.sql", "select 3")
+	run("add", "-A")
+	run("commit", "-q", "-m", "initial")
+
+	run("checkout", "-q", "-b", "feature")
+	write("assets/a.sql", "select 10")
+	run("commit", "-q", "-am", "change a")
+
+	run("checkout", "-q", "main")
+	write("assets/c.sql", "select 30")
+	run("commit", "-q", "-am", "change c on main")
+
+	run("checkout", "-q", "feature")
+	write("assets/b.sql", "select 20")
+	write("assets/d.sql", "select 4")
+
+	got, err := ChangedFiles(repo, "main")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(repo, "assets", "a.sql"),
+		filepath.Join(repo, "assets", "b.sql"),
+		filepath.Join(repo, "assets", "d.sql"),
+	}, got)
+
+	_, err = ChangedFiles(repo, "some-ref-that-does-not-exist")
+	require.Error(t, err)
+}
@@ -49,3 +49,24 @@ func EnsureGivenPatternIsInGitignore(fs afero.Fs, repoRoot string, pattern strin
 	_, err = file.Write([]byte("\n" + pattern))
 	return err
 }
+
+// IsPatternInGitignore returns true if the given pattern is already listed in the .gitignore file in the root of the
+// repository, without modifying the file.
+func IsPatternInGitignore(fs afero.Fs, repoRoot string, pattern string) (bool, error) {
+	content, err := afero.ReadFile(fs, path.Join(repoRoot, ".gitignore"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.TrimSpace(line) == pattern {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,33 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPatternInGitignore(t *testing.T) {
+	t.Parallel()
+
+	fs := afero.NewMemMapFs()
+
+	found, err := IsPatternInGitignore(fs, "/repo", ".bruin-cache")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, afero.WriteFile(fs, "/repo/.gitignore", []byte("logs/*.log\n .bruin-cache \n"), 0o644))
+
+	found, err = IsPatternInGitignore(fs, "/repo", ".bruin-cache")
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	found, err = IsPatternInGitignore(fs, "/repo", "logs")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	content, err := afero.ReadFile(fs, "/repo/.gitignore")
+	require.NoError(t, err)
+	assert.Equal(t, "logs/*.log\n .bruin-cache \n", string(content))
+}
@@ -11,10 +11,20 @@ import (
 	"github.com/spf13/afero"
 )
 
+// Check is a column check defined on an attribute, it has the same structure as the column checks in the assets.
+type Check struct {
+	Name     string      `json:"name" yaml:"name"`
+	Value    interface{} `json:"value" yaml:"value"`
+	Blocking *bool       `json:"blocking" yaml:"blocking"`
+}
+
 type Attribute struct {
-	Name        string `json:"name" yaml:"name"`
-	Description string `json:"description" yaml:"description"`
-	Type        string `json:"type" yaml:"type"`
+	Name          string        `json:"name" yaml:"name"`
+	Description   string        `json:"description" yaml:"description"`
+	Type          string        `json:"type" yaml:"type"`
+	Checks        []Check       `json:"checks" yaml:"checks"`
+	PII           bool          `json:"pii" yaml:"pii"`
+	AllowedValues []interface{} `json:"allowed_values" yaml:"allowed_values"`
 }
 
 type Entity struct {
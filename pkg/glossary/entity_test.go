@@ -1,9 +1,12 @@
 package glossary
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGlossary_Merge(t *testing.T) {
@@ -31,3 +34,50 @@ func TestGlossary_Merge(t *testing.T) {
 	assert.Len(t, mainGlossary.Entities, 1)
 	assert.Equal(t, anotherGlossary, mainGlossary)
 }
+
+func TestLoadGlossaryFromFile(t *testing.T) {
+	t.Parallel()
+
+	content := `entities:
+  Customer:
+    description: a customer
+    attributes:
+      ID:
+        type: integer
+        checks:
+          - name: not_null
+          - name: positive
+            blocking: false
+      Email:
+        type: string
+        pii: true
+        checks:
+          - name: pattern
+            value: "^.+@.+$"
+      Tier:
+        allowed_values: [gold, silver]
+`
+	glossaryPath := filepath.Join(t.TempDir(), "glossary.yml")
+	require.NoError(t, os.WriteFile(glossaryPath, []byte(content), 0o644))
+
+	g, err := LoadGlossaryFromFile(glossaryPath)
+	require.NoError(t, err)
+	require.Len(t, g.Entities, 1)
+
+	customer := g.GetEntity("Customer")
+	require.NotNil(t, customer)
+
+	id := customer.GetAttribute("ID")
+	assert.Equal(t, "ID", id.Name)
+	require.Len(t, id.Checks, 2)
+	assert.Equal(t, "not_null", id.Checks[0].Name)
+	assert.Nil(t, id.Checks[0].Blocking)
+	require.NotNil(t, id.Checks[1].Blocking)
+	assert.False(t, *id.Checks[1].Blocking)
+
+	email := customer.GetAttribute("Email")
+	assert.True(t, email.PII)
+	assert.Equal(t, "^.+@.+$", email.Checks[0].Value)
+
+	assert.Equal(t, []interface{}{"gold", "silver"}, customer.GetAttribute("Tier").AllowedValues)
+}
@@ -1,6 +1,9 @@
 package jinja
 
 import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
 	"regexp"
 	"strings"
 	"sync"
@@ -8,12 +11,15 @@ import (
 
 	"github.com/nikolalohinski/gonja/v2"
 	"github.com/nikolalohinski/gonja/v2/exec"
+	"github.com/nikolalohinski/gonja/v2/tokens"
 	"github.com/pkg/errors"
 )
 
 type Renderer struct {
+	values          Context
 	context         *exec.Context
 	queryRenderLock *sync.Mutex
+	macros          *MacroLibrary
 }
 
 func init() { //nolint: gochecknoinits
@@ -27,14 +33,22 @@ var (
 
 type Context map[string]any
 
+// RendererInterface is implemented by the renderers that can be extended with additional variables, e.g. the ones that
+// are specific to a pipeline or an asset.
+type RendererInterface interface {
+	Render(query string) (string, error)
+	WithContext(values Context) RendererInterface
+}
+
 func NewRenderer(context Context) *Renderer {
 	return &Renderer{
+		values:          context,
 		context:         exec.NewContext(context),
 		queryRenderLock: &sync.Mutex{},
 	}
 }
 
-func PythonEnvVariables(startDate, endDate *time.Time, pipelineName, runID string, fullRefresh bool) map[string]string {
+func PythonEnvVariables(startDate, endDate *time.Time, pipelineName, runID string, fullRefresh bool, variables map[string]any) map[string]string {
 	vars := map[string]string{
 		"BRUIN_START_DATE":      startDate.Format("2006-01-02"),
 		"BRUIN_START_DATETIME":  startDate.Format("2006-01-02T15:04:05"),
@@ -45,17 +59,24 @@ func PythonEnvVariables(startDate, endDate *time.Time, pipelineName, runID strin
 		"BRUIN_RUN_ID":          runID,
 		"BRUIN_PIPELINE":        pipelineName,
 		"BRUIN_FULL_REFRESH":    "",
+		"BRUIN_VARS":            "{}",
 	}
 
 	if fullRefresh {
 		vars["BRUIN_FULL_REFRESH"] = "1"
 	}
 
+	if len(variables) > 0 {
+		if encoded, err := json.Marshal(variables); err == nil {
+			vars["BRUIN_VARS"] = string(encoded)
+		}
+	}
+
 	return vars
 }
 
 func NewRendererWithStartEndDates(startDate, endDate *time.Time, pipelineName, runID string) *Renderer {
-	ctx := exec.NewContext(map[string]any{
+	return NewRenderer(Context{
 		"start_date":        startDate.Format("2006-01-02"),
 		"start_date_nodash": startDate.Format("20060102"),
 		"start_datetime":    startDate.Format("2006-01-02T15:04:05"),
@@ -67,11 +88,6 @@ func NewRendererWithStartEndDates(startDate, endDate *time.Time, pipelineName, r
 		"pipeline":          pipelineName,
 		"run_id":            runID,
 	})
-
-	return &Renderer{
-		context:         ctx,
-		queryRenderLock: &sync.Mutex{},
-	}
 }
 
 func NewRendererWithYesterday(pipelineName, runID string) *Renderer {
@@ -81,12 +97,45 @@ func NewRendererWithYesterday(pipelineName, runID string) *Renderer {
 	return NewRendererWithStartEndDates(&startDate, &endDate, pipelineName, runID)
 }
 
+// WithMacros returns a copy of the renderer that makes the macros in the given library available to the queries.
+func (r *Renderer) WithMacros(macros *MacroLibrary) *Renderer {
+	return &Renderer{
+		values:          r.values,
+		context:         r.context,
+		queryRenderLock: r.queryRenderLock,
+		macros:          macros,
+	}
+}
+
+// WithContext returns a copy of the renderer that has the given values in its context in addition to the existing
+// ones, the given values take precedence in case of a conflict.
+func (r *Renderer) WithContext(values Context) RendererInterface {
+	merged := make(Context, len(r.values)+len(values))
+	for k, v := range r.values {
+		merged[k] = v
+	}
+	for k, v := range values {
+		merged[k] = v
+	}
+
+	return &Renderer{
+		values:          merged,
+		context:         exec.NewContext(merged),
+		queryRenderLock: r.queryRenderLock,
+		macros:          r.macros,
+	}
+}
+
 func (r *Renderer) Render(query string) (string, error) {
 	r.queryRenderLock.Lock()
 
-	tpl, err := gonja.FromString(query)
+	tpl, err := r.parseTemplate(query)
 	if err != nil {
 		r.queryRenderLock.Unlock()
+		if macroErr := r.findMacroError(err); macroErr != nil {
+			return "", macroErr
+		}
+
 		customError := findParserErrorType(err)
 		if customError == "" {
 			return "", errors.Wrap(err, "you have found a bug in the jinja parser, please report it")
@@ -100,6 +149,10 @@ func (r *Renderer) Render(query string) (string, error) {
 	// gonja.context how often you want to.
 	out, err := tpl.ExecuteToString(r.context)
 	if err != nil {
+		if macroErr := r.findMacroError(err); macroErr != nil {
+			return "", macroErr
+		}
+
 		customError := findRenderErrorType(err)
 		if customError == "" {
 			return "", errors.Wrap(err, "you have found a bug in the jinja renderer, please report it")
@@ -111,6 +164,30 @@ func (r *Renderer) Render(query string) (string, error) {
 	return out, nil
 }
 
+func (r *Renderer) parseTemplate(query string) (*exec.Template, error) {
+	if r.macros == nil {
+		return gonja.FromString(query)
+	}
+
+	source := r.macros.importPrefix(r.context) + query
+	rootID := fmt.Sprintf("root-%x", sha256.Sum256([]byte(source)))
+	loader, err := r.macros.newLoader(rootID, source)
+	if err != nil {
+		return nil, err
+	}
+
+	return exec.NewTemplate(rootID, gonja.DefaultConfig, loader, gonja.DefaultEnvironment)
+}
+
+// findMacroError returns an error that points to the macro file if the given error originates from one of them.
+func (r *Renderer) findMacroError(err error) error {
+	if r.macros == nil {
+		return nil
+	}
+
+	return r.macros.findError(err)
+}
+
 func findRenderErrorType(err error) string {
 	message := err.Error()
 	errorBits := strings.Split(message, ": ")
@@ -143,3 +220,38 @@ func findParserErrorType(err error) string {
 
 	return ""
 }
+
+// FindCallArguments returns the string literals given as the only argument to the calls of the given function in the
+// template, e.g. `orders` for `{{ ref('orders') }}`. The template is tokenized the same way it is for rendering, so the
+// text outside of the Jinja expressions and statements, such as SQL comments and string literals, and the Jinja
+// comments are not considered.
+func FindCallArguments(template, function string) []string {
+	lexer := tokens.NewLexer(template, gonja.DefaultConfig)
+	go lexer.Run()
+
+	args := make([]string, 0)
+	// the last five tokens, enough for a call preceded by the token that tells if it is a method, e.g. `x.ref('a')`
+	window := make([]*tokens.Token, 0, 5)
+	for token := range lexer.Tokens {
+		if token.Type == tokens.Whitespace {
+			continue
+		}
+
+		window = append(window, token)
+		if len(window) > 5 {
+			window = window[1:]
+		}
+
+		call := window[max(len(window)-4, 0):]
+		if len(call) < 4 || len(window) == 5 && window[0].Type == tokens.Dot {
+			continue
+		}
+
+		if call[0].Type == tokens.Name && call[0].Val == function && call[1].Type == tokens.LeftParenthesis &&
+			call[2].Type == tokens.String && call[3].Type == tokens.RightParenthesis {
+			args = append(args, call[2].Val)
+		}
+	}
+
+	return args
+}
@@ -288,6 +288,43 @@ group by 1`,
 	}
 }
 
+func TestJinjaRendererWithContext(t *testing.T) {
+	t.Parallel()
+
+	startDate := time.Date(2022, 2, 3, 4, 0, 0, 0, time.UTC)
+	endDate := time.Date(2022, 2, 4, 4, 0, 0, 0, time.UTC)
+	base := NewRendererWithStartEndDates(&startDate, &endDate, "your-pipeline-name", "your-run-id")
+
+	receiver := base.WithContext(Context{
+		"var": map[string]any{
+			"segment":   "smb",
+			"countries": []any{"US", "DE"},
+		},
+	})
+
+	got, err := receiver.Render("{{ start_date }} {{ var.segment }} {% for c in var.countries %}{{ c }}{% if not loop.last %},{% endif %}{% endfor %}")
+	require.NoError(t, err)
+	require.Equal(t, "2022-02-03 smb US,DE", got)
+
+	_, err = base.Render("{{ var.segment }}")
+	require.EqualError(t, err, "missing variable 'var'")
+}
+
+func TestPythonEnvVariables(t *testing.T) {
+	t.Parallel()
+
+	startDate := time.Date(2022, 2, 3, 4, 0, 0, 0, time.UTC)
+	endDate := time.Date(2022, 2, 4, 4, 0, 0, 0, time.UTC)
+
+	vars := PythonEnvVariables(&startDate, &endDate, "my-pipeline", "run-id", true, nil)
+	require.Equal(t, "{}", vars["BRUIN_VARS"])
+	require.Equal(t, "1", vars["BRUIN_FULL_REFRESH"])
+
+	vars = PythonEnvVariables(&startDate, &endDate, "my-pipeline", "run-id", false, map[string]any{"segment": "smb", "lookback_days": 7})
+	require.JSONEq(t, `{"segment": "smb", "lookback_days": 7}`, vars["BRUIN_VARS"])
+	require.Equal(t, "", vars["BRUIN_FULL_REFRESH"])
+}
+
 func TestJinjaRendererErrorHandling(t *testing.T) {
 	t.Parallel()
 
@@ -911,3 +948,85 @@ func TestAddMilliseconds(t *testing.T) {
 		})
 	}
 }
+
+func TestJinjaRendererWithMacros(t *testing.T) {
+	t.Parallel()
+
+	macros, err := LoadMacroLibrary("testdata/macros")
+	require.NoError(t, err)
+	require.Equal(t, []string{"broken.sql", "currency.sql", "helpers/dedup.sql"}, macros.Files())
+
+	tests := []struct {
+		name    string
+		query   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "top-level macro files are imported automatically",
+			query: "SELECT {{ currency.convert('amount') }} FROM orders",
+			want:  "SELECT ROUND(amount * 1.1, 2) FROM orders",
+		},
+		{
+			name:  "macro files can be imported explicitly",
+			query: "{% import 'currency.sql' as c %}SELECT {{ c.convert('amount', 2) }} FROM orders",
+			want:  "SELECT ROUND(amount * 2, 2) FROM orders",
+		},
+		{
+			name:  "macros can be imported from nested files",
+			query: "{% from 'helpers/dedup.sql' import first_row %}SELECT * FROM orders QUALIFY {{ first_row('id', 'updated_at') }}",
+			want:  "SELECT * FROM orders QUALIFY ROW_NUMBER() OVER (PARTITION BY id ORDER BY updated_at DESC) = 1",
+		},
+		{
+			name:  "the context variables are still available",
+			query: "SELECT '{{ pipeline }}', {{ currency.convert('amount') }}",
+			want:  "SELECT 'my-pipeline', ROUND(amount * 1.1, 2)",
+		},
+		{
+			name:    "errors in macros point to the macro file",
+			query:   "SELECT\n{{ broken.render_missing() }}",
+			wantErr: "error in macro file 'macros/broken.sql:2': missing variable 'some_missing_variable'",
+		},
+		{
+			name:    "missing macro files are reported",
+			query:   "{% from 'missing.sql' import something %}SELECT 1",
+			wantErr: "macro file 'macros/missing.sql' does not exist",
+		},
+		{
+			name:    "errors in the query itself are reported as before",
+			query:   "SELECT {{ missing_in_query }}",
+			wantErr: "missing variable 'missing_in_query'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			startDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			endDate := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+			renderer := NewRendererWithStartEndDates(&startDate, &endDate, "my-pipeline", "run-id").WithMacros(macros)
+
+			got, err := renderer.Render(tt.query)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLoadMacroLibrary(t *testing.T) {
+	t.Parallel()
+
+	macros, err := LoadMacroLibrary("testdata/non-existing-macros")
+	require.NoError(t, err)
+	require.Nil(t, macros)
+
+	_, err = LoadMacroLibrary("testdata/invalid-macros")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error in macro file 'macros/invalid.sql'")
+}
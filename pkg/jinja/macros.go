@@ -0,0 +1,204 @@
+package jinja
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nikolalohinski/gonja/v2"
+	"github.com/nikolalohinski/gonja/v2/exec"
+	"github.com/nikolalohinski/gonja/v2/loaders"
+	"github.com/pkg/errors"
+)
+
+// MacrosFolder is the folder at the root of the project where the shared macro files live.
+const MacrosFolder = "macros"
+
+var (
+	macroFileExtensions = map[string]bool{".sql": true, ".jinja": true, ".j2": true}
+	macroNamespaceRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	macroErrorLineRegex = regexp.MustCompile(`(?:at line (\d+)|Line: (\d+) Col: \d+)`)
+
+	macroExecutionErrorRegex = regexp.MustCompile(`Unable to execute macro '([^']+)': (.*)$`)
+	macroLoadErrorRegex      = regexp.MustCompile(`unable to load template '([^']+)'`)
+)
+
+// MacroLibrary is a set of Jinja macro files that can be used by every query rendered with a Renderer.
+// The files can be imported explicitly, e.g. `{% from "currency.sql" import convert %}`, and the files at the top
+// level of the folder are also imported automatically under their name, e.g. `{{ currency.convert('amount') }}`.
+type MacroLibrary struct {
+	path       string
+	files      []string
+	macroFiles map[string]string
+}
+
+// LoadMacroLibrary reads the macro files in the given folder and makes sure they can be parsed. It returns nil if the
+// folder does not exist.
+func LoadMacroLibrary(path string) (*MacroLibrary, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrapf(err, "failed to read the macros folder '%s'", path)
+	}
+
+	if !info.IsDir() {
+		return nil, errors.Errorf("the macros path '%s' is not a folder", path)
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	library := &MacroLibrary{path: absPath, files: make([]string, 0), macroFiles: make(map[string]string)}
+	err = filepath.WalkDir(absPath, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || !macroFileExtensions[filepath.Ext(filePath)] {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(absPath, filePath)
+		if err != nil {
+			return err
+		}
+
+		library.files = append(library.files, filepath.ToSlash(relPath))
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the macro files in '%s'", path)
+	}
+
+	sort.Strings(library.files)
+
+	loader, err := loaders.NewFileSystemLoader(absPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range library.files {
+		source, err := os.ReadFile(filepath.Join(absPath, file))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read the macro file '%s'", file)
+		}
+
+		tpl, err := exec.NewTemplate(file, gonja.DefaultConfig, loader, gonja.DefaultEnvironment)
+		if err != nil {
+			message := strings.TrimPrefix(err.Error(), fmt.Sprintf("failed to parse template '%s': ", source))
+			return nil, library.fileError(file, errorLine(message), message)
+		}
+
+		for name := range tpl.Macros() {
+			if _, ok := library.macroFiles[name]; !ok {
+				library.macroFiles[name] = file
+			}
+		}
+	}
+
+	return library, nil
+}
+
+// Path returns the absolute path of the macros folder.
+func (l *MacroLibrary) Path() string {
+	return l.path
+}
+
+// Files returns the macro files in the library, relative to the macros folder.
+func (l *MacroLibrary) Files() []string {
+	return l.files
+}
+
+// importPrefix builds the import statements for the files at the top level of the library. The statements are all on
+// a single line and render to nothing, so that the line numbers of the query are not shifted.
+func (l *MacroLibrary) importPrefix(context *exec.Context) string {
+	var sb strings.Builder
+	for _, file := range l.files {
+		if strings.Contains(file, "/") {
+			continue
+		}
+
+		namespace := strings.TrimSuffix(file, filepath.Ext(file))
+		if !macroNamespaceRegex.MatchString(namespace) || context.Has(namespace) {
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf(`{%% import "%s" as %s %%}`, file, namespace))
+	}
+
+	return sb.String()
+}
+
+func (l *MacroLibrary) newLoader(rootID, query string) (loaders.Loader, error) {
+	loader, err := loaders.NewFileSystemLoader(l.path)
+	if err != nil {
+		return nil, err
+	}
+
+	return loaders.NewShiftedLoader(rootID, strings.NewReader(query), loader)
+}
+
+// fileError reports an error in a macro file with the path of the file relative to the project and the line it is at.
+func (l *MacroLibrary) fileError(file string, line int, message string) error {
+	location := filepath.ToSlash(filepath.Join(MacrosFolder, file))
+	if line > 0 {
+		location = fmt.Sprintf("%s:%d", location, line)
+	}
+
+	return errors.Errorf("error in macro file '%s': %s", location, message)
+}
+
+// findError converts the errors that originate from the macro files into errors that point to the file and the line.
+func (l *MacroLibrary) findError(err error) error {
+	message := err.Error()
+
+	if match := macroExecutionErrorRegex.FindStringSubmatch(message); match != nil {
+		if file, ok := l.macroFiles[match[1]]; ok {
+			return l.fileError(file, errorLine(match[2]), innermostErrorMessage(match[2]))
+		}
+	}
+
+	if match := macroLoadErrorRegex.FindStringSubmatch(message); match != nil {
+		relPath, relErr := filepath.Rel(l.path, match[1])
+		if relErr == nil && !strings.HasPrefix(relPath, "..") {
+			relPath = filepath.ToSlash(relPath)
+			if !slices.Contains(l.files, relPath) {
+				return errors.Errorf("macro file '%s' does not exist", filepath.ToSlash(filepath.Join(MacrosFolder, relPath)))
+			}
+
+			return l.fileError(relPath, errorLine(message), innermostErrorMessage(message))
+		}
+	}
+
+	return nil
+}
+
+func errorLine(message string) int {
+	match := macroErrorLineRegex.FindStringSubmatch(message)
+	if match == nil {
+		return 0
+	}
+
+	line, _ := strconv.Atoi(match[1] + match[2])
+	return line
+}
+
+func innermostErrorMessage(message string) string {
+	if customError := findRenderErrorType(errors.New(message)); customError != "" {
+		return customError
+	}
+
+	errorBits := strings.Split(message, ": ")
+	return errorBits[len(errorBits)-1]
+}
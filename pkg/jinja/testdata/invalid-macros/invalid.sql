@@ -0,0 +1,3 @@
+{% macro invalid() %}
+  {% if %}
+{% endmacro %}
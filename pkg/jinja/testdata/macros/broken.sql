@@ -0,0 +1,3 @@
+{% macro render_missing() %}
+  {{ some_missing_variable }}
+{% endmacro %}
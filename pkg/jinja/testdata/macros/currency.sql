@@ -0,0 +1,3 @@
+{% macro convert(column, rate=1.1) -%}
+ROUND({{ column }} * {{ rate }}, 2)
+{%- endmacro %}
@@ -0,0 +1,3 @@
+{% macro first_row(key, order_by) -%}
+ROW_NUMBER() OVER (PARTITION BY {{ key }} ORDER BY {{ order_by }} DESC) = 1
+{%- endmacro %}
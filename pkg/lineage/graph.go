@@ -0,0 +1,208 @@
+package lineage
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/pkg/errors"
+)
+
+const externalNodeType = "external"
+
+// Node is an asset, or an external `uri` dependency of an asset, in the lineage graph.
+type Node struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Path     string   `json:"path,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Columns  []string `json:"columns,omitempty"`
+	External bool     `json:"external,omitempty"`
+}
+
+// Edge is a dependency between two nodes, pointing from the upstream to the downstream.
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ColumnEdge is a dependency between the columns of two assets, pointing from the upstream to the downstream.
+type ColumnEdge struct {
+	FromAsset  string `json:"from_asset"`
+	FromColumn string `json:"from_column"`
+	ToAsset    string `json:"to_asset"`
+	ToColumn   string `json:"to_column"`
+}
+
+type Graph struct {
+	Pipeline    string        `json:"pipeline"`
+	Nodes       []*Node       `json:"nodes"`
+	Edges       []*Edge       `json:"edges"`
+	ColumnEdges []*ColumnEdge `json:"column_edges,omitempty"`
+}
+
+// Options controls which parts of the pipeline end up in the graph.
+type Options struct {
+	// Tag limits the graph to the assets with the given tag.
+	Tag string
+	// Asset limits the graph to the given asset and its upstream and downstream assets.
+	Asset string
+	// Depth limits the number of hops from Asset, 0 means no limit.
+	Depth int
+	// IncludeExternal adds the `uri` dependencies of the assets as external nodes.
+	IncludeExternal bool
+	// IncludeColumns adds the columns of the assets and the dependencies between them.
+	IncludeColumns bool
+}
+
+// NewGraph builds the lineage graph of the pipeline.
+func NewGraph(p *pipeline.Pipeline, opts Options) (*Graph, error) {
+	included, err := includedAssets(p, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &Graph{
+		Pipeline:    p.Name,
+		Nodes:       make([]*Node, 0),
+		Edges:       make([]*Edge, 0),
+		ColumnEdges: make([]*ColumnEdge, 0),
+	}
+
+	assetsByName := make(map[string]*pipeline.Asset, len(p.Assets))
+	for _, asset := range p.Assets {
+		if !included[asset.Name] {
+			continue
+		}
+
+		assetsByName[strings.ToLower(asset.Name)] = asset
+
+		node := &Node{
+			Name: asset.Name,
+			Type: string(asset.Type),
+			Path: p.RelativeAssetPath(asset),
+			Tags: asset.Tags,
+		}
+		if opts.IncludeColumns {
+			for _, column := range asset.Columns {
+				node.Columns = append(node.Columns, column.Name)
+			}
+		}
+		graph.Nodes = append(graph.Nodes, node)
+	}
+
+	externalNodes := make(map[string]bool)
+	for _, asset := range p.Assets {
+		if !included[asset.Name] {
+			continue
+		}
+
+		for _, upstream := range asset.Upstreams {
+			switch upstream.Type {
+			case "uri":
+				if !opts.IncludeExternal {
+					continue
+				}
+
+				if !externalNodes[upstream.Value] {
+					externalNodes[upstream.Value] = true
+					graph.Nodes = append(graph.Nodes, &Node{
+						Name:     upstream.Value,
+						Type:     externalNodeType,
+						External: true,
+					})
+				}
+			case "", "asset":
+				if !included[upstream.Value] {
+					continue
+				}
+			default:
+				continue
+			}
+
+			graph.Edges = append(graph.Edges, &Edge{From: upstream.Value, To: asset.Name})
+		}
+	}
+
+	if !opts.IncludeColumns {
+		return graph, nil
+	}
+
+	for _, asset := range p.Assets {
+		if !included[asset.Name] {
+			continue
+		}
+
+		for _, column := range asset.Columns {
+			for _, upstream := range column.Upstreams {
+				upstreamAsset, ok := assetsByName[strings.ToLower(upstream.Table)]
+				if !ok {
+					continue
+				}
+
+				graph.ColumnEdges = append(graph.ColumnEdges, &ColumnEdge{
+					FromAsset:  upstreamAsset.Name,
+					FromColumn: upstream.Column,
+					ToAsset:    asset.Name,
+					ToColumn:   column.Name,
+				})
+			}
+		}
+	}
+
+	return graph, nil
+}
+
+// includedAssets returns the names of the assets that match the tag and are within the depth of the focal asset.
+func includedAssets(p *pipeline.Pipeline, opts Options) (map[string]bool, error) {
+	included := make(map[string]bool, len(p.Assets))
+	for _, asset := range p.Assets {
+		if opts.Tag != "" && !slices.Contains(asset.Tags, opts.Tag) {
+			continue
+		}
+
+		included[asset.Name] = true
+	}
+
+	if opts.Asset == "" {
+		return included, nil
+	}
+
+	focal := p.GetAssetByName(opts.Asset)
+	if focal == nil {
+		return nil, errors.Errorf("asset '%s' does not exist in the pipeline", opts.Asset)
+	}
+
+	reachable := map[string]bool{focal.Name: true}
+	walk(focal, opts.Depth, (*pipeline.Asset).GetUpstream, reachable)
+	walk(focal, opts.Depth, (*pipeline.Asset).GetDownstream, reachable)
+
+	for name := range included {
+		if !reachable[name] {
+			delete(included, name)
+		}
+	}
+
+	return included, nil
+}
+
+// walk marks the assets that can be reached from the given asset in the given direction within the depth.
+func walk(asset *pipeline.Asset, depth int, next func(*pipeline.Asset) []*pipeline.Asset, reachable map[string]bool) {
+	current := []*pipeline.Asset{asset}
+	visited := map[string]bool{asset.Name: true}
+	for hop := 1; len(current) > 0 && (depth <= 0 || hop <= depth); hop++ {
+		nextLevel := make([]*pipeline.Asset, 0)
+		for _, a := range current {
+			for _, n := range next(a) {
+				if visited[n.Name] {
+					continue
+				}
+
+				visited[n.Name] = true
+				reachable[n.Name] = true
+				nextLevel = append(nextLevel, n)
+			}
+		}
+		current = nextLevel
+	}
+}
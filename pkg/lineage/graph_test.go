@@ -0,0 +1,169 @@
+package lineage
+
+import (
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPipeline builds the following pipeline: raw.orders -> staging.orders -> mart.revenue <- mart.customers,
+// where raw.orders also depends on an external file.
+func testPipeline() *pipeline.Pipeline {
+	rawOrders := &pipeline.Asset{
+		Name: "raw.orders",
+		Type: pipeline.AssetTypeIngestr,
+		Tags: []string{"raw"},
+		Columns: []pipeline.Column{
+			{Name: "id"},
+			{Name: "amount"},
+		},
+		Upstreams: []pipeline.Upstream{
+			{Type: "uri", Value: "s3://bucket/orders.csv"},
+		},
+	}
+	stagingOrders := &pipeline.Asset{
+		Name: "staging.orders",
+		Type: pipeline.AssetTypeBigqueryQuery,
+		Columns: []pipeline.Column{
+			{Name: "order_id", Upstreams: []*pipeline.UpstreamColumn{{Table: "RAW.ORDERS", Column: "id"}}},
+			{Name: "amount", Upstreams: []*pipeline.UpstreamColumn{{Table: "raw.orders", Column: "amount"}}},
+		},
+		Upstreams: []pipeline.Upstream{
+			{Type: "asset", Value: "raw.orders"},
+		},
+	}
+	customers := &pipeline.Asset{
+		Name: "mart.customers",
+		Type: pipeline.AssetTypeBigqueryQuery,
+	}
+	revenue := &pipeline.Asset{
+		Name: "mart.revenue",
+		Type: pipeline.AssetTypeBigqueryQuery,
+		Columns: []pipeline.Column{
+			{Name: "total", Upstreams: []*pipeline.UpstreamColumn{{Table: "staging.orders", Column: "amount"}}},
+		},
+		Upstreams: []pipeline.Upstream{
+			{Type: "asset", Value: "staging.orders"},
+			{Type: "asset", Value: "mart.customers"},
+		},
+	}
+
+	stagingOrders.AddUpstream(rawOrders)
+	rawOrders.AddDownstream(stagingOrders)
+	revenue.AddUpstream(stagingOrders)
+	stagingOrders.AddDownstream(revenue)
+	revenue.AddUpstream(customers)
+	customers.AddDownstream(revenue)
+
+	return &pipeline.Pipeline{
+		Name:   "sales",
+		Assets: []*pipeline.Asset{rawOrders, stagingOrders, customers, revenue},
+	}
+}
+
+func nodeNames(g *Graph) []string {
+	names := make([]string, 0, len(g.Nodes))
+	for _, node := range g.Nodes {
+		names = append(names, node.Name)
+	}
+	return names
+}
+
+func TestNewGraph(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		opts            Options
+		wantNodes       []string
+		wantEdges       []*Edge
+		wantColumnEdges []*ColumnEdge
+		wantErr         string
+	}{
+		{
+			name:      "whole pipeline",
+			wantNodes: []string{"raw.orders", "staging.orders", "mart.customers", "mart.revenue"},
+			wantEdges: []*Edge{
+				{From: "raw.orders", To: "staging.orders"},
+				{From: "staging.orders", To: "mart.revenue"},
+				{From: "mart.customers", To: "mart.revenue"},
+			},
+			wantColumnEdges: []*ColumnEdge{},
+		},
+		{
+			name:      "external dependencies",
+			opts:      Options{IncludeExternal: true},
+			wantNodes: []string{"raw.orders", "staging.orders", "mart.customers", "mart.revenue", "s3://bucket/orders.csv"},
+			wantEdges: []*Edge{
+				{From: "s3://bucket/orders.csv", To: "raw.orders"},
+				{From: "raw.orders", To: "staging.orders"},
+				{From: "staging.orders", To: "mart.revenue"},
+				{From: "mart.customers", To: "mart.revenue"},
+			},
+			wantColumnEdges: []*ColumnEdge{},
+		},
+		{
+			name:            "filtered by tag",
+			opts:            Options{Tag: "raw"},
+			wantNodes:       []string{"raw.orders"},
+			wantEdges:       []*Edge{},
+			wantColumnEdges: []*ColumnEdge{},
+		},
+		{
+			name:      "focal asset with depth",
+			opts:      Options{Asset: "staging.orders", Depth: 1},
+			wantNodes: []string{"raw.orders", "staging.orders", "mart.revenue"},
+			wantEdges: []*Edge{
+				{From: "raw.orders", To: "staging.orders"},
+				{From: "staging.orders", To: "mart.revenue"},
+			},
+			wantColumnEdges: []*ColumnEdge{},
+		},
+		{
+			name:      "focal asset without depth",
+			opts:      Options{Asset: "mart.customers"},
+			wantNodes: []string{"mart.customers", "mart.revenue"},
+			wantEdges: []*Edge{
+				{From: "mart.customers", To: "mart.revenue"},
+			},
+			wantColumnEdges: []*ColumnEdge{},
+		},
+		{
+			name:      "columns",
+			opts:      Options{Asset: "staging.orders", IncludeColumns: true},
+			wantNodes: []string{"raw.orders", "staging.orders", "mart.revenue"},
+			wantEdges: []*Edge{
+				{From: "raw.orders", To: "staging.orders"},
+				{From: "staging.orders", To: "mart.revenue"},
+			},
+			wantColumnEdges: []*ColumnEdge{
+				{FromAsset: "raw.orders", FromColumn: "id", ToAsset: "staging.orders", ToColumn: "order_id"},
+				{FromAsset: "raw.orders", FromColumn: "amount", ToAsset: "staging.orders", ToColumn: "amount"},
+				{FromAsset: "staging.orders", FromColumn: "amount", ToAsset: "mart.revenue", ToColumn: "total"},
+			},
+		},
+		{
+			name:    "unknown focal asset",
+			opts:    Options{Asset: "unknown"},
+			wantErr: "asset 'unknown' does not exist in the pipeline",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewGraph(testPipeline(), tt.opts)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNodes, nodeNames(got))
+			assert.Equal(t, tt.wantEdges, got.Edges)
+			assert.Equal(t, tt.wantColumnEdges, got.ColumnEdges)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package lineage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+	FormatHTML    = "html"
+	FormatJSON    = "json"
+)
+
+var SupportedFormats = []string{FormatDOT, FormatMermaid, FormatHTML, FormatJSON}
+
+// Render returns the graph in the given format.
+func (g *Graph) Render(format string) (string, error) {
+	switch format {
+	case FormatDOT:
+		return g.DOT(), nil
+	case FormatMermaid:
+		return g.Mermaid(), nil
+	case FormatHTML:
+		return g.HTML()
+	case FormatJSON:
+		res, err := json.MarshalIndent(g, "", "  ")
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal the lineage graph to json")
+		}
+		return string(res), nil
+	default:
+		return "", errors.Errorf("unsupported format '%s', supported formats are: %s", format, strings.Join(SupportedFormats, ", "))
+	}
+}
+
+// DOT returns the graph in the Graphviz DOT language.
+func (g *Graph) DOT() string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("digraph %s {\n", dotQuote(g.Pipeline)))
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=rounded];\n")
+
+	hasColumns := len(g.ColumnEdges) > 0
+	for _, node := range g.Nodes {
+		switch {
+		case node.External:
+			b.WriteString(fmt.Sprintf("  %s [shape=cylinder, style=dashed];\n", dotQuote(node.Name)))
+		case hasColumns && len(node.Columns) > 0:
+			fields := make([]string, 0, len(node.Columns)+1)
+			fields = append(fields, dotRecordEscape(node.Name))
+			for i, column := range node.Columns {
+				fields = append(fields, fmt.Sprintf("<c%d> %s", i, dotRecordEscape(column)))
+			}
+			b.WriteString(fmt.Sprintf("  %s [shape=record, style=solid, label=\"{%s}\"];\n", dotQuote(node.Name), strings.Join(fields, "|")))
+		default:
+			b.WriteString(fmt.Sprintf("  %s [label=%s];\n", dotQuote(node.Name), dotQuote(node.Name+"\n"+node.Type)))
+		}
+	}
+
+	for _, edge := range g.Edges {
+		b.WriteString(fmt.Sprintf("  %s -> %s;\n", dotQuote(edge.From), dotQuote(edge.To)))
+	}
+
+	ports := g.columnPorts()
+	for _, edge := range g.ColumnEdges {
+		from, fromOk := ports[edge.FromAsset][strings.ToLower(edge.FromColumn)]
+		to, toOk := ports[edge.ToAsset][strings.ToLower(edge.ToColumn)]
+		if !fromOk || !toOk {
+			continue
+		}
+
+		b.WriteString(fmt.Sprintf("  %s:c%d -> %s:c%d [style=dotted];\n", dotQuote(edge.FromAsset), from, dotQuote(edge.ToAsset), to))
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid returns the graph as a Mermaid flowchart.
+func (g *Graph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	ids := make(map[string]string, len(g.Nodes))
+	for i, node := range g.Nodes {
+		ids[node.Name] = fmt.Sprintf("n%d", i)
+	}
+
+	ports := g.columnPorts()
+	hasColumns := len(g.ColumnEdges) > 0
+	for _, node := range g.Nodes {
+		id := ids[node.Name]
+		switch {
+		case node.External:
+			b.WriteString(fmt.Sprintf("  %s[(%s)]\n", id, mermaidQuote(node.Name)))
+		case hasColumns && len(node.Columns) > 0:
+			b.WriteString(fmt.Sprintf("  subgraph %s [%s]\n", id, mermaidQuote(node.Name)))
+			for i, column := range node.Columns {
+				b.WriteString(fmt.Sprintf("    %s_c%d[%s]\n", id, i, mermaidQuote(column)))
+			}
+			b.WriteString("  end\n")
+		default:
+			b.WriteString(fmt.Sprintf("  %s[%s]\n", id, mermaidQuote(node.Name)))
+		}
+	}
+
+	for _, edge := range g.Edges {
+		b.WriteString(fmt.Sprintf("  %s --> %s\n", ids[edge.From], ids[edge.To]))
+	}
+
+	for _, edge := range g.ColumnEdges {
+		from, fromOk := ports[edge.FromAsset][strings.ToLower(edge.FromColumn)]
+		to, toOk := ports[edge.ToAsset][strings.ToLower(edge.ToColumn)]
+		if !fromOk || !toOk {
+			continue
+		}
+
+		b.WriteString(fmt.Sprintf("  %s_c%d -.-> %s_c%d\n", ids[edge.FromAsset], from, ids[edge.ToAsset], to))
+	}
+
+	return b.String()
+}
+
+var htmlTemplate = template.Must(template.New("lineage").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>{{ .Title }}</title>
+  <style>
+    body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, sans-serif; margin: 2rem; }
+  </style>
+</head>
+<body>
+  <h1>{{ .Title }}</h1>
+  <pre class="mermaid">
+{{ .Mermaid }}
+  </pre>
+  <script type="module">
+    import mermaid from "https://cdn.jsdelivr.net/npm/mermaid@11/dist/mermaid.esm.min.mjs";
+    mermaid.initialize({ startOnLoad: true, securityLevel: "strict" });
+  </script>
+</body>
+</html>
+`))
+
+// HTML returns a standalone page that renders the Mermaid version of the graph.
+func (g *Graph) HTML() (string, error) {
+	var buf bytes.Buffer
+	err := htmlTemplate.Execute(&buf, map[string]string{
+		"Title":   fmt.Sprintf("Lineage: %s", g.Pipeline),
+		"Mermaid": g.Mermaid(),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to render the lineage graph as html")
+	}
+
+	return buf.String(), nil
+}
+
+// columnPorts maps the lowercase column names of every asset to their position among the columns of the asset.
+func (g *Graph) columnPorts() map[string]map[string]int {
+	ports := make(map[string]map[string]int, len(g.Nodes))
+	for _, node := range g.Nodes {
+		ports[node.Name] = make(map[string]int, len(node.Columns))
+		for i, column := range node.Columns {
+			ports[node.Name][strings.ToLower(column)] = i
+		}
+	}
+
+	return ports
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+// dotRecordEscape escapes the characters that have a meaning in record labels, the result can be placed in quotes as is.
+func dotRecordEscape(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "{", `\{`, "}", `\}`, "|", `\|`, "<", `\<`, ">", `\>`, " ", `\ `)
+	return replacer.Replace(s)
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
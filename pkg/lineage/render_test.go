@@ -0,0 +1,139 @@
+package lineage
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraph_Render(t *testing.T) {
+	t.Parallel()
+
+	graph := &Graph{
+		Pipeline: "sales",
+		Nodes: []*Node{
+			{Name: "raw.orders", Type: "ingestr", Columns: []string{"id", "amount"}},
+			{Name: "staging.orders", Type: "bq.sql", Columns: []string{"order_id"}},
+			{Name: "s3://bucket/orders.csv", Type: "external", External: true},
+		},
+		Edges: []*Edge{
+			{From: "s3://bucket/orders.csv", To: "raw.orders"},
+			{From: "raw.orders", To: "staging.orders"},
+		},
+	}
+	withColumns := &Graph{
+		Pipeline: graph.Pipeline,
+		Nodes:    graph.Nodes,
+		Edges:    graph.Edges,
+		ColumnEdges: []*ColumnEdge{
+			{FromAsset: "raw.orders", FromColumn: "ID", ToAsset: "staging.orders", ToColumn: "order_id"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		graph  *Graph
+		format string
+		want   string
+	}{
+		{
+			name:   "dot",
+			graph:  graph,
+			format: FormatDOT,
+			want: `digraph "sales" {
+  rankdir=LR;
+  node [shape=box, style=rounded];
+  "raw.orders" [label="raw.orders\ningestr"];
+  "staging.orders" [label="staging.orders\nbq.sql"];
+  "s3://bucket/orders.csv" [shape=cylinder, style=dashed];
+  "s3://bucket/orders.csv" -> "raw.orders";
+  "raw.orders" -> "staging.orders";
+}
+`,
+		},
+		{
+			name:   "dot with columns",
+			graph:  withColumns,
+			format: FormatDOT,
+			want: `digraph "sales" {
+  rankdir=LR;
+  node [shape=box, style=rounded];
+  "raw.orders" [shape=record, style=solid, label="{raw.orders|<c0> id|<c1> amount}"];
+  "staging.orders" [shape=record, style=solid, label="{staging.orders|<c0> order_id}"];
+  "s3://bucket/orders.csv" [shape=cylinder, style=dashed];
+  "s3://bucket/orders.csv" -> "raw.orders";
+  "raw.orders" -> "staging.orders";
+  "raw.orders":c0 -> "staging.orders":c0 [style=dotted];
+}
+`,
+		},
+		{
+			name:   "mermaid",
+			graph:  graph,
+			format: FormatMermaid,
+			want: `flowchart LR
+  n0["raw.orders"]
+  n1["staging.orders"]
+  n2[("s3://bucket/orders.csv")]
+  n2 --> n0
+  n0 --> n1
+`,
+		},
+		{
+			name:   "mermaid with columns",
+			graph:  withColumns,
+			format: FormatMermaid,
+			want: `flowchart LR
+  subgraph n0 ["raw.orders"]
+    n0_c0["id"]
+    n0_c1["amount"]
+  end
+  subgraph n1 ["staging.orders"]
+    n1_c0["order_id"]
+  end
+  n2[("s3://bucket/orders.csv")]
+  n2 --> n0
+  n0 --> n1
+  n0_c0 -.-> n1_c0
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.graph.Render(tt.format)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("html embeds the mermaid graph", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := graph.Render(FormatHTML)
+		require.NoError(t, err)
+		assert.Contains(t, got, "<title>Lineage: sales</title>")
+		assert.Contains(t, got, `n2 --&gt; n0`)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := graph.Render(FormatJSON)
+		require.NoError(t, err)
+
+		var decoded Graph
+		require.NoError(t, json.Unmarshal([]byte(got), &decoded))
+		assert.Equal(t, *graph, decoded)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := graph.Render("svg")
+		require.EqualError(t, err, "unsupported format 'svg', supported formats are: dot, mermaid, html, json")
+	})
+}
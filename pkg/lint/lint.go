@@ -34,7 +34,7 @@ type Issue struct {
 type Rule interface {
 	Name() string
 	IsFast() bool
-	Validate(pipeline *pipeline.Pipeline) ([]*Issue, error)
+	Validate(ctx context.Context, pipeline *pipeline.Pipeline) ([]*Issue, error)
 	ValidateAsset(ctx context.Context, pipeline *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error)
 	GetApplicableLevels() []Level
 	GetSeverity() ValidatorSeverity
@@ -49,7 +49,7 @@ type SimpleRule struct {
 	Severity         ValidatorSeverity
 }
 
-func (g *SimpleRule) Validate(pipeline *pipeline.Pipeline) ([]*Issue, error) {
+func (g *SimpleRule) Validate(ctx context.Context, pipeline *pipeline.Pipeline) ([]*Issue, error) {
 	return g.Validator(pipeline)
 }
 
@@ -112,7 +112,7 @@ func (l *Linter) Lint(rootPath string, pipelineDefinitionFileName []string, c *c
 		return nil, err
 	}
 
-	return l.LintPipelines(pipelines)
+	return l.LintPipelines(c.Context, pipelines)
 }
 
 func (l *Linter) LintAsset(rootPath string, pipelineDefinitionFileName []string, assetNameOrPath string, c *cli.Context) (*PipelineAnalysisResult, error) {
@@ -163,7 +163,7 @@ func (l *Linter) LintAsset(rootPath string, pipelineDefinitionFileName []string,
 
 	// now the actual validation starts
 	for _, rule := range l.rules {
-		issues, err := rule.ValidateAsset(context.TODO(), assetPipeline, asset)
+		issues, err := rule.ValidateAsset(c.Context, assetPipeline, asset)
 		if err != nil {
 			return nil, err
 		}
@@ -259,6 +259,26 @@ type PipelineIssues struct {
 	Issues   map[Rule][]*Issue
 }
 
+// KeepAssets drops the issues of the assets that are not in the given set, the issues that are not tied to an asset
+// are kept.
+func (p *PipelineIssues) KeepAssets(assets map[*pipeline.Asset]bool) {
+	for rule, issues := range p.Issues {
+		kept := make([]*Issue, 0, len(issues))
+		for _, issue := range issues {
+			if issue.Task == nil || assets[issue.Task] {
+				kept = append(kept, issue)
+			}
+		}
+
+		if len(kept) == 0 {
+			delete(p.Issues, rule)
+			continue
+		}
+
+		p.Issues[rule] = kept
+	}
+}
+
 func (p *PipelineIssues) MarshalJSON() ([]byte, error) {
 	type IssueSummary struct {
 		Asset       string   `json:"asset"`
@@ -303,11 +323,11 @@ func (p *PipelineIssues) MarshalJSON() ([]byte, error) {
 	})
 }
 
-func (l *Linter) LintPipelines(pipelines []*pipeline.Pipeline) (*PipelineAnalysisResult, error) {
+func (l *Linter) LintPipelines(ctx context.Context, pipelines []*pipeline.Pipeline) (*PipelineAnalysisResult, error) {
 	result := &PipelineAnalysisResult{}
 
 	for _, p := range pipelines {
-		pipelineResult, err := l.LintPipeline(p)
+		pipelineResult, err := l.LintPipeline(ctx, p)
 		if err != nil {
 			return nil, err
 		}
@@ -317,18 +337,18 @@ func (l *Linter) LintPipelines(pipelines []*pipeline.Pipeline) (*PipelineAnalysi
 	return result, nil
 }
 
-func (l *Linter) LintPipeline(p *pipeline.Pipeline) (*PipelineIssues, error) {
-	return RunLintRulesOnPipeline(p, l.rules)
+func (l *Linter) LintPipeline(ctx context.Context, p *pipeline.Pipeline) (*PipelineIssues, error) {
+	return RunLintRulesOnPipeline(ctx, p, l.rules)
 }
 
-func RunLintRulesOnPipeline(p *pipeline.Pipeline, rules []Rule) (*PipelineIssues, error) {
+func RunLintRulesOnPipeline(ctx context.Context, p *pipeline.Pipeline, rules []Rule) (*PipelineIssues, error) {
 	pipelineResult := &PipelineIssues{
 		Pipeline: p,
 		Issues:   make(map[Rule][]*Issue),
 	}
 
 	for _, rule := range rules {
-		issues, err := rule.Validate(p)
+		issues, err := rule.Validate(ctx, p)
 		if err != nil {
 			return nil, err
 		}
@@ -551,3 +551,35 @@ func TestPipelineAnalysisResult_ErrorCount(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineIssues_KeepAssets(t *testing.T) {
+	t.Parallel()
+
+	changed := &pipeline.Asset{Name: "changed"}
+	unchanged := &pipeline.Asset{Name: "unchanged"}
+	rule1 := &SimpleRule{Identifier: "rule1", Severity: ValidatorSeverityCritical}
+	rule2 := &SimpleRule{Identifier: "rule2", Severity: ValidatorSeverityCritical}
+
+	issues := &PipelineIssues{
+		Pipeline: &pipeline.Pipeline{Name: "pipeline1", Assets: []*pipeline.Asset{changed, unchanged}},
+		Issues: map[Rule][]*Issue{
+			rule1: {
+				{Task: changed, Description: "issue1"},
+				{Task: unchanged, Description: "issue2"},
+				{Description: "pipeline issue"},
+			},
+			rule2: {
+				{Task: unchanged, Description: "issue3"},
+			},
+		},
+	}
+
+	issues.KeepAssets(map[*pipeline.Asset]bool{changed: true})
+
+	assert.Equal(t, map[Rule][]*Issue{
+		rule1: {
+			{Task: changed, Description: "issue1"},
+			{Description: "pipeline issue"},
+		},
+	}, issues.Issues)
+}
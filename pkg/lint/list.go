@@ -15,7 +15,7 @@ type repoFinder interface {
 	Repo(path string) (*git.Repo, error)
 }
 
-func GetRules(fs afero.Fs, finder repoFinder, excludeWarnings bool, parser *sqlparser.SQLParser, cacheFoundGlossary bool) ([]Rule, error) {
+func GetRules(fs afero.Fs, finder repoFinder, excludeWarnings bool, parser *sqlparser.SQLParser, macros *jinja.MacroLibrary, cacheFoundGlossary bool) ([]Rule, error) {
 	gr := GlossaryChecker{
 		gr: &glossary.GlossaryReader{
 			RepoFinder: finder,
@@ -49,6 +49,14 @@ func GetRules(fs afero.Fs, finder repoFinder, excludeWarnings bool, parser *sqlp
 			AssetValidator:   EnsureDependencyExistsForASingleAsset,
 			ApplicableLevels: []Level{LevelPipeline, LevelAsset},
 		},
+		&SimpleRule{
+			Identifier:       "valid-refs",
+			Fast:             true,
+			Severity:         ValidatorSeverityCritical,
+			Validator:        CallFuncForEveryAsset(EnsureRefsExistForASingleAsset),
+			AssetValidator:   EnsureRefsExistForASingleAsset,
+			ApplicableLevels: []Level{LevelPipeline, LevelAsset},
+		},
 		&SimpleRule{
 			Identifier:       "valid-executable-file",
 			Fast:             true,
@@ -139,6 +147,13 @@ func GetRules(fs afero.Fs, finder repoFinder, excludeWarnings bool, parser *sqlp
 			Validator:        EnsurePipelineStartDateIsValid,
 			ApplicableLevels: []Level{LevelPipeline},
 		},
+		&SimpleRule{
+			Identifier:       "valid-pipeline-variables",
+			Fast:             true,
+			Severity:         ValidatorSeverityCritical,
+			Validator:        EnsurePipelineVariablesAreValid,
+			ApplicableLevels: []Level{LevelPipeline},
+		},
 		&SimpleRule{
 			Identifier:       "valid-entity-references",
 			Fast:             true,
@@ -147,6 +162,14 @@ func GetRules(fs afero.Fs, finder repoFinder, excludeWarnings bool, parser *sqlp
 			AssetValidator:   gr.EnsureAssetEntitiesExistInGlossary,
 			ApplicableLevels: []Level{LevelPipeline, LevelAsset},
 		},
+		&SimpleRule{
+			Identifier:       "pii-columns-classified",
+			Fast:             true,
+			Severity:         ValidatorSeverityCritical,
+			Validator:        CallFuncForEveryAsset(gr.EnsurePIIColumnsAreClassified),
+			AssetValidator:   gr.EnsurePIIColumnsAreClassified,
+			ApplicableLevels: []Level{LevelPipeline, LevelAsset},
+		},
 		&SimpleRule{
 			Identifier:       "duplicate-column-names",
 			Fast:             true,
@@ -155,6 +178,14 @@ func GetRules(fs afero.Fs, finder repoFinder, excludeWarnings bool, parser *sqlp
 			AssetValidator:   ValidateDuplicateColumnNames,
 			ApplicableLevels: []Level{LevelPipeline, LevelAsset},
 		},
+		&SimpleRule{
+			Identifier:       "contract-columns-typed",
+			Fast:             true,
+			Severity:         ValidatorSeverityCritical,
+			Validator:        CallFuncForEveryAsset(ValidateContractColumns),
+			AssetValidator:   ValidateContractColumns,
+			ApplicableLevels: []Level{LevelPipeline, LevelAsset},
+		},
 		&SimpleRule{
 			Identifier:       "custom-check-query-exists",
 			Fast:             true,
@@ -163,6 +194,46 @@ func GetRules(fs afero.Fs, finder repoFinder, excludeWarnings bool, parser *sqlp
 			AssetValidator:   ValidateCustomCheckQueryExists,
 			ApplicableLevels: []Level{LevelPipeline, LevelAsset},
 		},
+		&SimpleRule{
+			Identifier:       "valid-min-max-checks",
+			Fast:             true,
+			Severity:         ValidatorSeverityCritical,
+			Validator:        CallFuncForEveryAsset(ValidateMinMaxChecks),
+			AssetValidator:   ValidateMinMaxChecks,
+			ApplicableLevels: []Level{LevelPipeline, LevelAsset},
+		},
+		&SimpleRule{
+			Identifier:       "valid-cross-asset-checks",
+			Fast:             true,
+			Severity:         ValidatorSeverityCritical,
+			Validator:        CallFuncForEveryAsset(ValidateCrossAssetChecks),
+			AssetValidator:   ValidateCrossAssetChecks,
+			ApplicableLevels: []Level{LevelPipeline, LevelAsset},
+		},
+		&SimpleRule{
+			Identifier:       "valid-freshness-check",
+			Fast:             true,
+			Severity:         ValidatorSeverityCritical,
+			Validator:        CallFuncForEveryAsset(ValidateFreshnessCheck),
+			AssetValidator:   ValidateFreshnessCheck,
+			ApplicableLevels: []Level{LevelPipeline, LevelAsset},
+		},
+		&SimpleRule{
+			Identifier:       "valid-anomaly-checks",
+			Fast:             true,
+			Severity:         ValidatorSeverityCritical,
+			Validator:        CallFuncForEveryAsset(ValidateAnomalyChecks),
+			AssetValidator:   ValidateAnomalyChecks,
+			ApplicableLevels: []Level{LevelPipeline, LevelAsset},
+		},
+		&SimpleRule{
+			Identifier:       "valid-sql-tests",
+			Fast:             true,
+			Severity:         ValidatorSeverityCritical,
+			Validator:        CallFuncForEveryAsset(ValidateSQLTests),
+			AssetValidator:   ValidateSQLTests,
+			ApplicableLevels: []Level{LevelPipeline, LevelAsset},
+		},
 		&SimpleRule{
 			Identifier:       "assets-directory-exist",
 			Fast:             true,
@@ -190,8 +261,9 @@ func GetRules(fs afero.Fs, finder repoFinder, excludeWarnings bool, parser *sqlp
 
 	if parser != nil {
 		rules = append(rules, UsedTableValidatorRule{
-			renderer: jinja.NewRendererWithYesterday("your-pipeline", "some-run-id"),
-			parser:   parser,
+			renderer:    jinja.NewRendererWithYesterday("your-pipeline", "some-run-id").WithMacros(macros),
+			parser:      parser,
+			workerCount: parser.WorkerCount(),
 		})
 	}
 
@@ -3,6 +3,7 @@ package lint
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"path/filepath"
 	"strings"
 
@@ -13,6 +14,8 @@ import (
 
 type Printer struct {
 	RootCheckPath string
+	// Output is where the issues are printed, it defaults to the output of the color package, which is stdout.
+	Output io.Writer
 }
 
 type (
@@ -37,6 +40,14 @@ func (l *Printer) PrintIssues(analysis *PipelineAnalysisResult) {
 	}
 }
 
+func (l *Printer) output() io.Writer {
+	if l.Output == nil {
+		return color.Output
+	}
+
+	return l.Output
+}
+
 func (l *Printer) PrintJSON(analysis *PipelineAnalysisResult) error {
 	jsonRes, err := json.MarshalIndent(analysis, "", "  ")
 	if err != nil {
@@ -48,13 +59,14 @@ func (l *Printer) PrintJSON(analysis *PipelineAnalysisResult) error {
 }
 
 func (l *Printer) printPipelineSummary(pipelineIssues *PipelineIssues) {
-	successPrinter.Println()
+	w := l.output()
+	successPrinter.Fprintln(w)
 
 	pipelineDirectory := l.relativePipelinePath(pipelineIssues.Pipeline)
-	pipelinePrinter.Printf("Pipeline: %s %s\n", pipelineIssues.Pipeline.Name, faint(fmt.Sprintf("(%s)", pipelineDirectory)))
+	pipelinePrinter.Fprintf(w, "Pipeline: %s %s\n", pipelineIssues.Pipeline.Name, faint(fmt.Sprintf("(%s)", pipelineDirectory)))
 
 	if len(pipelineIssues.Issues) == 0 {
-		successPrinter.Println("  No issues found")
+		successPrinter.Fprintln(w, "  No issues found")
 		return
 	}
 
@@ -81,17 +93,17 @@ func (l *Printer) printPipelineSummary(pipelineIssues *PipelineIssues) {
 		}
 	}
 
-	printGenericIssues(genericIssues)
+	printGenericIssues(w, genericIssues)
 	if len(genericIssues) > 0 && len(taskIssueMap) > 0 {
-		issuePrinter.Println()
+		issuePrinter.Fprintln(w)
 	}
 
 	for task, summary := range taskIssueMap {
 		relativeTaskPath := pipelineIssues.Pipeline.RelativeAssetPath(task)
-		taskNamePrinter.Printf("  %s %s\n", task.Name, faint(fmt.Sprintf("(%s)", relativeTaskPath)))
-		printAssetIssues(summary)
+		taskNamePrinter.Fprintf(w, "  %s %s\n", task.Name, faint(fmt.Sprintf("(%s)", relativeTaskPath)))
+		printAssetIssues(w, summary)
 
-		issuePrinter.Println()
+		issuePrinter.Fprintln(w)
 	}
 }
 
@@ -111,7 +123,7 @@ func (l *Printer) relativePipelinePath(p *pipeline.Pipeline) string {
 	return pipelineDirectory
 }
 
-func printGenericIssues(genericIssues map[Rule][]*Issue) {
+func printGenericIssues(w io.Writer, genericIssues map[Rule][]*Issue) {
 	totalIssueCount := 0
 	for _, issues := range genericIssues {
 		totalIssueCount += len(issues)
@@ -132,13 +144,13 @@ func printGenericIssues(genericIssues map[Rule][]*Issue) {
 				connector = "└──"
 			}
 
-			pp.Printf("    %s %s %s\n", connector, issue.Description, faint(fmt.Sprintf("(%s)", rule.Name())))
-			printIssueContext(pp, issue.Context, printedIssueCount == totalIssueCount)
+			pp.Fprintf(w, "    %s %s %s\n", connector, issue.Description, faint(fmt.Sprintf("(%s)", rule.Name())))
+			printIssueContext(w, pp, issue.Context, printedIssueCount == totalIssueCount)
 		}
 	}
 }
 
-func printAssetIssues(assetIssues []*ruleIssue) {
+func printAssetIssues(w io.Writer, assetIssues []*ruleIssue) {
 	issueCount := len(assetIssues)
 	for index, ruleIssue := range assetIssues {
 		rule := ruleIssue.rule
@@ -154,12 +166,12 @@ func printAssetIssues(assetIssues []*ruleIssue) {
 			connector = "└──"
 		}
 
-		pp.Printf("    %s %s %s\n", connector, issue.Description, faint(fmt.Sprintf("(%s)", rule.Name())))
-		printIssueContext(pp, issue.Context, index == issueCount-1)
+		pp.Fprintf(w, "    %s %s %s\n", connector, issue.Description, faint(fmt.Sprintf("(%s)", rule.Name())))
+		printIssueContext(w, pp, issue.Context, index == issueCount-1)
 	}
 }
 
-func printIssueContext(printer *color.Color, context []string, lastIssue bool) {
+func printIssueContext(w io.Writer, printer *color.Color, context []string, lastIssue bool) {
 	issueCount := len(context)
 	beginning := "│"
 	if lastIssue {
@@ -172,7 +184,7 @@ func printIssueContext(printer *color.Color, context []string, lastIssue bool) {
 			connector = "└─"
 		}
 
-		printer.Printf("    %s   %s %s\n", beginning, connector, padLinesIfMultiline(row, 11))
+		printer.Fprintf(w, "    %s   %s %s\n", beginning, connector, padLinesIfMultiline(row, 11))
 	}
 }
 
@@ -21,6 +21,7 @@ type connectionManager interface {
 
 type queryExtractor interface {
 	ExtractQueriesFromString(content string) ([]*query.Query, error)
+	CloneForAsset(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) query.QueryExtractor
 }
 
 type materializer interface {
@@ -62,7 +63,7 @@ func (q *QueryValidatorRule) ValidateAsset(ctx context.Context, p *pipeline.Pipe
 		return issues, nil
 	}
 
-	queries, err := q.Extractor.ExtractQueriesFromString(asset.ExecutableFile.Content)
+	queries, err := q.Extractor.CloneForAsset(ctx, p, asset).ExtractQueriesFromString(asset.ExecutableFile.Content)
 	if err != nil {
 		q.Logger.Debugf("failed to extract the queries from pipeline '%s' task '%s'", p.Name, asset.Name)
 		issues = append(issues, &Issue{
@@ -170,7 +171,7 @@ func (q *QueryValidatorRule) validateTask(p *pipeline.Pipeline, task *pipeline.A
 	issues := make([]*Issue, 0)
 
 	q.Logger.Debugf("validating pipeline '%s' task '%s'", p.Name, task.Name)
-	queries, err := q.Extractor.ExtractQueriesFromString(task.ExecutableFile.Content)
+	queries, err := q.Extractor.CloneForAsset(context.Background(), p, task).ExtractQueriesFromString(task.ExecutableFile.Content)
 	q.Logger.Debugf("got the query extract results from file for pipeline '%s' task '%s'", p.Name, task.Name)
 
 	if err != nil {
@@ -307,7 +308,7 @@ func (q *QueryValidatorRule) bufferSize() int {
 	return 256
 }
 
-func (q *QueryValidatorRule) Validate(p *pipeline.Pipeline) ([]*Issue, error) {
+func (q *QueryValidatorRule) Validate(ctx context.Context, p *pipeline.Pipeline) ([]*Issue, error) {
 	issues := make([]*Issue, 0)
 
 	// skip if there are no workers defined
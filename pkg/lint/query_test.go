@@ -40,6 +40,10 @@ func (m *mockExtractor) ExtractQueriesFromString(content string) ([]*query.Query
 	return res.Get(0).([]*query.Query), res.Error(1)
 }
 
+func (m *mockExtractor) CloneForAsset(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) query.QueryExtractor {
+	return m
+}
+
 type mockMaterializer struct {
 	mock.Mock
 }
@@ -309,7 +313,7 @@ func TestQueryValidatorRule_Validate(t *testing.T) {
 				Materializer: mat,
 			}
 
-			got, err := q.Validate(tt.p)
+			got, err := q.Validate(context.Background(), tt.p)
 			if tt.wantErr {
 				require.Error(t, err)
 			} else {
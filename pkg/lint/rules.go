@@ -11,11 +11,15 @@ import (
 	"strings"
 	"time"
 
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/dialect"
 	"github.com/bruin-data/bruin/pkg/executor"
 	"github.com/bruin-data/bruin/pkg/glossary"
+	"github.com/bruin-data/bruin/pkg/jinja"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/pkg/errors"
 	"github.com/robfig/cron/v3"
+	"github.com/sourcegraph/conc/pool"
 	"github.com/spf13/afero"
 	"github.com/yourbasic/graph"
 )
@@ -313,6 +317,24 @@ func EnsureDependencyExistsForASingleAsset(ctx context.Context, p *pipeline.Pipe
 	return issues, nil
 }
 
+func EnsureRefsExistForASingleAsset(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
+	issues := make([]*Issue, 0)
+	if !asset.IsSQLAsset() {
+		return issues, nil
+	}
+
+	for _, ref := range pipeline.FindRefs(asset.ExecutableFile.Content) {
+		if p.GetAssetByName(ref) == nil {
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: fmt.Sprintf("ref('%s') refers to an asset that does not exist", ref),
+			})
+		}
+	}
+
+	return issues, nil
+}
+
 func EnsurePipelineScheduleIsValidCron(p *pipeline.Pipeline) ([]*Issue, error) {
 	issues := make([]*Issue, 0)
 	if p.Schedule == "" {
@@ -354,6 +376,18 @@ func EnsurePipelineStartDateIsValid(p *pipeline.Pipeline) ([]*Issue, error) {
 	return issues, nil
 }
 
+// EnsurePipelineVariablesAreValid checks that every pipeline variable has a default value matching its schema.
+func EnsurePipelineVariablesAreValid(p *pipeline.Pipeline) ([]*Issue, error) {
+	issues := make([]*Issue, 0)
+	if err := p.Variables.Validate(); err != nil {
+		issues = append(issues, &Issue{
+			Description: err.Error(),
+		})
+	}
+
+	return issues, nil
+}
+
 // ValidateCustomCheckQueryExists checks for duplicate column checks within a single column.
 // It returns a slice of Issues, each representing a duplicate column check found.
 func ValidateCustomCheckQueryExists(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
@@ -483,6 +517,332 @@ func ValidateDuplicateColumnNames(ctx context.Context, p *pipeline.Pipeline, ass
 	return issues, nil
 }
 
+// contractSupportedAssetTypes are the asset types whose materializers create the table from the declared columns.
+var contractSupportedAssetTypes = map[pipeline.AssetType]bool{
+	pipeline.AssetTypeBigqueryQuery:  true,
+	pipeline.AssetTypeSnowflakeQuery: true,
+	pipeline.AssetTypePostgresQuery:  true,
+	pipeline.AssetTypeRedshiftQuery:  true,
+	pipeline.AssetTypeDuckDBQuery:    true,
+}
+
+// ValidateContractColumns ensures that the assets with an enforced contract can be materialized with it: the contract
+// must be supported for the asset type, and every column must be declared with a type.
+func ValidateContractColumns(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
+	issues := make([]*Issue, 0)
+	if asset.Contract == pipeline.ContractModeNone {
+		return issues, nil
+	}
+
+	if !asset.IsContractEnforced() {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: fmt.Sprintf("Invalid contract mode '%s', the only supported value is '%s'", asset.Contract, pipeline.ContractModeEnforced),
+		})
+
+		return issues, nil
+	}
+
+	if !contractSupportedAssetTypes[asset.Type] {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: fmt.Sprintf("Enforced contracts are not supported for assets of type '%s'", asset.Type),
+		})
+
+		return issues, nil
+	}
+
+	if asset.Materialization.Type != pipeline.MaterializationTypeTable {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: "Enforced contracts require the asset to be materialized as a table",
+		})
+	} else if !pipeline.IsContractSupportedForStrategy(asset.Materialization.Strategy) {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: fmt.Sprintf("Enforced contracts are only supported with the '%s' strategy, '%s' given", pipeline.MaterializationStrategyCreateReplace, asset.Materialization.Strategy),
+		})
+	}
+
+	if len(asset.Columns) == 0 {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: "Assets with an enforced contract must declare their columns",
+		})
+
+		return issues, nil
+	}
+
+	for _, column := range asset.Columns {
+		if strings.TrimSpace(column.Type) == "" {
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: fmt.Sprintf("Column '%s' must have a type since the asset has an enforced contract", column.Name),
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// ValidateMinMaxChecks ensures the `min` and `max` column checks have a numeric, date or timestamp value and that
+// they are used on assets whose platform can run them.
+func ValidateMinMaxChecks(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
+	issues := make([]*Issue, 0)
+	platformSupported := canRunSQLChecks(asset)
+
+	for _, column := range asset.Columns {
+		for _, check := range column.Checks {
+			if check.Name != "min" && check.Name != "max" {
+				continue
+			}
+
+			if !platformSupported {
+				issues = append(issues, &Issue{
+					Task:        asset,
+					Description: fmt.Sprintf("The `%s` check on column '%s' is not supported for assets of type '%s'", check.Name, column.Name, asset.Type),
+				})
+				continue
+			}
+
+			if _, err := ansisql.BoundaryLiteral(check.Value, column.Type); err != nil {
+				issues = append(issues, &Issue{
+					Task:        asset,
+					Description: fmt.Sprintf("The value of the `%s` check on column '%s' must be a number, a date or a timestamp", check.Name, column.Name),
+				})
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+// canRunSQLChecks returns true if the checks of the asset run on a SQL platform, which is the destination for ingestr
+// assets and the majority platform of the pipeline for Python assets.
+func canRunSQLChecks(asset *pipeline.Asset) bool {
+	if asset.Type == pipeline.AssetTypePython || asset.Type == pipeline.AssetTypeEmpty {
+		return true
+	}
+
+	assetType := asset.Type
+	if assetType == pipeline.AssetTypeIngestr {
+		assetType = pipeline.IngestrTypeConnectionMapping[asset.Parameters["destination"]]
+	}
+
+	_, ok := pipeline.AssetTypeConnectionMapping[assetType]
+	return ok
+}
+
+// ValidateFreshnessCheck ensures the freshness check of the asset has a column and valid thresholds, and that the
+// asset runs on a platform that can evaluate it.
+func ValidateFreshnessCheck(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
+	issues := make([]*Issue, 0)
+	if asset.Freshness == nil {
+		return issues, nil
+	}
+
+	if !canRunSQLChecks(asset) {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: fmt.Sprintf("Freshness checks are not supported for assets of type '%s'", asset.Type),
+		})
+
+		return issues, nil
+	}
+
+	if asset.Freshness.Column == "" {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: "The freshness check must have a `column` to find the latest value of",
+		})
+	}
+
+	warnAfter, errorAfter, err := asset.Freshness.Thresholds()
+	switch {
+	case err != nil:
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: fmt.Sprintf("The freshness check has an %s", err),
+		})
+	case warnAfter == 0 && errorAfter == 0:
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: "The freshness check must have at least one of `warn_after` or `error_after`",
+		})
+	case warnAfter > 0 && errorAfter > 0 && warnAfter > errorAfter:
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: "The `warn_after` value of the freshness check must not be greater than its `error_after` value",
+		})
+	}
+
+	return issues, nil
+}
+
+// ValidateAnomalyChecks ensures the anomaly checks of the asset have unique names, a supported metric with a column
+// where needed, and at least one threshold.
+func ValidateAnomalyChecks(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
+	issues := make([]*Issue, 0)
+	if len(asset.Anomalies) == 0 {
+		return issues, nil
+	}
+
+	if !canRunSQLChecks(asset) {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: fmt.Sprintf("Anomaly checks are not supported for assets of type '%s'", asset.Type),
+		})
+
+		return issues, nil
+	}
+
+	seen := make(map[string]bool, len(asset.Anomalies))
+	for i := range asset.Anomalies {
+		check := &asset.Anomalies[i]
+		if seen[check.Name] {
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: fmt.Sprintf("Duplicate anomaly check name '%s', anomaly checks must have unique names", check.Name),
+			})
+		}
+		seen[check.Name] = true
+
+		if !slices.Contains(pipeline.AnomalyMetrics, check.Metric) {
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: fmt.Sprintf("Anomaly check '%s' has an invalid metric '%s', supported metrics are: %s", check.Name, check.Metric, strings.Join(pipeline.AnomalyMetrics, ", ")),
+			})
+		} else if check.MetricNeedsColumn() && check.Column == "" {
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: fmt.Sprintf("Anomaly check '%s' must have a `column` for the '%s' metric", check.Name, check.Metric),
+			})
+		}
+
+		switch {
+		case check.MaxStddev < 0 || check.MaxChangePercent < 0 || check.Window < 0 || check.MinHistory < 0 || check.RecoverAfter < 0:
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: fmt.Sprintf("Anomaly check '%s' must not have negative values", check.Name),
+			})
+		case check.MaxStddev == 0 && check.MaxChangePercent == 0:
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: fmt.Sprintf("Anomaly check '%s' must have at least one of `max_stddev` or `max_change_percent`", check.Name),
+			})
+		}
+
+		if check.Severity != "" && check.Severity != pipeline.AnomalySeverityError && check.Severity != pipeline.AnomalySeverityWarning {
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: fmt.Sprintf("Anomaly check '%s' has an invalid severity '%s', it must be either 'error' or 'warning'", check.Name, check.Severity),
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// ValidateSQLTests ensures the tests of the asset can be run by `bruin test`: the asset has a SQL dialect, and the
+// fixtures and the expectations of the tests have their data given either inline or as a CSV file.
+func ValidateSQLTests(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
+	issues := make([]*Issue, 0)
+	if len(asset.Tests) == 0 {
+		return issues, nil
+	}
+
+	if _, err := dialect.GetDialectByAssetType(string(asset.Type)); err != nil {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: fmt.Sprintf("Tests are not supported for assets of type '%s'", asset.Type),
+		})
+
+		return issues, nil
+	}
+
+	seen := make(map[string]bool, len(asset.Tests))
+	for _, test := range asset.Tests {
+		if test.Name == "" {
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: "Tests must have a name",
+			})
+		} else if seen[test.Name] {
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: fmt.Sprintf("Duplicate test name '%s', tests must have unique names", test.Name),
+			})
+		}
+		seen[test.Name] = true
+
+		for _, fixture := range test.Given {
+			switch {
+			case fixture.Table == "":
+				issues = append(issues, &Issue{
+					Task:        asset,
+					Description: fmt.Sprintf("The fixtures of test '%s' must have a `table`", test.Name),
+				})
+			case (len(fixture.Rows) > 0) == (fixture.CSV != ""):
+				issues = append(issues, &Issue{
+					Task:        asset,
+					Description: fmt.Sprintf("The fixture for table '%s' in test '%s' must have either `rows` or `csv`", fixture.Table, test.Name),
+				})
+			}
+		}
+
+		if len(test.Expect.Rows) > 0 && test.Expect.CSV != "" {
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: fmt.Sprintf("The expectation of test '%s' must not have both `rows` and `csv`", test.Name),
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// ValidateCrossAssetChecks ensures the `relationships`, `references` and `matches_upstream_count` column checks refer
+// to assets that exist in the pipeline. The `matches_upstream_count` check compares the row counts of the tables and
+// does not use its column, so comparing with the same upstream on more than one column is reported as well.
+func ValidateCrossAssetChecks(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
+	issues := make([]*Issue, 0)
+	upstreamCountColumns := make(map[string]string)
+
+	for i := range asset.Columns {
+		column := &asset.Columns[i]
+		for j := range column.Checks {
+			check := &column.Checks[j]
+			if !pipeline.IsCrossAssetColumnCheck(check.Name) {
+				continue
+			}
+
+			ref, _, err := p.ColumnCheckReference(asset, column, check)
+			if err != nil {
+				issues = append(issues, &Issue{
+					Task:        asset,
+					Description: fmt.Sprintf("Invalid `%s` check on column '%s': %s", check.Name, column.Name, err),
+				})
+				continue
+			}
+
+			if check.Name != pipeline.ColumnCheckMatchesUpstreamCount {
+				continue
+			}
+
+			if previous, ok := upstreamCountColumns[ref]; ok {
+				issues = append(issues, &Issue{
+					Task:        asset,
+					Description: fmt.Sprintf("The `%s` check on column '%s' compares with '%s' again, it is already defined on column '%s' and the row counts do not depend on the column", check.Name, column.Name, ref, previous),
+				})
+				continue
+			}
+			upstreamCountColumns[ref] = column.Name
+		}
+	}
+
+	return issues, nil
+}
+
 func ValidateAssetDirectoryExist(p *pipeline.Pipeline) ([]*Issue, error) {
 	var issues []*Issue
 
@@ -843,19 +1203,10 @@ func (g *GlossaryChecker) EnsureAssetEntitiesExistInGlossary(ctx context.Context
 	if asset.Columns == nil {
 		return issues, nil
 	}
-	var err error
 
-	foundGlossary := g.foundGlossary
-	if g.foundGlossary == nil {
-		foundGlossary, err = g.gr.GetGlossary(p.DefinitionFile.Path)
-		if err != nil {
-			g.foundGlossary = &glossary.Glossary{Entities: make([]*glossary.Entity, 0)}
-			return issues, err
-		}
-
-		if foundGlossary != nil && g.cacheFoundGlossary {
-			g.foundGlossary = foundGlossary
-		}
+	foundGlossary, err := g.getGlossary(p)
+	if err != nil {
+		return issues, err
 	}
 
 	for _, column := range asset.Columns {
@@ -900,6 +1251,61 @@ func (g *GlossaryChecker) EnsureAssetEntitiesExistInGlossary(ctx context.Context
 	return issues, nil
 }
 
+// EnsurePIIColumnsAreClassified reports the columns that extend a glossary attribute marked as PII without a
+// classification, so that they can be protected in the warehouse when the metadata is pushed.
+func (g *GlossaryChecker) EnsurePIIColumnsAreClassified(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
+	issues := make([]*Issue, 0)
+	if asset.Columns == nil {
+		return issues, nil
+	}
+
+	foundGlossary, err := g.getGlossary(p)
+	if err != nil {
+		return issues, err
+	}
+
+	for _, column := range asset.Columns {
+		if column.EntityAttribute == nil || column.Classification != "" {
+			continue
+		}
+
+		entity := foundGlossary.GetEntity(column.EntityAttribute.Entity)
+		if entity == nil {
+			continue
+		}
+
+		attribute := entity.GetAttribute(column.EntityAttribute.Attribute)
+		if attribute == nil || !attribute.PII {
+			continue
+		}
+
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: fmt.Sprintf("Column '%s' extends the PII attribute '%s.%s' and must have a `classification`", column.Name, entity.Name, attribute.Name),
+		})
+	}
+
+	return issues, nil
+}
+
+func (g *GlossaryChecker) getGlossary(p *pipeline.Pipeline) (*glossary.Glossary, error) {
+	if g.foundGlossary != nil {
+		return g.foundGlossary, nil
+	}
+
+	foundGlossary, err := g.gr.GetGlossary(p.DefinitionFile.Path)
+	if err != nil {
+		g.foundGlossary = &glossary.Glossary{Entities: make([]*glossary.Entity, 0)}
+		return nil, err
+	}
+
+	if foundGlossary != nil && g.cacheFoundGlossary {
+		g.foundGlossary = foundGlossary
+	}
+
+	return foundGlossary, nil
+}
+
 var assetTypeDialectMap = map[pipeline.AssetType]string{
 	"bq.sql": "bigquery",
 	"sf.sql": "snowflake",
@@ -911,11 +1317,13 @@ type sqlParser interface {
 
 type jinjaRenderer interface {
 	Render(query string) (string, error)
+	WithContext(values jinja.Context) jinja.RendererInterface
 }
 
 type UsedTableValidatorRule struct {
-	renderer jinjaRenderer
-	parser   sqlParser
+	renderer    jinjaRenderer
+	parser      sqlParser
+	workerCount int
 }
 
 func (u UsedTableValidatorRule) Name() string {
@@ -934,8 +1342,32 @@ func (u UsedTableValidatorRule) GetSeverity() ValidatorSeverity {
 	return ValidatorSeverityWarning
 }
 
-func (u UsedTableValidatorRule) Validate(p *pipeline.Pipeline) ([]*Issue, error) {
-	return CallFuncForEveryAsset(u.ValidateAsset)(p)
+func (u UsedTableValidatorRule) Validate(ctx context.Context, p *pipeline.Pipeline) ([]*Issue, error) {
+	if u.workerCount <= 1 {
+		return CallFuncForEveryAsset(u.ValidateAsset)(p)
+	}
+
+	// the parser runs multiple processes, parse the assets concurrently to make use of them
+	assetIssues := make([][]*Issue, len(p.Assets))
+	wp := pool.New().WithErrors().WithMaxGoroutines(u.workerCount)
+	for i, asset := range p.Assets {
+		wp.Go(func() error {
+			issues, err := u.ValidateAsset(ctx, p, asset)
+			assetIssues[i] = issues
+			return err
+		})
+	}
+
+	if err := wp.Wait(); err != nil {
+		return make([]*Issue, 0), err
+	}
+
+	issues := make([]*Issue, 0)
+	for _, found := range assetIssues {
+		issues = append(issues, found...)
+	}
+
+	return issues, nil
 }
 
 func (u UsedTableValidatorRule) ValidateAsset(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
@@ -950,7 +1382,7 @@ func (u UsedTableValidatorRule) ValidateAsset(ctx context.Context, p *pipeline.P
 		return issues, nil
 	}
 
-	renderedQ, err := u.renderer.Render(asset.ExecutableFile.Content)
+	renderedQ, err := u.renderer.WithContext(pipeline.AssetRenderContext(ctx, p, asset)).Render(asset.ExecutableFile.Content)
 	if err != nil {
 		issues = append(issues, &Issue{
 			Task:        asset,
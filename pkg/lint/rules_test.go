@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/bruin-data/bruin/pkg/glossary"
+	"github.com/bruin-data/bruin/pkg/jinja"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
@@ -548,6 +549,37 @@ func TestEnsureDependencyExists(t *testing.T) {
 	}
 }
 
+func TestEnsureRefsExistForASingleAsset(t *testing.T) {
+	t.Parallel()
+
+	report := &pipeline.Asset{
+		Name: "dataset.report",
+		Type: pipeline.AssetTypeBigqueryQuery,
+		ExecutableFile: pipeline.ExecutableFile{
+			Content: "-- the old source was ref('dataset.removed')\nSELECT * FROM {{ ref('dataset.orders') }} JOIN {{ ref('dataset.unknown') }}",
+		},
+	}
+	python := &pipeline.Asset{
+		Name: "python_asset",
+		Type: pipeline.AssetTypePython,
+		ExecutableFile: pipeline.ExecutableFile{
+			Content: "print(ref('dataset.unknown'))",
+		},
+	}
+	p := &pipeline.Pipeline{
+		Assets: []*pipeline.Asset{{Name: "dataset.orders"}, report, python},
+	}
+
+	got, err := CallFuncForEveryAsset(EnsureRefsExistForASingleAsset)(p)
+	require.NoError(t, err)
+	require.Equal(t, []*Issue{
+		{
+			Task:        report,
+			Description: "ref('dataset.unknown') refers to an asset that does not exist",
+		},
+	}, got)
+}
+
 func TestEnsurePipelineScheduleIsValidCron(t *testing.T) {
 	t.Parallel()
 
@@ -2075,6 +2107,70 @@ func TestGlossaryChecker_EnsureAssetEntitiesExistInGlossary(t *testing.T) {
 	}
 }
 
+func TestGlossaryChecker_EnsurePIIColumnsAreClassified(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		columns []pipeline.Column
+		want    []string
+	}{
+		{
+			name: "columns without pii attributes are skipped",
+			columns: []pipeline.Column{
+				{Name: "col1"},
+				{Name: "col2", EntityAttribute: &pipeline.EntityAttribute{Entity: "Customer", Attribute: "ID"}},
+				{Name: "col3", EntityAttribute: &pipeline.EntityAttribute{Entity: "Missing", Attribute: "Email"}},
+			},
+			want: []string{},
+		},
+		{
+			name: "classified pii columns are not reported",
+			columns: []pipeline.Column{
+				{Name: "email", Classification: "pii", EntityAttribute: &pipeline.EntityAttribute{Entity: "Customer", Attribute: "Email"}},
+			},
+			want: []string{},
+		},
+		{
+			name: "pii columns without a classification are reported",
+			columns: []pipeline.Column{
+				{Name: "email", EntityAttribute: &pipeline.EntityAttribute{Entity: "Customer", Attribute: "Email"}},
+				{Name: "id", EntityAttribute: &pipeline.EntityAttribute{Entity: "Customer", Attribute: "ID"}},
+			},
+			want: []string{"Column 'email' extends the PII attribute 'Customer.Email' and must have a `classification`"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := GlossaryChecker{
+				foundGlossary: &glossary.Glossary{
+					Entities: []*glossary.Entity{
+						{
+							Name: "Customer",
+							Attributes: map[string]*glossary.Attribute{
+								"ID":    {Name: "ID"},
+								"Email": {Name: "Email", PII: true},
+							},
+						},
+					},
+				},
+			}
+
+			got, err := checker.EnsurePIIColumnsAreClassified(context.Background(), &pipeline.Pipeline{}, &pipeline.Asset{Name: "asset1", Columns: tt.columns})
+			require.NoError(t, err)
+
+			gotMessages := make([]string, len(got))
+			for i, issue := range got {
+				gotMessages[i] = issue.Description
+			}
+
+			assert.Equal(t, tt.want, gotMessages)
+		})
+	}
+}
+
 type mockSQLParser struct {
 	mock.Mock
 }
@@ -2093,6 +2189,10 @@ func (m *mockRenderer) Render(query string) (string, error) {
 	return args.Get(0).(string), args.Error(1)
 }
 
+func (m *mockRenderer) WithContext(values jinja.Context) jinja.RendererInterface {
+	return m
+}
+
 func TestUsedTableValidatorRule_ValidateAsset(t *testing.T) {
 	t.Parallel()
 
@@ -2251,7 +2351,7 @@ func TestUsedTableValidatorRule_ValidateAsset(t *testing.T) {
 				tt.setup(renderer, parser)
 			}
 
-			u := UsedTableValidatorRule{renderer, parser}
+			u := UsedTableValidatorRule{renderer: renderer, parser: parser}
 			got, err := u.ValidateAsset(context.Background(), pp, tt.asset)
 			if !tt.wantErr(t, err) {
 				return
@@ -2437,3 +2537,512 @@ func TestEnsureValidPythonAssetMaterialization(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateContractColumns(t *testing.T) {
+	t.Parallel()
+
+	tableMaterialization := pipeline.Materialization{Type: pipeline.MaterializationTypeTable}
+
+	tests := []struct {
+		name  string
+		asset *pipeline.Asset
+		want  []string
+	}{
+		{
+			name: "assets without a contract are skipped",
+			asset: &pipeline.Asset{
+				Type:    pipeline.AssetTypeBigqueryQuery,
+				Columns: []pipeline.Column{{Name: "col1"}},
+			},
+			want: []string{},
+		},
+		{
+			name: "enforced contract with typed columns is valid",
+			asset: &pipeline.Asset{
+				Type:            pipeline.AssetTypeBigqueryQuery,
+				Contract:        pipeline.ContractModeEnforced,
+				Materialization: tableMaterialization,
+				Columns:         []pipeline.Column{{Name: "col1", Type: "INT64"}, {Name: "col2", Type: "STRING"}},
+			},
+			want: []string{},
+		},
+		{
+			name: "invalid contract mode is reported",
+			asset: &pipeline.Asset{
+				Type:     pipeline.AssetTypeBigqueryQuery,
+				Contract: "strict",
+			},
+			want: []string{"Invalid contract mode 'strict', the only supported value is 'enforced'"},
+		},
+		{
+			name: "unsupported asset types are reported",
+			asset: &pipeline.Asset{
+				Type:     pipeline.AssetTypeMsSQLQuery,
+				Contract: pipeline.ContractModeEnforced,
+			},
+			want: []string{"Enforced contracts are not supported for assets of type 'ms.sql'"},
+		},
+		{
+			name: "missing columns and table materialization are reported",
+			asset: &pipeline.Asset{
+				Type:     pipeline.AssetTypeSnowflakeQuery,
+				Contract: pipeline.ContractModeEnforced,
+			},
+			want: []string{
+				"Enforced contracts require the asset to be materialized as a table",
+				"Assets with an enforced contract must declare their columns",
+			},
+		},
+		{
+			name: "incremental strategies are reported",
+			asset: &pipeline.Asset{
+				Type:     pipeline.AssetTypePostgresQuery,
+				Contract: pipeline.ContractModeEnforced,
+				Materialization: pipeline.Materialization{
+					Type:     pipeline.MaterializationTypeTable,
+					Strategy: pipeline.MaterializationStrategyMerge,
+				},
+				Columns: []pipeline.Column{{Name: "col1", Type: "INTEGER"}},
+			},
+			want: []string{"Enforced contracts are only supported with the 'create+replace' strategy, 'merge' given"},
+		},
+		{
+			name: "columns without a type are reported",
+			asset: &pipeline.Asset{
+				Type:            pipeline.AssetTypeDuckDBQuery,
+				Contract:        pipeline.ContractModeEnforced,
+				Materialization: tableMaterialization,
+				Columns:         []pipeline.Column{{Name: "col1", Type: "INTEGER"}, {Name: "col2"}, {Name: "col3", Type: " "}},
+			},
+			want: []string{
+				"Column 'col2' must have a type since the asset has an enforced contract",
+				"Column 'col3' must have a type since the asset has an enforced contract",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			issues, err := ValidateContractColumns(context.Background(), &pipeline.Pipeline{}, tt.asset)
+			require.NoError(t, err)
+
+			descriptions := make([]string, 0, len(issues))
+			for _, issue := range issues {
+				descriptions = append(descriptions, issue.Description)
+			}
+
+			assert.Equal(t, tt.want, descriptions)
+		})
+	}
+}
+
+func TestValidateMinMaxChecks(t *testing.T) {
+	t.Parallel()
+
+	intValue := 10
+	floatValue := 1.5
+	stringValue := "2024-01-01"
+	emptyString := ""
+
+	tests := []struct {
+		name  string
+		asset *pipeline.Asset
+		want  []string
+	}{
+		{
+			name: "numeric and date boundaries are valid",
+			asset: &pipeline.Asset{
+				Type: pipeline.AssetTypeBigqueryQuery,
+				Columns: []pipeline.Column{
+					{Name: "amount", Type: "FLOAT64", Checks: []pipeline.ColumnCheck{
+						{Name: "min", Value: pipeline.ColumnCheckValue{Int: &intValue}},
+						{Name: "max", Value: pipeline.ColumnCheckValue{Float: &floatValue}},
+					}},
+					{Name: "created_at", Type: "DATE", Checks: []pipeline.ColumnCheck{
+						{Name: "min", Value: pipeline.ColumnCheckValue{String: &stringValue}},
+					}},
+				},
+			},
+			want: []string{},
+		},
+		{
+			name: "other checks are ignored",
+			asset: &pipeline.Asset{
+				Type: pipeline.AssetTypeTableau,
+				Columns: []pipeline.Column{
+					{Name: "id", Checks: []pipeline.ColumnCheck{{Name: "not_null"}}},
+				},
+			},
+			want: []string{},
+		},
+		{
+			name: "missing or empty values are reported",
+			asset: &pipeline.Asset{
+				Type: pipeline.AssetTypeSnowflakeQuery,
+				Columns: []pipeline.Column{
+					{Name: "amount", Checks: []pipeline.ColumnCheck{
+						{Name: "min"},
+						{Name: "max", Value: pipeline.ColumnCheckValue{String: &emptyString}},
+					}},
+				},
+			},
+			want: []string{
+				"The value of the `min` check on column 'amount' must be a number, a date or a timestamp",
+				"The value of the `max` check on column 'amount' must be a number, a date or a timestamp",
+			},
+		},
+		{
+			name: "ingestr assets use the platform of their destination",
+			asset: &pipeline.Asset{
+				Type:       pipeline.AssetTypeIngestr,
+				Parameters: map[string]string{"destination": "unknown"},
+				Columns: []pipeline.Column{
+					{Name: "amount", Checks: []pipeline.ColumnCheck{{Name: "max", Value: pipeline.ColumnCheckValue{Int: &intValue}}}},
+				},
+			},
+			want: []string{"The `max` check on column 'amount' is not supported for assets of type 'ingestr'"},
+		},
+		{
+			name: "python assets are allowed",
+			asset: &pipeline.Asset{
+				Type: pipeline.AssetTypePython,
+				Columns: []pipeline.Column{
+					{Name: "amount", Checks: []pipeline.ColumnCheck{{Name: "max", Value: pipeline.ColumnCheckValue{Int: &intValue}}}},
+				},
+			},
+			want: []string{},
+		},
+		{
+			name: "unsupported platforms are reported",
+			asset: &pipeline.Asset{
+				Type: pipeline.AssetTypeTableau,
+				Columns: []pipeline.Column{
+					{Name: "amount", Checks: []pipeline.ColumnCheck{{Name: "min", Value: pipeline.ColumnCheckValue{Int: &intValue}}}},
+				},
+			},
+			want: []string{"The `min` check on column 'amount' is not supported for assets of type 'tableau'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			issues, err := ValidateMinMaxChecks(context.Background(), &pipeline.Pipeline{}, tt.asset)
+			require.NoError(t, err)
+
+			descriptions := make([]string, 0, len(issues))
+			for _, issue := range issues {
+				descriptions = append(descriptions, issue.Description)
+			}
+
+			assert.Equal(t, tt.want, descriptions)
+		})
+	}
+}
+
+func TestValidateCrossAssetChecks(t *testing.T) {
+	t.Parallel()
+
+	customersRef := "raw.customers.id"
+	unknownRef := "raw.users.id"
+
+	customers := &pipeline.Asset{Name: "raw.customers"}
+	asset := &pipeline.Asset{
+		Name: "raw.orders",
+		Columns: []pipeline.Column{
+			{
+				Name: "customer_id",
+				Checks: []pipeline.ColumnCheck{
+					{Name: "not_null"},
+					{Name: "relationships", Value: pipeline.ColumnCheckValue{String: &customersRef}},
+					{Name: "references", Value: pipeline.ColumnCheckValue{String: &unknownRef}},
+				},
+			},
+			{
+				Name:   "id",
+				Checks: []pipeline.ColumnCheck{{Name: "matches_upstream_count"}},
+			},
+		},
+	}
+	p := &pipeline.Pipeline{Assets: []*pipeline.Asset{customers, asset}}
+
+	stagedRef := "staging.orders"
+	staged := &pipeline.Asset{
+		Name:      "mart.orders",
+		Upstreams: []pipeline.Upstream{{Type: "asset", Value: "staging.orders"}},
+		Columns: []pipeline.Column{
+			{
+				Name:   "id",
+				Checks: []pipeline.ColumnCheck{{Name: "matches_upstream_count"}},
+			},
+			{
+				Name:   "customer_id",
+				Checks: []pipeline.ColumnCheck{{Name: "matches_upstream_count", Value: pipeline.ColumnCheckValue{String: &stagedRef}}},
+			},
+			{
+				Name:   "amount",
+				Checks: []pipeline.ColumnCheck{{Name: "matches_upstream_count", Value: pipeline.ColumnCheckValue{String: &customersRef}}},
+			},
+		},
+	}
+	p.Assets = append(p.Assets, &pipeline.Asset{Name: "staging.orders"}, staged)
+
+	issues, err := ValidateCrossAssetChecks(context.Background(), p, asset)
+	require.NoError(t, err)
+
+	descriptions := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		descriptions = append(descriptions, issue.Description)
+	}
+
+	assert.Equal(t, []string{
+		"Invalid `references` check on column 'customer_id': 'raw.users.id' does not refer to an asset in the pipeline",
+		"Invalid `matches_upstream_count` check on column 'id': the upstream asset to compare with must be given as the value since the asset has 0 upstream assets",
+	}, descriptions)
+
+	issues, err = ValidateCrossAssetChecks(context.Background(), p, staged)
+	require.NoError(t, err)
+
+	descriptions = make([]string, 0, len(issues))
+	for _, issue := range issues {
+		descriptions = append(descriptions, issue.Description)
+	}
+
+	assert.Equal(t, []string{
+		"The `matches_upstream_count` check on column 'customer_id' compares with 'staging.orders' again, it is already defined on column 'id' and the row counts do not depend on the column",
+		"Invalid `matches_upstream_count` check on column 'amount': 'raw.customers.id' does not refer to an asset in the pipeline",
+	}, descriptions)
+}
+
+func TestValidateFreshnessCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		asset *pipeline.Asset
+		want  []string
+	}{
+		{
+			name:  "assets without a freshness check are skipped",
+			asset: &pipeline.Asset{Type: pipeline.AssetTypeTableau},
+			want:  []string{},
+		},
+		{
+			name: "valid freshness check on a source",
+			asset: &pipeline.Asset{
+				Type:      pipeline.AssetTypeBigquerySource,
+				Freshness: &pipeline.FreshnessCheck{Column: "updated_at", WarnAfter: "12h", ErrorAfter: "1d"},
+			},
+			want: []string{},
+		},
+		{
+			name: "unsupported platforms are reported",
+			asset: &pipeline.Asset{
+				Type:      pipeline.AssetTypeTableau,
+				Freshness: &pipeline.FreshnessCheck{Column: "updated_at", ErrorAfter: "1d"},
+			},
+			want: []string{"Freshness checks are not supported for assets of type 'tableau'"},
+		},
+		{
+			name: "missing column and thresholds are reported",
+			asset: &pipeline.Asset{
+				Type:      pipeline.AssetTypeSnowflakeQuery,
+				Freshness: &pipeline.FreshnessCheck{},
+			},
+			want: []string{
+				"The freshness check must have a `column` to find the latest value of",
+				"The freshness check must have at least one of `warn_after` or `error_after`",
+			},
+		},
+		{
+			name: "invalid thresholds are reported",
+			asset: &pipeline.Asset{
+				Type:      pipeline.AssetTypeDuckDBQuery,
+				Freshness: &pipeline.FreshnessCheck{Column: "updated_at", WarnAfter: "soon"},
+			},
+			want: []string{"The freshness check has an invalid `warn_after` value: 'soon' is not a valid duration, use values such as '30m', '12h' or '2d'"},
+		},
+		{
+			name: "warn_after greater than error_after is reported",
+			asset: &pipeline.Asset{
+				Type:      pipeline.AssetTypePostgresQuery,
+				Freshness: &pipeline.FreshnessCheck{Column: "updated_at", WarnAfter: "2d", ErrorAfter: "1d"},
+			},
+			want: []string{"The `warn_after` value of the freshness check must not be greater than its `error_after` value"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			issues, err := ValidateFreshnessCheck(context.Background(), &pipeline.Pipeline{}, tt.asset)
+			require.NoError(t, err)
+
+			descriptions := make([]string, 0, len(issues))
+			for _, issue := range issues {
+				descriptions = append(descriptions, issue.Description)
+			}
+
+			assert.Equal(t, tt.want, descriptions)
+		})
+	}
+}
+
+func TestValidateAnomalyChecks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		asset *pipeline.Asset
+		want  []string
+	}{
+		{
+			name:  "assets without anomaly checks are skipped",
+			asset: &pipeline.Asset{Type: pipeline.AssetTypeTableau},
+			want:  []string{},
+		},
+		{
+			name: "valid anomaly checks",
+			asset: &pipeline.Asset{
+				Type: pipeline.AssetTypeBigqueryQuery,
+				Anomalies: []pipeline.AnomalyCheck{
+					{Name: "row_count", Metric: "row_count", MaxStddev: 3},
+					{Name: "sum_amount", Metric: "sum", Column: "amount", MaxChangePercent: 50, Severity: "warning"},
+				},
+			},
+			want: []string{},
+		},
+		{
+			name: "unsupported platforms are reported",
+			asset: &pipeline.Asset{
+				Type:      pipeline.AssetTypeTableau,
+				Anomalies: []pipeline.AnomalyCheck{{Name: "row_count", Metric: "row_count", MaxStddev: 3}},
+			},
+			want: []string{"Anomaly checks are not supported for assets of type 'tableau'"},
+		},
+		{
+			name: "invalid checks are reported",
+			asset: &pipeline.Asset{
+				Type: pipeline.AssetTypeDuckDBQuery,
+				Anomalies: []pipeline.AnomalyCheck{
+					{Name: "rows", Metric: "rows", MaxStddev: 3},
+					{Name: "rows", Metric: "avg", Severity: "fatal"},
+					{Name: "nulls", Metric: "null_ratio", Column: "email", MaxStddev: -1},
+					{Name: "total", Metric: "sum", Column: "amount", MaxStddev: 3, RecoverAfter: -1},
+				},
+			},
+			want: []string{
+				"Anomaly check 'rows' has an invalid metric 'rows', supported metrics are: row_count, sum, avg, null_ratio",
+				"Duplicate anomaly check name 'rows', anomaly checks must have unique names",
+				"Anomaly check 'rows' must have a `column` for the 'avg' metric",
+				"Anomaly check 'rows' must have at least one of `max_stddev` or `max_change_percent`",
+				"Anomaly check 'rows' has an invalid severity 'fatal', it must be either 'error' or 'warning'",
+				"Anomaly check 'nulls' must not have negative values",
+				"Anomaly check 'total' must not have negative values",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			issues, err := ValidateAnomalyChecks(context.Background(), &pipeline.Pipeline{}, tt.asset)
+			require.NoError(t, err)
+
+			descriptions := make([]string, 0, len(issues))
+			for _, issue := range issues {
+				descriptions = append(descriptions, issue.Description)
+			}
+
+			assert.Equal(t, tt.want, descriptions)
+		})
+	}
+}
+
+func TestValidateSQLTests(t *testing.T) {
+	t.Parallel()
+
+	rows := []map[string]interface{}{{"id": 1}}
+	tests := []struct {
+		name  string
+		asset *pipeline.Asset
+		want  []string
+	}{
+		{
+			name:  "assets without tests are skipped",
+			asset: &pipeline.Asset{Type: pipeline.AssetTypePython},
+			want:  []string{},
+		},
+		{
+			name: "valid tests",
+			asset: &pipeline.Asset{
+				Type: pipeline.AssetTypeBigqueryQuery,
+				Tests: []pipeline.SQLTest{
+					{
+						Name:   "inline",
+						Given:  []pipeline.SQLTestFixture{{Table: "raw.orders", Rows: rows}},
+						Expect: pipeline.SQLTestExpectation{Rows: rows},
+					},
+					{
+						Name:   "csv",
+						Given:  []pipeline.SQLTestFixture{{Table: "raw.orders", CSV: "orders.csv"}},
+						Expect: pipeline.SQLTestExpectation{CSV: "expected.csv"},
+					},
+				},
+			},
+			want: []string{},
+		},
+		{
+			name: "unsupported asset types are reported",
+			asset: &pipeline.Asset{
+				Type:  pipeline.AssetTypePython,
+				Tests: []pipeline.SQLTest{{Name: "inline"}},
+			},
+			want: []string{"Tests are not supported for assets of type 'python'"},
+		},
+		{
+			name: "invalid tests are reported",
+			asset: &pipeline.Asset{
+				Type: pipeline.AssetTypeDuckDBQuery,
+				Tests: []pipeline.SQLTest{
+					{Name: "first", Given: []pipeline.SQLTestFixture{{Rows: rows}, {Table: "raw.orders"}}},
+					{
+						Name:   "first",
+						Given:  []pipeline.SQLTestFixture{{Table: "raw.orders", Rows: rows, CSV: "orders.csv"}},
+						Expect: pipeline.SQLTestExpectation{Rows: rows, CSV: "expected.csv"},
+					},
+					{},
+				},
+			},
+			want: []string{
+				"The fixtures of test 'first' must have a `table`",
+				"The fixture for table 'raw.orders' in test 'first' must have either `rows` or `csv`",
+				"Duplicate test name 'first', tests must have unique names",
+				"The fixture for table 'raw.orders' in test 'first' must have either `rows` or `csv`",
+				"The expectation of test 'first' must not have both `rows` and `csv`",
+				"Tests must have a name",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			issues, err := ValidateSQLTests(context.Background(), &pipeline.Pipeline{}, tt.asset)
+			require.NoError(t, err)
+
+			descriptions := make([]string, 0, len(issues))
+			for _, issue := range issues {
+				descriptions = append(descriptions, issue.Description)
+			}
+
+			assert.Equal(t, tt.want, descriptions)
+		})
+	}
+}
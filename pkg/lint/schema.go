@@ -0,0 +1,151 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// SchemaDriftRule compares the columns declared on the assets with the columns of the tables in the warehouse, and
+// reports the columns that are missing on either side as well as the columns whose types do not match.
+type SchemaDriftRule struct {
+	Identifier  string
+	Connections connectionManager
+	Logger      *zap.SugaredLogger
+}
+
+func (r *SchemaDriftRule) Name() string {
+	return r.Identifier
+}
+
+func (r *SchemaDriftRule) IsFast() bool {
+	return false
+}
+
+func (r *SchemaDriftRule) GetApplicableLevels() []Level {
+	return []Level{LevelPipeline, LevelAsset}
+}
+
+func (r *SchemaDriftRule) GetSeverity() ValidatorSeverity {
+	return ValidatorSeverityCritical
+}
+
+// Validate checks every asset of the pipeline, a failure to check one of them is reported as an issue of that asset so
+// that the rest of the pipeline is still checked.
+func (r *SchemaDriftRule) Validate(ctx context.Context, p *pipeline.Pipeline) ([]*Issue, error) {
+	issues := make([]*Issue, 0)
+	for _, asset := range p.Assets {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		assetIssues, err := r.ValidateAsset(ctx, p, asset)
+		if err != nil {
+			issues = append(issues, &Issue{
+				Task:        asset,
+				Description: fmt.Sprintf("Failed to check the schema of the asset '%s': %v", asset.Name, err),
+			})
+			continue
+		}
+
+		issues = append(issues, assetIssues...)
+	}
+
+	return issues, nil
+}
+
+func (r *SchemaDriftRule) ValidateAsset(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) ([]*Issue, error) {
+	issues := make([]*Issue, 0)
+	if len(asset.Columns) == 0 || !assetHasTargetTable(asset) {
+		return issues, nil
+	}
+
+	connName, err := p.GetConnectionNameForAsset(asset)
+	if err != nil {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: fmt.Sprintf("Cannot get connection for asset '%s': %v", asset.Name, err),
+		})
+
+		return issues, nil
+	}
+
+	conn, err := r.Connections.GetConnection(connName)
+	if err != nil {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: fmt.Sprintf("Cannot get connection for asset '%s': %v", asset.Name, err),
+		})
+
+		return issues, nil
+	}
+
+	fetcher, ok := conn.(ansisql.TableSchemaFetcher)
+	if !ok {
+		r.Logger.Debugw("Skipping schema drift check, the connection does not support schema introspection", "asset", asset.Name, "connection", connName)
+		return issues, nil
+	}
+
+	columns, err := fetcher.GetTableColumns(ctx, asset.Name)
+	if err != nil {
+		if errors.Is(err, ansisql.ErrTableNotFound) {
+			r.Logger.Debugw("Skipping schema drift check, the table does not exist yet", "asset", asset.Name)
+			return issues, nil
+		}
+
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: fmt.Sprintf("Failed to fetch the schema of the table '%s': %v", asset.Name, err),
+		})
+
+		return issues, nil
+	}
+
+	return SchemaDriftIssues(asset, ansisql.CompareColumns(asset.Columns, columns)), nil
+}
+
+// SchemaDriftIssues converts the given drift into issues, one per drifted column.
+func SchemaDriftIssues(asset *pipeline.Asset, drift *ansisql.SchemaDrift) []*Issue {
+	issues := make([]*Issue, 0)
+	for _, col := range drift.MissingInDatabase {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: fmt.Sprintf("Column '%s' is defined in the asset but does not exist in the table '%s'", col, asset.Name),
+		})
+	}
+
+	for _, col := range drift.MissingInDefinition {
+		issues = append(issues, &Issue{
+			Task:        asset,
+			Description: fmt.Sprintf("Column '%s' exists in the table '%s' but is not defined in the asset", col.Name, asset.Name),
+			Context:     []string{"Type in the database: " + col.Type},
+		})
+	}
+
+	for _, mismatch := range drift.TypeMismatches {
+		issues = append(issues, &Issue{
+			Task: asset,
+			Description: fmt.Sprintf(
+				"Column '%s' is defined as '%s' in the asset but its type is '%s' in the table '%s'",
+				mismatch.Column, mismatch.DeclaredType, mismatch.ActualType, asset.Name,
+			),
+		})
+	}
+
+	return issues
+}
+
+func assetHasTargetTable(asset *pipeline.Asset) bool {
+	if asset.Materialization.Type != pipeline.MaterializationTypeNone {
+		return true
+	}
+
+	return asset.Type == pipeline.AssetTypeIngestr ||
+		strings.HasSuffix(string(asset.Type), ".source") ||
+		strings.HasSuffix(string(asset.Type), ".seed")
+}
@@ -0,0 +1,194 @@
+package lint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/ansisql"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type mockSchemaFetcher struct {
+	mock.Mock
+}
+
+func (m *mockSchemaFetcher) GetTableColumns(ctx context.Context, tableName string) ([]*ansisql.DBColumn, error) {
+	res := m.Called(ctx, tableName)
+	cols, _ := res.Get(0).([]*ansisql.DBColumn)
+	return cols, res.Error(1)
+}
+
+func TestSchemaDriftRule_ValidateAsset(t *testing.T) {
+	t.Parallel()
+
+	p := &pipeline.Pipeline{
+		DefaultConnections: map[string]string{"google_cloud_platform": "gcp"},
+	}
+
+	tableAsset := &pipeline.Asset{
+		Name:            "dataset.table",
+		Type:            pipeline.AssetTypeBigqueryQuery,
+		Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+		Columns: []pipeline.Column{
+			{Name: "id", Type: "integer"},
+			{Name: "name", Type: "string"},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		asset         *pipeline.Asset
+		setupFetcher  func(f *mockSchemaFetcher)
+		wantIssues    []string
+		skipsDatabase bool
+	}{
+		{
+			name: "assets without columns are skipped",
+			asset: &pipeline.Asset{
+				Name:            "dataset.table",
+				Type:            pipeline.AssetTypeBigqueryQuery,
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+			},
+			skipsDatabase: true,
+		},
+		{
+			name: "assets without a target table are skipped",
+			asset: &pipeline.Asset{
+				Name:    "dataset.table",
+				Type:    pipeline.AssetTypeBigqueryQuery,
+				Columns: []pipeline.Column{{Name: "id"}},
+			},
+			skipsDatabase: true,
+		},
+		{
+			name:  "missing tables are skipped",
+			asset: tableAsset,
+			setupFetcher: func(f *mockSchemaFetcher) {
+				f.On("GetTableColumns", mock.Anything, "dataset.table").Return(nil, ansisql.ErrTableNotFound)
+			},
+		},
+		{
+			name:  "introspection errors are reported",
+			asset: tableAsset,
+			setupFetcher: func(f *mockSchemaFetcher) {
+				f.On("GetTableColumns", mock.Anything, "dataset.table").Return(nil, errors.New("access denied"))
+			},
+			wantIssues: []string{"Failed to fetch the schema of the table 'dataset.table': access denied"},
+		},
+		{
+			name:  "matching schema has no issues",
+			asset: tableAsset,
+			setupFetcher: func(f *mockSchemaFetcher) {
+				f.On("GetTableColumns", mock.Anything, "dataset.table").Return([]*ansisql.DBColumn{
+					{Name: "id", Type: "INT64"},
+					{Name: "name", Type: "STRING"},
+				}, nil)
+			},
+		},
+		{
+			name:  "drift is reported",
+			asset: tableAsset,
+			setupFetcher: func(f *mockSchemaFetcher) {
+				f.On("GetTableColumns", mock.Anything, "dataset.table").Return([]*ansisql.DBColumn{
+					{Name: "id", Type: "STRING"},
+					{Name: "extra", Type: "BOOL"},
+				}, nil)
+			},
+			wantIssues: []string{
+				"Column 'name' is defined in the asset but does not exist in the table 'dataset.table'",
+				"Column 'extra' exists in the table 'dataset.table' but is not defined in the asset",
+				"Column 'id' is defined as 'integer' in the asset but its type is 'STRING' in the table 'dataset.table'",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fetcher := new(mockSchemaFetcher)
+			if tt.setupFetcher != nil {
+				tt.setupFetcher(fetcher)
+			}
+
+			conn := new(mockConnectionManager)
+			if !tt.skipsDatabase {
+				conn.On("GetConnection", "gcp").Return(fetcher, nil)
+			}
+
+			rule := &SchemaDriftRule{
+				Identifier:  "schema-drift",
+				Connections: conn,
+				Logger:      zap.NewNop().Sugar(),
+			}
+
+			issues, err := rule.ValidateAsset(context.Background(), p, tt.asset)
+			require.NoError(t, err)
+
+			descriptions := make([]string, 0, len(issues))
+			for _, issue := range issues {
+				descriptions = append(descriptions, issue.Description)
+			}
+			if tt.wantIssues == nil {
+				tt.wantIssues = []string{}
+			}
+			assert.Equal(t, tt.wantIssues, descriptions)
+
+			conn.AssertExpectations(t)
+			fetcher.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSchemaDriftRule_Validate(t *testing.T) {
+	t.Parallel()
+
+	newAsset := func(name string) *pipeline.Asset {
+		return &pipeline.Asset{
+			Name:            name,
+			Type:            pipeline.AssetTypeBigqueryQuery,
+			Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeTable},
+			Columns:         []pipeline.Column{{Name: "id", Type: "integer"}},
+		}
+	}
+
+	p := &pipeline.Pipeline{
+		DefaultConnections: map[string]string{"google_cloud_platform": "gcp"},
+		Assets:             []*pipeline.Asset{newAsset("dataset.broken"), newAsset("dataset.drifted")},
+	}
+
+	fetcher := new(mockSchemaFetcher)
+	fetcher.On("GetTableColumns", mock.Anything, "dataset.broken").Return(nil, errors.New("connection reset"))
+	fetcher.On("GetTableColumns", mock.Anything, "dataset.drifted").Return([]*ansisql.DBColumn{{Name: "id", Type: "STRING"}}, nil)
+
+	conn := new(mockConnectionManager)
+	conn.On("GetConnection", "gcp").Return(fetcher, nil)
+
+	rule := &SchemaDriftRule{
+		Identifier:  "schema-drift",
+		Connections: conn,
+		Logger:      zap.NewNop().Sugar(),
+	}
+
+	issues, err := rule.Validate(context.Background(), p)
+	require.NoError(t, err)
+
+	descriptions := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		descriptions = append(descriptions, issue.Task.Name+": "+issue.Description)
+	}
+	assert.Equal(t, []string{
+		"dataset.broken: Failed to fetch the schema of the table 'dataset.broken': connection reset",
+		"dataset.drifted: Column 'id' is defined as 'integer' in the asset but its type is 'STRING' in the table 'dataset.drifted'",
+	}, descriptions)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = rule.Validate(ctx, p)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/bruin-data/bruin/pkg/ansisql"
 	"github.com/bruin-data/bruin/pkg/query"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/microsoft/go-mssqldb"
@@ -33,13 +34,13 @@ func (db *DB) GetIngestrURI() (string, error) {
 	return db.config.GetIngestrURI(), nil
 }
 
-func (db *DB) RunQueryWithoutResult(ctx context.Context, query *query.Query) error {
-	_, err := db.Select(ctx, query)
+func (db *DB) RunQueryWithoutResult(ctx context.Context, queryObj *query.Query) error {
+	_, err := db.Select(ctx, queryObj)
 	return err
 }
 
-func (db *DB) Select(ctx context.Context, query *query.Query) ([][]interface{}, error) {
-	queryString := query.String()
+func (db *DB) Select(ctx context.Context, queryObj *query.Query) ([][]interface{}, error) {
+	queryString := query.AnnotateQueryWithComment(ctx, queryObj.String())
 	rows, err := db.conn.QueryContext(ctx, queryString)
 	if err == nil {
 		err = rows.Err()
@@ -87,3 +88,88 @@ func (db *DB) Limit(query string, limit int64) string {
 	query = strings.TrimRight(query, "; \n\t")
 	return fmt.Sprintf("SELECT TOP %d * FROM (\n%s\n) as t", limit, query)
 }
+
+// GetTableColumns returns the columns of the given table in the order they are defined in the database. Tables without
+// a schema are looked up in the "dbo" schema.
+func (db *DB) GetTableColumns(ctx context.Context, tableName string) ([]*ansisql.DBColumn, error) {
+	schemaName, table, err := splitTableName(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	queryStr := fmt.Sprintf(
+		`SELECT COLUMN_NAME, DATA_TYPE, IS_NULLABLE
+FROM INFORMATION_SCHEMA.COLUMNS
+WHERE TABLE_SCHEMA = '%s' AND TABLE_NAME = '%s'
+ORDER BY ORDINAL_POSITION`,
+		ansisql.EscapeSQLString(schemaName),
+		ansisql.EscapeSQLString(table),
+	)
+
+	rows, err := db.Select(ctx, &query.Query{Query: queryStr})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query column metadata for %s", tableName)
+	}
+
+	if len(rows) == 0 {
+		return nil, ansisql.ErrTableNotFound
+	}
+
+	return ansisql.ColumnsFromInformationSchemaRows(rows)
+}
+
+// GetTables returns the names of the tables and views in the given schema.
+func (db *DB) GetTables(ctx context.Context, schemaName string) ([]string, error) {
+	queryStr := fmt.Sprintf(
+		`SELECT TABLE_NAME
+FROM INFORMATION_SCHEMA.TABLES
+WHERE TABLE_SCHEMA = '%s'
+ORDER BY TABLE_NAME`,
+		ansisql.EscapeSQLString(schemaName),
+	)
+
+	rows, err := db.Select(ctx, &query.Query{Query: queryStr})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the tables in schema %s", schemaName)
+	}
+
+	return ansisql.StringsFromRows(rows), nil
+}
+
+// GetPrimaryKeyColumns returns the columns of the primary key of the given table, if any.
+func (db *DB) GetPrimaryKeyColumns(ctx context.Context, tableName string) ([]string, error) {
+	schemaName, table, err := splitTableName(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	queryStr := fmt.Sprintf(
+		`SELECT KCU.COLUMN_NAME
+FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS TC
+JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE KCU
+  ON TC.CONSTRAINT_NAME = KCU.CONSTRAINT_NAME AND TC.TABLE_SCHEMA = KCU.TABLE_SCHEMA AND TC.TABLE_NAME = KCU.TABLE_NAME
+WHERE TC.CONSTRAINT_TYPE = 'PRIMARY KEY' AND TC.TABLE_SCHEMA = '%s' AND TC.TABLE_NAME = '%s'
+ORDER BY KCU.ORDINAL_POSITION`,
+		ansisql.EscapeSQLString(schemaName),
+		ansisql.EscapeSQLString(table),
+	)
+
+	rows, err := db.Select(ctx, &query.Query{Query: queryStr})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query the primary key of %s", tableName)
+	}
+
+	return ansisql.StringsFromRows(rows), nil
+}
+
+func splitTableName(tableName string) (string, string, error) {
+	tableComponents := strings.Split(tableName, ".")
+	switch len(tableComponents) {
+	case 1:
+		return "dbo", tableComponents[0], nil
+	case 2:
+		return tableComponents[0], tableComponents[1], nil
+	default:
+		return "", "", errors.Errorf("table name must be in table or schema.table format, '%s' given", tableName)
+	}
+}
@@ -2,6 +2,8 @@ package mssql
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/bruin-data/bruin/pkg/ansisql"
 	"github.com/bruin-data/bruin/pkg/pipeline"
@@ -21,6 +23,7 @@ type MsClient interface {
 
 type queryExtractor interface {
 	ExtractQueriesFromString(content string) ([]*query.Query, error)
+	CloneForAsset(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) query.QueryExtractor
 }
 
 type connectionFetcher interface {
@@ -47,7 +50,13 @@ func (o BasicOperator) Run(ctx context.Context, ti scheduler.TaskInstance) error
 }
 
 func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) error {
-	queries, err := o.extractor.ExtractQueriesFromString(t.ExecutableFile.Content)
+	ctx, err := ansisql.WithTargetTableState(ctx, o.connection, p, t, t.ExecutableFile.Content)
+	if err != nil {
+		return err
+	}
+
+	extractor := o.extractor.CloneForAsset(ctx, p, t)
+	queries, err := extractor.ExtractQueriesFromString(t.ExecutableFile.Content)
 	if err != nil {
 		return errors.Wrap(err, "cannot extract queries from the task file")
 	}
@@ -83,12 +92,91 @@ func (o BasicOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pip
 
 func NewColumnCheckOperator(manager connectionFetcher) *ansisql.ColumnCheckOperator {
 	return ansisql.NewColumnCheckOperator(map[string]ansisql.CheckRunner{
-		"not_null":        ansisql.NewNotNullCheck(manager),
-		"unique":          &UniqueCheck{conn: manager},
-		"positive":        ansisql.NewPositiveCheck(manager),
-		"non_negative":    ansisql.NewNonNegativeCheck(manager),
-		"negative":        ansisql.NewNegativeCheck(manager),
-		"accepted_values": &AcceptedValuesCheck{conn: manager},
-		"pattern":         &PatternCheck{conn: manager},
+		"not_null":               ansisql.NewNotNullCheck(manager),
+		"unique":                 &UniqueCheck{conn: manager},
+		"positive":               ansisql.NewPositiveCheck(manager),
+		"non_negative":           ansisql.NewNonNegativeCheck(manager),
+		"negative":               ansisql.NewNegativeCheck(manager),
+		"min":                    ansisql.NewMinCheck(manager),
+		"max":                    ansisql.NewMaxCheck(manager),
+		"relationships":          ansisql.NewRelationshipsCheck(manager),
+		"references":             ansisql.NewRelationshipsCheck(manager),
+		"matches_upstream_count": ansisql.NewMatchesUpstreamCountCheck(manager),
+		"accepted_values":        &AcceptedValuesCheck{conn: manager},
+		"pattern":                &PatternCheck{conn: manager},
 	})
 }
+
+// NewMetadataPushOperator pushes the metadata of the assets as extended properties on the tables and their columns.
+func NewMetadataPushOperator(conn connectionFetcher) *ansisql.MetadataPushOperator {
+	return ansisql.NewMetadataPushOperator(conn, MetadataQueries)
+}
+
+// MetadataQueries returns the queries that set the description, owner and tags of the table, and the descriptions,
+// classifications and tags of its columns as extended properties, the descriptions are stored as `MS_Description`.
+func MetadataQueries(asset *pipeline.Asset, _ map[string]*pipeline.ClassificationPolicy) ([]string, error) {
+	schemaName, tableName, err := splitTableName(asset.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	objectType := "TABLE"
+	if asset.Materialization.Type == pipeline.MaterializationTypeView {
+		objectType = "VIEW"
+	}
+
+	target := extendedPropertyTarget{schema: schemaName, objectType: objectType, object: tableName}
+	queries := make([]string, 0)
+	if asset.Description != "" {
+		queries = append(queries, target.setProperty("MS_Description", asset.Description))
+	}
+	if asset.Owner != "" {
+		queries = append(queries, target.setProperty("owner", asset.Owner))
+	}
+	if len(asset.Tags) > 0 {
+		queries = append(queries, target.setProperty("tags", strings.Join(asset.Tags, ", ")))
+	}
+
+	for _, col := range asset.Columns {
+		colTarget := target
+		colTarget.column = col.Name
+
+		if col.Description != "" {
+			queries = append(queries, colTarget.setProperty("MS_Description", col.Description))
+		}
+		if col.Classification != "" {
+			queries = append(queries, colTarget.setProperty("classification", col.Classification))
+		}
+		if len(col.Tags) > 0 {
+			queries = append(queries, colTarget.setProperty("tags", strings.Join(col.Tags, ", ")))
+		}
+	}
+
+	return queries, nil
+}
+
+type extendedPropertyTarget struct {
+	schema     string
+	objectType string
+	object     string
+	column     string
+}
+
+// setProperty returns the query that updates the extended property if it exists on the target, or adds it otherwise.
+func (t extendedPropertyTarget) setProperty(name, value string) string {
+	objectID := fmt.Sprintf("OBJECT_ID(N'%s.%s')", ansisql.EscapeSQLString(t.schema), ansisql.EscapeSQLString(t.object))
+	minorID := "0"
+	levels := fmt.Sprintf("@level0type = N'SCHEMA', @level0name = N'%s', @level1type = N'%s', @level1name = N'%s'",
+		ansisql.EscapeSQLString(t.schema), t.objectType, ansisql.EscapeSQLString(t.object))
+	if t.column != "" {
+		minorID = fmt.Sprintf("COLUMNPROPERTY(%s, N'%s', 'ColumnId')", objectID, ansisql.EscapeSQLString(t.column))
+		levels += fmt.Sprintf(", @level2type = N'COLUMN', @level2name = N'%s'", ansisql.EscapeSQLString(t.column))
+	}
+
+	args := fmt.Sprintf("@name = N'%s', @value = N'%s', %s", name, ansisql.EscapeSQLString(value), levels)
+
+	return fmt.Sprintf(`IF EXISTS (SELECT 1 FROM sys.extended_properties WHERE class = 1 AND major_id = %s AND minor_id = %s AND name = N'%s')
+    EXEC sp_updateextendedproperty %s
+ELSE
+    EXEC sp_addextendedproperty %s`, objectID, minorID, name, args, args)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type mockExtractor struct {
@@ -20,6 +21,10 @@ func (m *mockExtractor) ExtractQueriesFromString(content string) ([]*query.Query
 	return res.Get(0).([]*query.Query), res.Error(1)
 }
 
+func (m *mockExtractor) CloneForAsset(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) query.QueryExtractor {
+	return m
+}
+
 type mockMaterializer struct {
 	mock.Mock
 }
@@ -214,3 +219,88 @@ func TestBasicOperator_RunTask(t *testing.T) {
 		})
 	}
 }
+
+func TestMetadataQueries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		asset   *pipeline.Asset
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "assets without metadata are skipped",
+			asset: &pipeline.Asset{
+				Name:    "customers",
+				Columns: []pipeline.Column{{Name: "id"}},
+			},
+			want: []string{},
+		},
+		{
+			name: "descriptions, owner, classifications and tags",
+			asset: &pipeline.Asset{
+				Name:        "customers",
+				Description: "the customer's details",
+				Owner:       "data-team",
+				Tags:        []string{"core", "crm"},
+				Columns: []pipeline.Column{
+					{Name: "email", Classification: "pii"},
+				},
+			},
+			want: []string{
+				`IF EXISTS (SELECT 1 FROM sys.extended_properties WHERE class = 1 AND major_id = OBJECT_ID(N'dbo.customers') AND minor_id = 0 AND name = N'MS_Description')
+    EXEC sp_updateextendedproperty @name = N'MS_Description', @value = N'the customer''s details', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'customers'
+ELSE
+    EXEC sp_addextendedproperty @name = N'MS_Description', @value = N'the customer''s details', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'customers'`,
+				`IF EXISTS (SELECT 1 FROM sys.extended_properties WHERE class = 1 AND major_id = OBJECT_ID(N'dbo.customers') AND minor_id = 0 AND name = N'owner')
+    EXEC sp_updateextendedproperty @name = N'owner', @value = N'data-team', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'customers'
+ELSE
+    EXEC sp_addextendedproperty @name = N'owner', @value = N'data-team', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'customers'`,
+				`IF EXISTS (SELECT 1 FROM sys.extended_properties WHERE class = 1 AND major_id = OBJECT_ID(N'dbo.customers') AND minor_id = 0 AND name = N'tags')
+    EXEC sp_updateextendedproperty @name = N'tags', @value = N'core, crm', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'customers'
+ELSE
+    EXEC sp_addextendedproperty @name = N'tags', @value = N'core, crm', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'customers'`,
+				`IF EXISTS (SELECT 1 FROM sys.extended_properties WHERE class = 1 AND major_id = OBJECT_ID(N'dbo.customers') AND minor_id = COLUMNPROPERTY(OBJECT_ID(N'dbo.customers'), N'email', 'ColumnId') AND name = N'classification')
+    EXEC sp_updateextendedproperty @name = N'classification', @value = N'pii', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'customers', @level2type = N'COLUMN', @level2name = N'email'
+ELSE
+    EXEC sp_addextendedproperty @name = N'classification', @value = N'pii', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'customers', @level2type = N'COLUMN', @level2name = N'email'`,
+			},
+		},
+		{
+			name: "views are described as views",
+			asset: &pipeline.Asset{
+				Name:            "sales.active_customers",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeView},
+				Columns: []pipeline.Column{
+					{Name: "id", Description: "the id"},
+				},
+			},
+			want: []string{
+				`IF EXISTS (SELECT 1 FROM sys.extended_properties WHERE class = 1 AND major_id = OBJECT_ID(N'sales.active_customers') AND minor_id = COLUMNPROPERTY(OBJECT_ID(N'sales.active_customers'), N'id', 'ColumnId') AND name = N'MS_Description')
+    EXEC sp_updateextendedproperty @name = N'MS_Description', @value = N'the id', @level0type = N'SCHEMA', @level0name = N'sales', @level1type = N'VIEW', @level1name = N'active_customers', @level2type = N'COLUMN', @level2name = N'id'
+ELSE
+    EXEC sp_addextendedproperty @name = N'MS_Description', @value = N'the id', @level0type = N'SCHEMA', @level0name = N'sales', @level1type = N'VIEW', @level1name = N'active_customers', @level2type = N'COLUMN', @level2name = N'id'`,
+			},
+		},
+		{
+			name:    "invalid table name",
+			asset:   &pipeline.Asset{Name: "a.b.c"},
+			wantErr: "table name must be in table or schema.table format, 'a.b.c' given",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := MetadataQueries(tt.asset, nil)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
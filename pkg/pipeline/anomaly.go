@@ -0,0 +1,85 @@
+package pipeline
+
+import (
+	"strings"
+)
+
+const (
+	AnomalyMetricRowCount  = "row_count"
+	AnomalyMetricSum       = "sum"
+	AnomalyMetricAvg       = "avg"
+	AnomalyMetricNullRatio = "null_ratio"
+
+	AnomalySeverityError   = "error"
+	AnomalySeverityWarning = "warning"
+
+	DefaultAnomalyWindow       = 14
+	DefaultAnomalyMinHistory   = 3
+	DefaultAnomalyRecoverAfter = 3
+)
+
+var AnomalyMetrics = []string{AnomalyMetricRowCount, AnomalyMetricSum, AnomalyMetricAvg, AnomalyMetricNullRatio}
+
+// AnomalyCheck records a metric of the asset on every run, and compares the current value with the values of the
+// previous runs: the check fails, or warns if its severity is `warning`, once the value is more than `max_stddev`
+// standard deviations away from the mean of the trailing window, or once it changed more than `max_change_percent`
+// percent compared to the previous value.
+type AnomalyCheck struct {
+	Name             string  `json:"name" yaml:"name,omitempty" mapstructure:"name"`
+	Metric           string  `json:"metric" yaml:"metric" mapstructure:"metric"`
+	Column           string  `json:"column" yaml:"column,omitempty" mapstructure:"column"`
+	Window           int     `json:"window" yaml:"window,omitempty" mapstructure:"window"`
+	MinHistory       int     `json:"min_history" yaml:"min_history,omitempty" mapstructure:"min_history"`
+	RecoverAfter     int     `json:"recover_after" yaml:"recover_after,omitempty" mapstructure:"recover_after"`
+	MaxStddev        float64 `json:"max_stddev" yaml:"max_stddev,omitempty" mapstructure:"max_stddev"`
+	MaxChangePercent float64 `json:"max_change_percent" yaml:"max_change_percent,omitempty" mapstructure:"max_change_percent"`
+	Severity         string  `json:"severity" yaml:"severity,omitempty" mapstructure:"severity"`
+}
+
+// AnomalyCheckName returns the name used for anomaly checks that are not named explicitly, e.g. `row_count` or
+// `sum_amount`.
+func AnomalyCheckName(metric, column string) string {
+	if column == "" {
+		return metric
+	}
+
+	return metric + "_" + column
+}
+
+// MetricNeedsColumn reports whether the metric of the check is computed over a column rather than the whole asset.
+func (c *AnomalyCheck) MetricNeedsColumn() bool {
+	return c.Metric != AnomalyMetricRowCount
+}
+
+// WindowSize returns the number of previous values the current value is compared with.
+func (c *AnomalyCheck) WindowSize() int {
+	if c.Window <= 0 {
+		return DefaultAnomalyWindow
+	}
+
+	return c.Window
+}
+
+// MinHistorySize returns the number of previous values required before the check starts flagging anomalies.
+func (c *AnomalyCheck) MinHistorySize() int {
+	if c.MinHistory <= 0 {
+		return DefaultAnomalyMinHistory
+	}
+
+	return c.MinHistory
+}
+
+// RecoverAfterSize returns the number of consecutive anomalous values after which they are accepted as the new level
+// of the metric.
+func (c *AnomalyCheck) RecoverAfterSize() int {
+	if c.RecoverAfter <= 0 {
+		return DefaultAnomalyRecoverAfter
+	}
+
+	return c.RecoverAfter
+}
+
+// IsWarning reports whether the anomalies found by the check are only reported as warnings instead of failing it.
+func (c *AnomalyCheck) IsWarning() bool {
+	return strings.EqualFold(c.Severity, AnomalySeverityWarning)
+}
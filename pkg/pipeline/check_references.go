@@ -0,0 +1,111 @@
+package pipeline
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	ColumnCheckRelationships        = "relationships"
+	ColumnCheckReferences           = "references"
+	ColumnCheckMatchesUpstreamCount = "matches_upstream_count"
+)
+
+// IsCrossAssetColumnCheck returns true for the column checks that compare the asset with another asset.
+func IsCrossAssetColumnCheck(name string) bool {
+	switch name {
+	case ColumnCheckRelationships, ColumnCheckReferences, ColumnCheckMatchesUpstreamCount:
+		return true
+	default:
+		return false
+	}
+}
+
+// ColumnCheckReference returns the name of the asset, and the column for the `relationships` and `references`
+// checks, that a cross-asset column check refers to.
+//
+// The value of the `relationships` and `references` checks is either an asset name, in which case the column with
+// the same name is used, or an asset name followed by the column name, e.g. `raw.customers.id`. The value of the
+// `matches_upstream_count` check is the name of the asset to compare with, and it can be omitted if the asset has a
+// single upstream asset.
+func (p *Pipeline) ColumnCheckReference(asset *Asset, column *Column, check *ColumnCheck) (string, string, error) {
+	value := ""
+	if check.Value.String != nil {
+		value = strings.TrimSpace(*check.Value.String)
+	}
+
+	switch check.Name {
+	case ColumnCheckRelationships, ColumnCheckReferences:
+		if value == "" {
+			return "", "", errors.New("the referenced asset must be given as the value, e.g. 'raw.customers.id'")
+		}
+
+		if p.GetAssetByName(value) != nil {
+			return value, column.Name, nil
+		}
+
+		if i := strings.LastIndex(value, "."); i > 0 && i < len(value)-1 && p.GetAssetByName(value[:i]) != nil {
+			return value[:i], value[i+1:], nil
+		}
+
+		return "", "", fmt.Errorf("'%s' does not refer to an asset in the pipeline", value)
+
+	case ColumnCheckMatchesUpstreamCount:
+		if value != "" {
+			if p.GetAssetByName(value) == nil {
+				return "", "", fmt.Errorf("'%s' does not refer to an asset in the pipeline", value)
+			}
+
+			return value, "", nil
+		}
+
+		upstreams := make([]string, 0, len(asset.Upstreams))
+		for _, upstream := range asset.Upstreams {
+			if upstream.Type == "asset" {
+				upstreams = append(upstreams, upstream.Value)
+			}
+		}
+
+		if len(upstreams) != 1 {
+			return "", "", fmt.Errorf("the upstream asset to compare with must be given as the value since the asset has %d upstream assets", len(upstreams))
+		}
+
+		return upstreams[0], "", nil
+
+	default:
+		return "", "", fmt.Errorf("the `%s` check does not refer to another asset", check.Name)
+	}
+}
+
+// addCheckUpstreams adds the assets referenced by the cross-asset column checks as upstreams of the asset, so that
+// they are built before the checks run and they show up in the lineage. References that cannot be resolved are left
+// to the linter.
+func (a *Asset) addCheckUpstreams(p *Pipeline) {
+	for i := range a.Columns {
+		column := &a.Columns[i]
+		for j := range column.Checks {
+			check := &column.Checks[j]
+			if !IsCrossAssetColumnCheck(check.Name) {
+				continue
+			}
+
+			ref, _, err := p.ColumnCheckReference(a, column, check)
+			if err != nil || ref == a.Name {
+				continue
+			}
+
+			alreadyDeclared := false
+			for _, upstream := range a.Upstreams {
+				if upstream.Type == "asset" && upstream.Value == ref {
+					alreadyDeclared = true
+					break
+				}
+			}
+
+			if !alreadyDeclared {
+				a.Upstreams = append(a.Upstreams, Upstream{Type: "asset", Value: ref, Columns: make([]DependsColumn, 0)})
+			}
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeline_ColumnCheckReference(t *testing.T) {
+	t.Parallel()
+
+	str := func(s string) ColumnCheckValue {
+		return ColumnCheckValue{String: &s}
+	}
+
+	customers := &Asset{Name: "raw.customers"}
+	orders := &Asset{Name: "raw.orders"}
+	report := &Asset{
+		Name: "reports.orders",
+		Upstreams: []Upstream{
+			{Type: "asset", Value: "raw.orders"},
+			{Type: "uri", Value: "s3://bucket/orders"},
+		},
+	}
+	joined := &Asset{
+		Name: "reports.joined",
+		Upstreams: []Upstream{
+			{Type: "asset", Value: "raw.orders"},
+			{Type: "asset", Value: "raw.customers"},
+		},
+	}
+
+	p := &Pipeline{Assets: []*Asset{customers, orders, report, joined}}
+	column := &Column{Name: "customer_id"}
+
+	tests := []struct {
+		name       string
+		asset      *Asset
+		check      *ColumnCheck
+		wantAsset  string
+		wantColumn string
+		wantErr    string
+	}{
+		{
+			name:       "relationships with an asset and a column",
+			asset:      orders,
+			check:      &ColumnCheck{Name: ColumnCheckRelationships, Value: str("raw.customers.id")},
+			wantAsset:  "raw.customers",
+			wantColumn: "id",
+		},
+		{
+			name:       "references with only an asset uses the same column name",
+			asset:      orders,
+			check:      &ColumnCheck{Name: ColumnCheckReferences, Value: str(" raw.customers ")},
+			wantAsset:  "raw.customers",
+			wantColumn: "customer_id",
+		},
+		{
+			name:    "relationships without a value",
+			asset:   orders,
+			check:   &ColumnCheck{Name: ColumnCheckRelationships},
+			wantErr: "the referenced asset must be given as the value, e.g. 'raw.customers.id'",
+		},
+		{
+			name:    "relationships with an unknown asset",
+			asset:   orders,
+			check:   &ColumnCheck{Name: ColumnCheckRelationships, Value: str("raw.users.id")},
+			wantErr: "'raw.users.id' does not refer to an asset in the pipeline",
+		},
+		{
+			name:      "matches_upstream_count with an explicit asset",
+			asset:     joined,
+			check:     &ColumnCheck{Name: ColumnCheckMatchesUpstreamCount, Value: str("raw.orders")},
+			wantAsset: "raw.orders",
+		},
+		{
+			name:      "matches_upstream_count defaults to the single upstream asset",
+			asset:     report,
+			check:     &ColumnCheck{Name: ColumnCheckMatchesUpstreamCount},
+			wantAsset: "raw.orders",
+		},
+		{
+			name:    "matches_upstream_count with multiple upstream assets",
+			asset:   joined,
+			check:   &ColumnCheck{Name: ColumnCheckMatchesUpstreamCount},
+			wantErr: "the upstream asset to compare with must be given as the value since the asset has 2 upstream assets",
+		},
+		{
+			name:    "matches_upstream_count with an unknown asset",
+			asset:   report,
+			check:   &ColumnCheck{Name: ColumnCheckMatchesUpstreamCount, Value: str("raw.users")},
+			wantErr: "'raw.users' does not refer to an asset in the pipeline",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotAsset, gotColumn, err := p.ColumnCheckReference(tt.asset, column, tt.check)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAsset, gotAsset)
+			assert.Equal(t, tt.wantColumn, gotColumn)
+		})
+	}
+}
+
+func TestAsset_addCheckUpstreams(t *testing.T) {
+	t.Parallel()
+
+	customersRef := "raw.customers.id"
+	countRef := "raw.orders"
+	unknownRef := "raw.unknown.id"
+
+	customers := &Asset{Name: "raw.customers"}
+	orders := &Asset{Name: "raw.orders"}
+	asset := &Asset{
+		Name: "reports.orders",
+		Upstreams: []Upstream{
+			{Type: "asset", Value: "raw.orders", Columns: []DependsColumn{{Name: "id"}}},
+		},
+		Columns: []Column{
+			{
+				Name: "customer_id",
+				Checks: []ColumnCheck{
+					{Name: "not_null"},
+					{Name: ColumnCheckRelationships, Value: ColumnCheckValue{String: &customersRef}},
+					{Name: ColumnCheckReferences, Value: ColumnCheckValue{String: &unknownRef}},
+				},
+			},
+			{
+				Name:   "id",
+				Checks: []ColumnCheck{{Name: ColumnCheckMatchesUpstreamCount, Value: ColumnCheckValue{String: &countRef}}},
+			},
+		},
+	}
+
+	p := &Pipeline{Assets: []*Asset{customers, orders, asset}}
+	asset.addCheckUpstreams(p)
+
+	assert.Equal(t, []Upstream{
+		{Type: "asset", Value: "raw.orders", Columns: []DependsColumn{{Name: "id"}}},
+		{Type: "asset", Value: "raw.customers", Columns: []DependsColumn{}},
+	}, asset.Upstreams)
+}
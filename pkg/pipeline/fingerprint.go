@@ -0,0 +1,92 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/jinja"
+)
+
+// FingerprintInputs are the run parameters that are a part of every asset fingerprint, since the same code produces
+// different data for a different interval or environment.
+type FingerprintInputs struct {
+	StartDate   time.Time
+	EndDate     time.Time
+	Environment string
+}
+
+// Fingerprints returns a fingerprint for every asset in the pipeline that changes whenever the definition of the
+// asset, its rendered query, the fingerprint of any of its upstream assets, the pipeline variables or the run
+// parameters change. The assets whose query cannot be rendered, and their downstream, do not get a fingerprint.
+func Fingerprints(p *Pipeline, renderer jinja.RendererInterface, inputs FingerprintInputs) map[string]string {
+	variables, _ := json.Marshal(p.Variables.Value())
+	common := []string{
+		"start:" + inputs.StartDate.Format(time.RFC3339Nano),
+		"end:" + inputs.EndDate.Format(time.RFC3339Nano),
+		"environment:" + inputs.Environment,
+		"variables:" + string(variables),
+	}
+
+	f := &fingerprinter{
+		pipeline:     p,
+		renderer:     renderer,
+		common:       common,
+		fingerprints: make(map[string]string, len(p.Assets)),
+		visited:      make(map[string]bool, len(p.Assets)),
+	}
+
+	for _, asset := range p.Assets {
+		f.fingerprint(asset)
+	}
+
+	return f.fingerprints
+}
+
+type fingerprinter struct {
+	pipeline     *Pipeline
+	renderer     jinja.RendererInterface
+	common       []string
+	fingerprints map[string]string
+	visited      map[string]bool
+}
+
+func (f *fingerprinter) fingerprint(asset *Asset) (string, bool) {
+	if f.visited[asset.Name] {
+		fingerprint, ok := f.fingerprints[asset.Name]
+		return fingerprint, ok
+	}
+	f.visited[asset.Name] = true
+
+	definition, err := json.Marshal(asset)
+	if err != nil {
+		return "", false
+	}
+
+	parts := append([]string{"definition:" + hash(string(definition))}, f.common...)
+	if asset.IsSQLAsset() {
+		rendered, err := f.renderer.WithContext(AssetRenderContext(context.Background(), f.pipeline, asset)).Render(asset.ExecutableFile.Content)
+		if err != nil {
+			return "", false
+		}
+		parts = append(parts, "query:"+hash(rendered))
+	}
+
+	upstreams := make([]string, 0, len(asset.GetUpstream()))
+	for _, upstream := range asset.GetUpstream() {
+		upstreamFingerprint, ok := f.fingerprint(upstream)
+		if !ok {
+			return "", false
+		}
+		upstreams = append(upstreams, fmt.Sprintf("upstream:%s=%s", upstream.Name, upstreamFingerprint))
+	}
+	sort.Strings(upstreams)
+	parts = append(parts, upstreams...)
+
+	fingerprint := hash(strings.Join(parts, "\n"))
+	f.fingerprints[asset.Name] = fingerprint
+	return fingerprint, true
+}
@@ -0,0 +1,83 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/jinja"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprints(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 1, 23, 59, 59, 0, time.UTC)
+	inputs := FingerprintInputs{StartDate: start, EndDate: end, Environment: "dev"}
+
+	newPipeline := func(rawQuery string) *Pipeline {
+		raw := &Asset{
+			Name:           "raw.orders",
+			Type:           AssetTypeBigqueryQuery,
+			ExecutableFile: ExecutableFile{Content: rawQuery},
+		}
+		report := &Asset{
+			Name:           "reports.revenue",
+			Type:           AssetTypeBigqueryQuery,
+			ExecutableFile: ExecutableFile{Content: "SELECT sum(amount) FROM {{ ref('raw.orders') }}"},
+			Upstreams:      []Upstream{{Type: "asset", Value: "raw.orders"}},
+		}
+		users := &Asset{
+			Name:           "raw.users",
+			Type:           AssetTypePython,
+			ExecutableFile: ExecutableFile{Content: "print('users')"},
+		}
+
+		report.AddUpstream(raw)
+		raw.AddDownstream(report)
+
+		return &Pipeline{Name: "test", Assets: []*Asset{report, raw, users}}
+	}
+	newRenderer := func(start, end time.Time) *jinja.Renderer {
+		return jinja.NewRendererWithStartEndDates(&start, &end, "test", "run-id")
+	}
+
+	base := Fingerprints(newPipeline("SELECT * FROM orders WHERE dt = '{{ start_date }}'"), newRenderer(start, end), inputs)
+	assert.Len(t, base, 3)
+
+	t.Run("fingerprints are stable", func(t *testing.T) {
+		t.Parallel()
+
+		got := Fingerprints(newPipeline("SELECT * FROM orders WHERE dt = '{{ start_date }}'"), newRenderer(start, end), inputs)
+		assert.Equal(t, base, got)
+	})
+
+	t.Run("changing an asset changes its downstream", func(t *testing.T) {
+		t.Parallel()
+
+		got := Fingerprints(newPipeline("SELECT id, amount FROM orders WHERE dt = '{{ start_date }}'"), newRenderer(start, end), inputs)
+		assert.NotEqual(t, base["raw.orders"], got["raw.orders"])
+		assert.NotEqual(t, base["reports.revenue"], got["reports.revenue"])
+		assert.Equal(t, base["raw.users"], got["raw.users"])
+	})
+
+	t.Run("changing the interval changes every asset", func(t *testing.T) {
+		t.Parallel()
+
+		nextStart, nextEnd := start.AddDate(0, 0, 1), end.AddDate(0, 0, 1)
+		got := Fingerprints(newPipeline("SELECT * FROM orders WHERE dt = '{{ start_date }}'"), newRenderer(nextStart, nextEnd),
+			FingerprintInputs{StartDate: nextStart, EndDate: nextEnd, Environment: "dev"})
+		for name, fingerprint := range base {
+			assert.NotEqual(t, fingerprint, got[name], name)
+		}
+	})
+
+	t.Run("assets that cannot be rendered have no fingerprint", func(t *testing.T) {
+		t.Parallel()
+
+		got := Fingerprints(newPipeline("SELECT * FROM {{ ref('missing.asset') }}"), newRenderer(start, end), inputs)
+		assert.NotContains(t, got, "raw.orders")
+		assert.NotContains(t, got, "reports.revenue")
+		assert.Equal(t, base["raw.users"], got["raw.users"])
+	})
+}
@@ -0,0 +1,83 @@
+package pipeline
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	FreshnessStatusFresh = "fresh"
+	FreshnessStatusWarn  = "warn"
+	FreshnessStatusError = "error"
+)
+
+// FreshnessCheck asserts that the latest value of a timestamp column of the asset is recent enough. The asset is
+// reported with a warning once the latest value is older than `warn_after`, and it fails once it is older than
+// `error_after`.
+type FreshnessCheck struct {
+	Column     string `json:"column" yaml:"column" mapstructure:"column"`
+	WarnAfter  string `json:"warn_after" yaml:"warn_after,omitempty" mapstructure:"warn_after"`
+	ErrorAfter string `json:"error_after" yaml:"error_after,omitempty" mapstructure:"error_after"`
+}
+
+// Thresholds returns the parsed `warn_after` and `error_after` durations, a threshold that is not set is returned
+// as zero.
+func (f *FreshnessCheck) Thresholds() (time.Duration, time.Duration, error) {
+	warnAfter, err := ParseFreshnessDuration(f.WarnAfter)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "invalid `warn_after` value")
+	}
+
+	errorAfter, err := ParseFreshnessDuration(f.ErrorAfter)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "invalid `error_after` value")
+	}
+
+	return warnAfter, errorAfter, nil
+}
+
+// Status returns the freshness status of an asset whose latest value is as old as the given age.
+func (f *FreshnessCheck) Status(age time.Duration) (string, error) {
+	warnAfter, errorAfter, err := f.Thresholds()
+	if err != nil {
+		return "", err
+	}
+
+	if errorAfter > 0 && age > errorAfter {
+		return FreshnessStatusError, nil
+	}
+
+	if warnAfter > 0 && age > warnAfter {
+		return FreshnessStatusWarn, nil
+	}
+
+	return FreshnessStatusFresh, nil
+}
+
+// ParseFreshnessDuration parses the durations used in freshness checks, which are either Go durations such as `90m`
+// or `36h`, or a number of days such as `2d`. An empty value is returned as zero.
+func ParseFreshnessDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		count, err := strconv.ParseFloat(days, 64)
+		if err != nil || count < 0 {
+			return 0, errors.Errorf("'%s' is not a valid duration, use values such as '30m', '12h' or '2d'", value)
+		}
+
+		return time.Duration(count * float64(24*time.Hour)), nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, errors.Errorf("'%s' is not a valid duration, use values such as '30m', '12h' or '2d'", value)
+	}
+
+	return duration, nil
+}
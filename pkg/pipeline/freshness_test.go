@@ -0,0 +1,98 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFreshnessDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "", want: 0},
+		{value: "90m", want: 90 * time.Minute},
+		{value: " 12h ", want: 12 * time.Hour},
+		{value: "2d", want: 48 * time.Hour},
+		{value: "1.5d", want: 36 * time.Hour},
+		{value: "d", wantErr: true},
+		{value: "-1h", wantErr: true},
+		{value: "tomorrow", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseFreshnessDuration(tt.value)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFreshnessCheck_Status(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		check   FreshnessCheck
+		age     time.Duration
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "within both thresholds",
+			check: FreshnessCheck{WarnAfter: "12h", ErrorAfter: "1d"},
+			age:   time.Hour,
+			want:  FreshnessStatusFresh,
+		},
+		{
+			name:  "older than warn_after",
+			check: FreshnessCheck{WarnAfter: "12h", ErrorAfter: "1d"},
+			age:   13 * time.Hour,
+			want:  FreshnessStatusWarn,
+		},
+		{
+			name:  "older than error_after",
+			check: FreshnessCheck{WarnAfter: "12h", ErrorAfter: "1d"},
+			age:   25 * time.Hour,
+			want:  FreshnessStatusError,
+		},
+		{
+			name:  "only error_after is set",
+			check: FreshnessCheck{ErrorAfter: "1d"},
+			age:   13 * time.Hour,
+			want:  FreshnessStatusFresh,
+		},
+		{
+			name:    "invalid threshold",
+			check:   FreshnessCheck{WarnAfter: "soon"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.check.Status(tt.age)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package pipeline
+
+import (
+	"path/filepath"
+)
+
+const (
+	ImpactReasonChanged    = "changed"
+	ImpactReasonDownstream = "downstream"
+)
+
+// ImpactedAsset is an asset that is affected by a set of changed files, either because its own files changed or
+// because one of its upstream assets did.
+type ImpactedAsset struct {
+	Asset  *Asset `json:"-"`
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// ImpactedAssets returns the assets whose definition or executable file is among the given absolute paths, followed
+// by all of their downstream assets. A change in the pipeline definition impacts every asset in the pipeline.
+func (p *Pipeline) ImpactedAssets(changedFiles []string) []*ImpactedAsset {
+	changed := make(map[string]bool, len(changedFiles))
+	for _, file := range changedFiles {
+		changed[filepath.Clean(file)] = true
+	}
+
+	pipelineChanged := p.DefinitionFile.Path != "" && changed[filepath.Clean(p.DefinitionFile.Path)]
+
+	reasons := make(map[*Asset]string)
+	for _, asset := range p.Assets {
+		if !pipelineChanged && !asset.isAnyFileIn(changed) {
+			continue
+		}
+
+		reasons[asset] = ImpactReasonChanged
+	}
+
+	for _, asset := range p.Assets {
+		if reasons[asset] != ImpactReasonChanged {
+			continue
+		}
+
+		for _, downstream := range asset.GetFullDownstream() {
+			if _, ok := reasons[downstream]; !ok {
+				reasons[downstream] = ImpactReasonDownstream
+			}
+		}
+	}
+
+	impacted := make([]*ImpactedAsset, 0, len(reasons))
+	for _, asset := range p.Assets {
+		reason, ok := reasons[asset]
+		if !ok {
+			continue
+		}
+
+		impacted = append(impacted, &ImpactedAsset{
+			Asset:  asset,
+			Name:   asset.Name,
+			Path:   p.RelativeAssetPath(asset),
+			Reason: reason,
+		})
+	}
+
+	return impacted
+}
+
+func (a *Asset) isAnyFileIn(files map[string]bool) bool {
+	for _, path := range []string{a.DefinitionFile.Path, a.ExecutableFile.Path} {
+		if path != "" && files[filepath.Clean(path)] {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,98 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeline_ImpactedAssets(t *testing.T) {
+	t.Parallel()
+
+	newPipeline := func() *Pipeline {
+		raw := &Asset{
+			Name:           "raw.orders",
+			DefinitionFile: TaskDefinitionFile{Path: "/repo/pipeline/assets/raw_orders.asset.yml"},
+			ExecutableFile: ExecutableFile{Path: "/repo/pipeline/assets/raw_orders.py"},
+		}
+		staging := &Asset{
+			Name:           "staging.orders",
+			DefinitionFile: TaskDefinitionFile{Path: "/repo/pipeline/assets/staging_orders.sql"},
+			ExecutableFile: ExecutableFile{Path: "/repo/pipeline/assets/staging_orders.sql"},
+		}
+		report := &Asset{
+			Name:           "reports.revenue",
+			DefinitionFile: TaskDefinitionFile{Path: "/repo/pipeline/assets/revenue.sql"},
+			ExecutableFile: ExecutableFile{Path: "/repo/pipeline/assets/revenue.sql"},
+		}
+		users := &Asset{
+			Name:           "raw.users",
+			DefinitionFile: TaskDefinitionFile{Path: "/repo/pipeline/assets/users.sql"},
+			ExecutableFile: ExecutableFile{Path: "/repo/pipeline/assets/users.sql"},
+		}
+
+		staging.AddUpstream(raw)
+		raw.AddDownstream(staging)
+		report.AddUpstream(staging)
+		staging.AddDownstream(report)
+
+		return &Pipeline{
+			DefinitionFile: DefinitionFile{Path: "/repo/pipeline/pipeline.yml"},
+			Assets:         []*Asset{report, users, staging, raw},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		changedFiles []string
+		want         map[string]string
+	}{
+		{
+			name:         "nothing changed",
+			changedFiles: []string{"/repo/README.md"},
+			want:         map[string]string{},
+		},
+		{
+			name:         "changed executable file impacts the asset and its full downstream",
+			changedFiles: []string{"/repo/pipeline/assets/raw_orders.py"},
+			want: map[string]string{
+				"raw.orders":      ImpactReasonChanged,
+				"staging.orders":  ImpactReasonDownstream,
+				"reports.revenue": ImpactReasonDownstream,
+			},
+		},
+		{
+			name:         "changed asset is not reported as a downstream",
+			changedFiles: []string{"/repo/pipeline/assets/revenue.sql", "/repo/pipeline/assets/raw_orders.asset.yml"},
+			want: map[string]string{
+				"raw.orders":      ImpactReasonChanged,
+				"staging.orders":  ImpactReasonDownstream,
+				"reports.revenue": ImpactReasonChanged,
+			},
+		},
+		{
+			name:         "changed pipeline definition impacts every asset",
+			changedFiles: []string{"/repo/pipeline/pipeline.yml"},
+			want: map[string]string{
+				"raw.orders":      ImpactReasonChanged,
+				"raw.users":       ImpactReasonChanged,
+				"staging.orders":  ImpactReasonChanged,
+				"reports.revenue": ImpactReasonChanged,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := newPipeline()
+			got := make(map[string]string)
+			for _, impacted := range p.ImpactedAssets(tt.changedFiles) {
+				assert.Equal(t, impacted.Name, impacted.Asset.Name)
+				got[impacted.Name] = impacted.Reason
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package pipeline
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -14,9 +15,16 @@ type sqlParser interface {
 	ColumnLineage(sql, dialect string, schema sqlparser.Schema) (*sqlparser.Lineage, error)
 }
 
+// SourceSchemaFetcher returns the columns of a table in the given connection, it is used to find the columns of the
+// ingestr assets that do not declare them.
+type SourceSchemaFetcher interface {
+	GetSourceColumns(ctx context.Context, connectionName, tableName string) ([]Column, error)
+}
+
 type LineageExtractor struct {
-	sqlParser sqlParser
-	renderer  *jinja.Renderer
+	sqlParser           sqlParser
+	renderer            *jinja.Renderer
+	sourceSchemaFetcher SourceSchemaFetcher
 }
 
 // NewLineageExtractor creates a new LineageExtractor instance.
@@ -27,6 +35,16 @@ func NewLineageExtractor(parser sqlParser) *LineageExtractor {
 	}
 }
 
+// WithSourceSchemaFetcher returns a copy of the extractor that introspects the source tables of the ingestr assets
+// without columns.
+func (p *LineageExtractor) WithSourceSchemaFetcher(fetcher SourceSchemaFetcher) *LineageExtractor {
+	return &LineageExtractor{
+		sqlParser:           p.sqlParser,
+		renderer:            p.renderer,
+		sourceSchemaFetcher: fetcher,
+	}
+}
+
 // TableSchema extracts the table schema from the assets and stores it in the columnMetadata map.
 func (p *LineageExtractor) TableSchema(foundPipeline *Pipeline) sqlparser.Schema {
 	columnMetadata := make(sqlparser.Schema)
@@ -73,11 +91,82 @@ func (p *LineageExtractor) ColumnLineage(foundPipeline *Pipeline, asset *Asset,
 		_ = p.ColumnLineage(foundPipeline, upstreamAsset, processedAssets)
 	}
 
-	_ = p.parseLineage(foundPipeline, asset, p.TableSchemaForUpstreams(foundPipeline, asset))
+	switch asset.Type {
+	case AssetTypeIngestr:
+		p.inheritIngestrColumns(asset)
+		p.propagateDeclaredLineage(foundPipeline, asset)
+	case AssetTypePython:
+		p.propagateDeclaredLineage(foundPipeline, asset)
+	default:
+		_ = p.parseLineage(foundPipeline, asset, p.TableSchemaForUpstreams(foundPipeline, asset))
+	}
 
 	return nil
 }
 
+// inheritIngestrColumns maps the columns of ingestr assets to the same columns of their source table. If the asset
+// does not declare any columns, they are introspected from the source table where possible.
+func (p *LineageExtractor) inheritIngestrColumns(asset *Asset) {
+	sourceTable := asset.Parameters["source_table"]
+	if sourceTable == "" {
+		return
+	}
+
+	if len(asset.Columns) == 0 && p.sourceSchemaFetcher != nil && asset.Parameters["source_connection"] != "" {
+		columns, err := p.sourceSchemaFetcher.GetSourceColumns(context.Background(), asset.Parameters["source_connection"], sourceTable)
+		if err == nil {
+			for _, column := range columns {
+				column.PrimaryKey = false
+				column.Checks = []ColumnCheck{}
+				column.Upstreams = []*UpstreamColumn{}
+				asset.Columns = append(asset.Columns, column)
+			}
+		}
+	}
+
+	for i := range asset.Columns {
+		if len(asset.Columns[i].Upstreams) > 0 {
+			continue
+		}
+
+		asset.Columns[i].Upstreams = []*UpstreamColumn{
+			{
+				Column: asset.Columns[i].Name,
+				Table:  sourceTable,
+			},
+		}
+	}
+}
+
+// propagateDeclaredLineage fills in the types and descriptions of the columns of non-SQL assets from the upstream
+// columns they declare, since there is no query to extract them from.
+func (p *LineageExtractor) propagateDeclaredLineage(foundPipeline *Pipeline, asset *Asset) {
+	for i := range asset.Columns {
+		column := &asset.Columns[i]
+		for _, upstream := range column.Upstreams {
+			upstreamAsset := foundPipeline.GetAssetByNameCaseInsensitive(upstream.Table)
+			if upstreamAsset == nil {
+				continue
+			}
+
+			upstreamColumn := upstreamAsset.GetColumnWithName(upstream.Column)
+			if upstreamColumn == nil {
+				continue
+			}
+
+			if column.Type == "" {
+				column.Type = upstreamColumn.Type
+			}
+			if column.Description == "" {
+				column.Description = upstreamColumn.Description
+			}
+			if column.EntityAttribute == nil {
+				column.EntityAttribute = upstreamColumn.EntityAttribute
+			}
+		}
+	}
+}
+
 // ParseLineage analyzes the column lineage for a given asset within a
 // It traces column relationships between the asset and its upstream dependencies.
 func (p *LineageExtractor) parseLineage(foundPipeline *Pipeline, asset *Asset, metadata sqlparser.Schema) error {
@@ -97,7 +186,7 @@ func (p *LineageExtractor) parseLineage(foundPipeline *Pipeline, asset *Asset, m
 		}
 	}
 
-	query, err := p.renderer.Render(asset.ExecutableFile.Content)
+	query, err := p.renderer.WithContext(AssetRenderContext(context.Background(), foundPipeline, asset)).Render(asset.ExecutableFile.Content)
 	if err != nil {
 		return fmt.Errorf("failed to render the query: %w", err)
 	}
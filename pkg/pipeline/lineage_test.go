@@ -1,6 +1,7 @@
 package pipeline
 
 import (
+	"context"
 	"log"
 	"os"
 	"testing"
@@ -1665,3 +1666,116 @@ func TestUpstreamExists(t *testing.T) {
 		})
 	}
 }
+
+type mockSourceSchemaFetcher struct {
+	columns map[string][]Column
+}
+
+func (m *mockSourceSchemaFetcher) GetSourceColumns(ctx context.Context, connectionName, tableName string) ([]Column, error) {
+	columns, ok := m.columns[connectionName+"."+tableName]
+	if !ok {
+		return nil, assert.AnError
+	}
+
+	return columns, nil
+}
+
+func TestColumnLineage_NonSQLAssets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		fetcher SourceSchemaFetcher
+		asset   *Asset
+		want    []Column
+	}{
+		{
+			name: "ingestr columns map to the same columns of the source table",
+			asset: &Asset{
+				Name:       "raw.users",
+				Type:       AssetTypeIngestr,
+				Parameters: map[string]string{"source_connection": "pg", "source_table": "public.users"},
+				Columns: []Column{
+					{Name: "id", Type: "integer"},
+					{Name: "full_name", Upstreams: []*UpstreamColumn{{Table: "public.users", Column: "name"}}},
+				},
+			},
+			want: []Column{
+				{Name: "id", Type: "integer", Upstreams: []*UpstreamColumn{{Table: "public.users", Column: "id"}}},
+				{Name: "full_name", Upstreams: []*UpstreamColumn{{Table: "public.users", Column: "name"}}},
+			},
+		},
+		{
+			name: "ingestr columns are introspected from the source table",
+			fetcher: &mockSourceSchemaFetcher{columns: map[string][]Column{
+				"pg.public.users": {{Name: "id", Type: "integer", PrimaryKey: true}, {Name: "email", Type: "text"}},
+			}},
+			asset: &Asset{
+				Name:       "raw.users",
+				Type:       AssetTypeIngestr,
+				Parameters: map[string]string{"source_connection": "pg", "source_table": "public.users"},
+			},
+			want: []Column{
+				{Name: "id", Type: "integer", Upstreams: []*UpstreamColumn{{Table: "public.users", Column: "id"}}},
+				{Name: "email", Type: "text", Upstreams: []*UpstreamColumn{{Table: "public.users", Column: "email"}}},
+			},
+		},
+		{
+			name:    "introspection failures are ignored",
+			fetcher: &mockSourceSchemaFetcher{},
+			asset: &Asset{
+				Name:       "raw.users",
+				Type:       AssetTypeIngestr,
+				Parameters: map[string]string{"source_connection": "pg", "source_table": "public.users"},
+			},
+			want: nil,
+		},
+		{
+			name: "python columns inherit the type and description of their declared upstreams",
+			asset: &Asset{
+				Name:      "analytics.users",
+				Type:      AssetTypePython,
+				Upstreams: []Upstream{{Type: "asset", Value: "raw.customers"}},
+				Columns: []Column{
+					{Name: "customer_id", Upstreams: []*UpstreamColumn{{Table: "RAW.CUSTOMERS", Column: "ID"}}},
+					{Name: "score", Type: "float", Description: "computed in python"},
+				},
+			},
+			want: []Column{
+				{Name: "customer_id", Type: "integer", Description: "the customer id", Upstreams: []*UpstreamColumn{{Table: "RAW.CUSTOMERS", Column: "ID"}}},
+				{Name: "score", Type: "float", Description: "computed in python"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &Pipeline{
+				Assets: []*Asset{
+					{
+						Name:    "raw.customers",
+						Type:    AssetTypePython,
+						Columns: []Column{{Name: "id", Type: "integer", Description: "the customer id"}},
+					},
+					tt.asset,
+				},
+			}
+
+			extractor := NewLineageExtractor(SQLParser).WithSourceSchemaFetcher(tt.fetcher)
+			err := extractor.ColumnLineage(p, tt.asset, make(map[string]bool))
+			assert.NoError(t, err)
+
+			assert.Len(t, tt.asset.Columns, len(tt.want))
+			for i, want := range tt.want {
+				got := tt.asset.Columns[i]
+				assert.Equal(t, want.Name, got.Name)
+				assert.Equal(t, want.Type, got.Type)
+				assert.Equal(t, want.Description, got.Description)
+				assert.False(t, got.PrimaryKey)
+				assert.Equal(t, want.Upstreams, got.Upstreams)
+			}
+		})
+	}
+}
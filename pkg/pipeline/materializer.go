@@ -26,6 +26,11 @@ func (m *Materializer) Render(asset *Asset, query string) (string, error) {
 		strategy = MaterializationStrategyCreateReplace
 	}
 
+	// the contracts are only applied when the table is created, the other strategies would skip them silently
+	if asset.IsContractEnforced() && mat.Type == MaterializationTypeTable && !IsContractSupportedForStrategy(strategy) {
+		return "", fmt.Errorf("enforced contracts are only supported with the `%s` strategy, `%s` given", MaterializationStrategyCreateReplace, strategy)
+	}
+
 	if matFunc, ok := m.MaterializationMap[mat.Type][strategy]; ok {
 		materializedQuery, err := matFunc(asset, query)
 		if err != nil {
@@ -38,6 +43,12 @@ func (m *Materializer) Render(asset *Asset, query string) (string, error) {
 	return "", fmt.Errorf("unsupported materialization type - strategy combination: (`%s` - `%s`)", mat.Type, mat.Strategy)
 }
 
+// IsContractSupportedForStrategy returns true if the enforced contracts are applied with the given strategy, which is
+// only the case for the strategies that recreate the table.
+func IsContractSupportedForStrategy(strategy MaterializationStrategy) bool {
+	return strategy == MaterializationStrategyNone || strategy == MaterializationStrategyCreateReplace
+}
+
 func removeComments(query string) string {
 	bytes := []byte(query)
 	re := regexp.MustCompile(`/\* *@bruin[\s\w\S]*@bruin *\*/`)
@@ -68,3 +68,43 @@ func TestMaterializer_Render(t *testing.T) {
 		})
 	}
 }
+
+func TestMaterializer_Render_ContractStrategies(t *testing.T) {
+	t.Parallel()
+
+	materializer := Materializer{
+		MaterializationMap: AssetMaterializationMap{
+			MaterializationTypeTable: {
+				MaterializationStrategyCreateReplace: func(task *Asset, query string) (string, error) {
+					return "CREATE " + query, nil
+				},
+				MaterializationStrategyAppend: func(task *Asset, query string) (string, error) {
+					return "INSERT " + query, nil
+				},
+			},
+		},
+	}
+
+	asset := &Asset{
+		Contract: ContractModeEnforced,
+		Materialization: Materialization{
+			Type:     MaterializationTypeTable,
+			Strategy: MaterializationStrategyAppend,
+		},
+	}
+
+	_, err := materializer.Render(asset, "SELECT 1")
+	require.EqualError(t, err, "enforced contracts are only supported with the `create+replace` strategy, `append` given")
+
+	asset.Materialization.Strategy = MaterializationStrategyCreateReplace
+	render, err := materializer.Render(asset, "SELECT 1")
+	require.NoError(t, err)
+	assert.Equal(t, "CREATE SELECT 1", render)
+
+	// a full refresh recreates the table, which applies the contract
+	materializer.FullRefresh = true
+	asset.Materialization.Strategy = MaterializationStrategyAppend
+	render, err = materializer.Render(asset, "SELECT 1")
+	require.NoError(t, err)
+	assert.Equal(t, "CREATE SELECT 1", render)
+}
@@ -29,6 +29,7 @@ const (
 	AssetTypePython               = AssetType("python")
 	AssetTypeSnowflakeQuery       = AssetType("sf.sql")
 	AssetTypeSnowflakeSeed        = AssetType("sf.seed")
+	AssetTypeSnowflakeSource      = AssetType("sf.source")
 	AssetTypeSnowflakeQuerySensor = AssetType("sf.sensor.query")
 	AssetTypeBigqueryQuery        = AssetType("bq.sql")
 	AssetTypeBigqueryTableSensor  = AssetType("bq.sensor.table")
@@ -37,20 +38,26 @@ const (
 	AssetTypeBigquerySeed         = AssetType("bq.seed")
 	AssetTypeDuckDBQuery          = AssetType("duckdb.sql")
 	AssetTypeDuckDBSeed           = AssetType("duckdb.seed")
+	AssetTypeDuckDBSource         = AssetType("duckdb.source")
 	AssetTypeEmpty                = AssetType("empty")
 	AssetTypePostgresQuery        = AssetType("pg.sql")
 	AssetTypePostgresSeed         = AssetType("pg.seed")
+	AssetTypePostgresSource       = AssetType("pg.source")
 	AssetTypeRedshiftQuery        = AssetType("rs.sql")
 	AssetTypeRedshiftSeed         = AssetType("rs.seed")
+	AssetTypeRedshiftSource       = AssetType("rs.source")
 	AssetTypeAthenaQuery          = AssetType("athena.sql")
 	AssetTypeAthenaSQLSensor      = AssetType("athena.sensor.query")
 	AssetTypeAthenaSeed           = AssetType("athena.seed")
 	AssetTypeMsSQLQuery           = AssetType("ms.sql")
 	AssetTypeMsSQLSeed            = AssetType("ms.seed")
+	AssetTypeMsSQLSource          = AssetType("ms.source")
 	AssetTypeDatabricksQuery      = AssetType("databricks.sql")
 	AssetTypeDatabricksSeed       = AssetType("databricks.seed")
+	AssetTypeDatabricksSource     = AssetType("databricks.source")
 	AssetTypeSynapseQuery         = AssetType("synapse.sql")
 	AssetTypeSynapseSeed          = AssetType("synapse.seed")
+	AssetTypeSynapseSource        = AssetType("synapse.source")
 	AssetTypeIngestr              = AssetType("ingestr")
 	AssetTypeTableau              = AssetType("tableau")
 	AssetTypeClickHouse           = AssetType("clickhouse.sql")
@@ -58,6 +65,9 @@ const (
 	RunConfigFullRefresh          = RunConfig("full-refresh")
 	RunConfigStartDate            = RunConfig("start-date")
 	RunConfigEndDate              = RunConfig("end-date")
+	RunConfigRunID                = RunConfig("run-id")
+	RunConfigEnvironment          = RunConfig("environment")
+	RunConfigFailingRowsSample    = RunConfig("failing-rows-sample")
 )
 
 var defaultMapping = map[string]string{
@@ -456,9 +466,12 @@ type Column struct {
 	Description     string            `json:"description" yaml:"description,omitempty" mapstructure:"description"`
 	PrimaryKey      bool              `json:"primary_key" yaml:"primary_key,omitempty" mapstructure:"primary_key"`
 	UpdateOnMerge   bool              `json:"update_on_merge" yaml:"update_on_merge,omitempty" mapstructure:"update_on_merge"`
+	PII             bool              `json:"pii" yaml:"pii,omitempty" mapstructure:"pii"`
+	Classification  string            `json:"classification" yaml:"classification,omitempty" mapstructure:"classification"`
+	Tags            EmptyStringArray  `json:"tags" yaml:"tags,omitempty" mapstructure:"tags"`
 	Extends         string            `json:"-" yaml:"extends,omitempty" mapstructure:"extends"`
 	Checks          []ColumnCheck     `json:"checks" yaml:"checks,omitempty" mapstructure:"checks"`
-	Upstreams       []*UpstreamColumn `json:"upstreams" yaml:"-" mapstructure:"-"`
+	Upstreams       []*UpstreamColumn `json:"upstreams" yaml:"upstreams,omitempty" mapstructure:"upstreams"`
 }
 
 func (c *Column) HasCheck(check string) bool {
@@ -481,21 +494,28 @@ var AssetTypeConnectionMapping = map[AssetType]string{
 	AssetTypeSnowflakeQuery:       "snowflake",
 	AssetTypeSnowflakeQuerySensor: "snowflake",
 	AssetTypeSnowflakeSeed:        "snowflake",
+	AssetTypeSnowflakeSource:      "snowflake",
 	AssetTypePostgresQuery:        "postgres",
 	AssetTypePostgresSeed:         "postgres",
+	AssetTypePostgresSource:       "postgres",
 	AssetTypeRedshiftQuery:        "redshift",
 	AssetTypeRedshiftSeed:         "redshift",
+	AssetTypeRedshiftSource:       "redshift",
 	AssetTypeMsSQLQuery:           "mssql",
 	AssetTypeMsSQLSeed:            "mssql",
+	AssetTypeMsSQLSource:          "mssql",
 	AssetTypeDatabricksQuery:      "databricks",
 	AssetTypeDatabricksSeed:       "databricks",
+	AssetTypeDatabricksSource:     "databricks",
 	AssetTypeSynapseQuery:         "synapse",
 	AssetTypeSynapseSeed:          "synapse",
+	AssetTypeSynapseSource:        "synapse",
 	AssetTypeAthenaQuery:          "athena",
 	AssetTypeAthenaSeed:           "athena",
 	AssetTypeAthenaSQLSensor:      "athena",
 	AssetTypeDuckDBQuery:          "duckdb",
 	AssetTypeDuckDBSeed:           "duckdb",
+	AssetTypeDuckDBSource:         "duckdb",
 	AssetTypeClickHouse:           "clickhouse",
 	AssetTypeClickHouseSeed:       "clickhouse",
 }
@@ -592,6 +612,14 @@ func (s AthenaConfig) MarshalJSON() ([]byte, error) {
 	return json.Marshal(s)
 }
 
+// ContractMode controls whether the declared columns of an asset are enforced when its table is created.
+type ContractMode string
+
+const (
+	ContractModeNone     ContractMode = ""
+	ContractModeEnforced ContractMode = "enforced"
+)
+
 type Asset struct {
 	ID              string             `json:"id" yaml:"-" mapstructure:"-"`
 	URI             string             `json:"uri" yaml:"uri,omitempty" mapstructure:"uri"`
@@ -613,8 +641,12 @@ type Asset struct {
 	Columns         []Column           `json:"columns" yaml:"columns,omitempty" mapstructure:"columns"`
 	CustomChecks    []CustomCheck      `json:"custom_checks" yaml:"custom_checks,omitempty" mapstructure:"custom_checks"`
 	Metadata        EmptyStringMap     `json:"metadata" yaml:"metadata,omitempty" mapstructure:"metadata"`
+	Contract        ContractMode       `json:"contract" yaml:"contract,omitempty" mapstructure:"contract"`
 	Snowflake       SnowflakeConfig    `json:"snowflake" yaml:"snowflake,omitempty" mapstructure:"snowflake"`
 	Athena          AthenaConfig       `json:"athena" yaml:"athena,omitempty" mapstructure:"athena"`
+	Freshness       *FreshnessCheck    `json:"freshness" yaml:"freshness,omitempty" mapstructure:"freshness"`
+	Anomalies       []AnomalyCheck     `json:"anomalies" yaml:"anomalies,omitempty" mapstructure:"anomalies"`
+	Tests           []SQLTest          `json:"tests" yaml:"tests,omitempty" mapstructure:"tests"`
 
 	upstream   []*Asset
 	downstream []*Asset
@@ -731,6 +763,11 @@ func (a *Asset) GetFullDownstream() []*Asset {
 	return uniqueAssets(downstream)
 }
 
+// IsContractEnforced reports whether the table of the asset must be created with the declared column names and types.
+func (a *Asset) IsContractEnforced() bool {
+	return a.Contract == ContractModeEnforced
+}
+
 func (a *Asset) ColumnNames() []string {
 	columns := make([]string, len(a.Columns))
 	for i, c := range a.Columns {
@@ -813,11 +850,88 @@ func (a *Asset) EnrichFromEntityAttributes(entities []*glossary.Entity) error {
 		if c.Description == "" {
 			a.Columns[i].Description = attr.Description
 		}
+
+		if attr.PII {
+			a.Columns[i].PII = true
+		}
+
+		checks, err := attributeChecks(attr)
+		if err != nil {
+			return errors.Wrapf(err, "invalid checks in attribute '%s.%s'", entity, attr.Name)
+		}
+
+		// the checks defined on the column take priority over the ones with the same name coming from the attribute
+		for _, check := range checks {
+			if a.Columns[i].HasCheck(check.Name) {
+				continue
+			}
+
+			a.Columns[i].Checks = append(a.Columns[i].Checks, NewColumnCheck(a.Name, a.Columns[i].Name, check.Name, check.Value, check.Blocking))
+		}
 	}
 
 	return nil
 }
 
+// attributeChecks returns the column checks of a glossary attribute, the allowed values of the attribute are checked
+// with an `accepted_values` check unless the attribute already defines one.
+func attributeChecks(attr *glossary.Attribute) ([]glossaryCheck, error) {
+	checks := make([]glossaryCheck, 0, len(attr.Checks)+1)
+	seen := make(map[string]bool, len(attr.Checks))
+	for _, check := range attr.Checks {
+		name := strings.TrimSpace(check.Name)
+		if !ValidQualityChecks[name] {
+			return nil, errors.Errorf("unknown check '%s'", check.Name)
+		}
+
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		value, err := columnCheckValueFrom(check.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value for the check '%s'", name)
+		}
+
+		checks = append(checks, glossaryCheck{Name: name, Value: value, Blocking: check.Blocking})
+	}
+
+	if len(attr.AllowedValues) > 0 && !seen["accepted_values"] {
+		value, err := columnCheckValueFrom(attr.AllowedValues)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid allowed values")
+		}
+
+		checks = append(checks, glossaryCheck{Name: "accepted_values", Value: value})
+	}
+
+	return checks, nil
+}
+
+type glossaryCheck struct {
+	Name     string
+	Value    ColumnCheckValue
+	Blocking *bool
+}
+
+// columnCheckValueFrom converts a value decoded from YAML into a check value, the same way the values are parsed from
+// the JSON representation of the assets.
+func columnCheckValueFrom(value interface{}) (ColumnCheckValue, error) {
+	var ccv ColumnCheckValue
+	if value == nil {
+		return ccv, nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return ccv, err
+	}
+
+	err = ccv.UnmarshalJSON(encoded)
+	return ccv, err
+}
+
 func (a *Asset) Persist(fs afero.Fs) error {
 	if a == nil {
 		return errors.New("failed to build an asset, therefore cannot persist it")
@@ -970,29 +1084,81 @@ func PipelineFromPath(filePath string, fs afero.Fs) (*Pipeline, error) {
 }
 
 type MetadataPush struct {
-	Global   bool `json:"-"`
-	BigQuery bool `json:"bigquery" yaml:"bigquery" mapstructure:"bigquery"`
+	Global     bool `json:"-"`
+	BigQuery   bool `json:"bigquery" yaml:"bigquery" mapstructure:"bigquery"`
+	Snowflake  bool `json:"snowflake" yaml:"snowflake" mapstructure:"snowflake"`
+	Postgres   bool `json:"postgres" yaml:"postgres" mapstructure:"postgres"`
+	Redshift   bool `json:"redshift" yaml:"redshift" mapstructure:"redshift"`
+	Databricks bool `json:"databricks" yaml:"databricks" mapstructure:"databricks"`
+	MsSQL      bool `json:"mssql" yaml:"mssql" mapstructure:"mssql"`
+	DuckDB     bool `json:"duckdb" yaml:"duckdb" mapstructure:"duckdb"`
 }
 
 func (mp *MetadataPush) HasAnyEnabled() bool {
-	return mp.BigQuery || mp.Global
+	return mp.BigQuery || mp.Snowflake || mp.Postgres || mp.Redshift || mp.Databricks || mp.MsSQL || mp.DuckDB || mp.Global
+}
+
+// IsEnabledForAsset returns true if the metadata push is enabled globally, or for the platform of the asset.
+// The `bigquery` flag predates the other platforms: it has always pushed the metadata of the Snowflake assets, the
+// Python assets and the BigQuery query sensors as well, so it keeps doing that for the existing pipelines.
+func (mp *MetadataPush) IsEnabledForAsset(asset *Asset) bool {
+	if mp.Global {
+		return true
+	}
+
+	switch asset.Type {
+	case AssetTypePython:
+		return mp.BigQuery || mp.Snowflake
+	case AssetTypeBigqueryQuerySensor:
+		return mp.BigQuery
+	}
+
+	switch AssetTypeConnectionMapping[asset.Type] {
+	case "google_cloud_platform":
+		return mp.BigQuery
+	case "snowflake":
+		return mp.Snowflake || mp.BigQuery
+	case "postgres":
+		return mp.Postgres
+	case "redshift":
+		return mp.Redshift
+	case "databricks":
+		return mp.Databricks
+	case "mssql":
+		return mp.MsSQL
+	case "duckdb":
+		return mp.DuckDB
+	default:
+		return false
+	}
+}
+
+// ClassificationPolicy defines how the columns with a classification are protected in the warehouse when the metadata
+// of their assets is pushed.
+type ClassificationPolicy struct {
+	BigQueryPolicyTag      string   `json:"bigquery_policy_tag" yaml:"bigquery_policy_tag" mapstructure:"bigquery_policy_tag"`
+	SnowflakeMaskingPolicy string   `json:"snowflake_masking_policy" yaml:"snowflake_masking_policy" mapstructure:"snowflake_masking_policy"`
+	SnowflakeTag           string   `json:"snowflake_tag" yaml:"snowflake_tag" mapstructure:"snowflake_tag"`
+	PostgresGrantees       []string `json:"postgres_grantees" yaml:"postgres_grantees" mapstructure:"postgres_grantees"`
 }
 
 type Pipeline struct {
-	LegacyID           string                 `json:"legacy_id" yaml:"id" mapstructure:"id"`
-	Name               string                 `json:"name" yaml:"name" mapstructure:"name"`
-	Schedule           Schedule               `json:"schedule" yaml:"schedule" mapstructure:"schedule"`
-	StartDate          string                 `json:"start_date" yaml:"start_date" mapstructure:"start_date"`
-	DefinitionFile     DefinitionFile         `json:"definition_file"`
-	DefaultConnections EmptyStringMap         `json:"default_connections" yaml:"default_connections" mapstructure:"default_connections"`
-	Assets             []*Asset               `json:"assets"`
-	Notifications      Notifications          `json:"notifications" yaml:"notifications" mapstructure:"notifications"`
-	Catchup            bool                   `json:"catchup" yaml:"catchup" mapstructure:"catchup"`
-	MetadataPush       MetadataPush           `json:"metadata_push" yaml:"metadata_push" mapstructure:"metadata_push"`
-	Retries            int                    `json:"retries" yaml:"retries" mapstructure:"retries"`
-	DefaultValues      *DefaultValues         `json:"default,omitempty" yaml:"default,omitempty" mapstructure:"default,omitempty"`
-	Commit             string                 `json:"commit"`
-	TasksByType        map[AssetType][]*Asset `json:"-"`
+	LegacyID           string                           `json:"legacy_id" yaml:"id" mapstructure:"id"`
+	Name               string                           `json:"name" yaml:"name" mapstructure:"name"`
+	Schedule           Schedule                         `json:"schedule" yaml:"schedule" mapstructure:"schedule"`
+	StartDate          string                           `json:"start_date" yaml:"start_date" mapstructure:"start_date"`
+	DefinitionFile     DefinitionFile                   `json:"definition_file"`
+	DefaultConnections EmptyStringMap                   `json:"default_connections" yaml:"default_connections" mapstructure:"default_connections"`
+	Assets             []*Asset                         `json:"assets"`
+	Notifications      Notifications                    `json:"notifications" yaml:"notifications" mapstructure:"notifications"`
+	Catchup            bool                             `json:"catchup" yaml:"catchup" mapstructure:"catchup"`
+	MetadataPush       MetadataPush                     `json:"metadata_push" yaml:"metadata_push" mapstructure:"metadata_push"`
+	Classifications    map[string]*ClassificationPolicy `json:"classifications,omitempty" yaml:"classifications,omitempty" mapstructure:"classifications,omitempty"`
+	Retries            int                              `json:"retries" yaml:"retries" mapstructure:"retries"`
+	DefaultValues      *DefaultValues                   `json:"default,omitempty" yaml:"default,omitempty" mapstructure:"default,omitempty"`
+	Variables          Variables                        `json:"variables,omitempty" yaml:"variables,omitempty" mapstructure:"variables,omitempty"`
+	Commit             string                           `json:"commit"`
+	TasksByType        map[AssetType][]*Asset           `json:"-"`
 	tasksByName        map[string]*Asset
 }
 
@@ -1002,6 +1168,28 @@ type DefaultValues struct {
 	Secrets    []secretMapping   `json:"secrets" yaml:"secrets" mapstructure:"secrets"`
 }
 
+// GetClassificationPolicy returns the policy for the given column classification, nil if the pipeline does not define one.
+func (p *Pipeline) GetClassificationPolicy(classification string) *ClassificationPolicy {
+	if classification == "" {
+		return nil
+	}
+
+	return p.Classifications[classification]
+}
+
+// ColumnPolicies returns the classification policies of the columns of the asset by the column names, the columns
+// without a classification, or with a classification the pipeline has no policy for, are skipped.
+func (p *Pipeline) ColumnPolicies(asset *Asset) map[string]*ClassificationPolicy {
+	policies := make(map[string]*ClassificationPolicy)
+	for _, column := range asset.Columns {
+		if policy := p.GetClassificationPolicy(column.Classification); policy != nil {
+			policies[column.Name] = policy
+		}
+	}
+
+	return policies
+}
+
 func (p *Pipeline) GetCompatibilityHash() string {
 	parts := make([]string, 0, len(p.Assets)+1)
 	parts = append(parts, p.Name)
@@ -1390,6 +1578,9 @@ func (b *Builder) CreatePipelineFromPath(pathToPipeline string, isMutate bool) (
 	}
 
 	for _, asset := range pipeline.Assets {
+		asset.addRefUpstreams(pipeline)
+		asset.addCheckUpstreams(pipeline)
+
 		for _, upstream := range asset.Upstreams {
 			if upstream.Type != "asset" {
 				continue
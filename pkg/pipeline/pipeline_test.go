@@ -14,6 +14,7 @@ import (
 
 	"github.com/bruin-data/bruin/cmd"
 	"github.com/bruin-data/bruin/pkg/git"
+	"github.com/bruin-data/bruin/pkg/glossary"
 	"github.com/bruin-data/bruin/pkg/path"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/spf13/afero"
@@ -1060,3 +1061,169 @@ func TestPipeline_GetCompatibilityHash(t *testing.T) {
 		})
 	}
 }
+
+func TestAsset_EnrichFromEntityAttributes(t *testing.T) {
+	t.Parallel()
+
+	nonBlocking := false
+	emailPattern := "^.+@.+$"
+	entities := []*glossary.Entity{
+		{
+			Name: "Customer",
+			Attributes: map[string]*glossary.Attribute{
+				"ID": {
+					Name:        "ID",
+					Type:        "integer",
+					Description: "The unique identifier of the customer.",
+					Checks:      []glossary.Check{{Name: "not_null"}, {Name: "unique"}},
+				},
+				"Email": {
+					Name:   "Email",
+					Type:   "string",
+					PII:    true,
+					Checks: []glossary.Check{{Name: "pattern", Value: emailPattern, Blocking: &nonBlocking}},
+				},
+				"Tier": {
+					Name:          "Tier",
+					Type:          "integer",
+					AllowedValues: []interface{}{uint64(1), uint64(2), uint64(3)},
+				},
+				"Status": {
+					Name:          "Status",
+					Checks:        []glossary.Check{{Name: "accepted_values", Value: []interface{}{"active"}}},
+					AllowedValues: []interface{}{"active", "churned"},
+				},
+				"Invalid": {
+					Name:   "Invalid",
+					Checks: []glossary.Check{{Name: "not_a_check"}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		columns []pipeline.Column
+		want    []pipeline.Column
+		wantErr string
+	}{
+		{
+			name: "checks and pii are inherited",
+			columns: []pipeline.Column{
+				{Name: "customer_id", EntityAttribute: &pipeline.EntityAttribute{Entity: "Customer", Attribute: "ID"}},
+				{EntityAttribute: &pipeline.EntityAttribute{Entity: "Customer", Attribute: "Email"}},
+			},
+			want: []pipeline.Column{
+				{
+					Name:            "customer_id",
+					Type:            "integer",
+					Description:     "The unique identifier of the customer.",
+					EntityAttribute: &pipeline.EntityAttribute{Entity: "Customer", Attribute: "ID"},
+					Checks: []pipeline.ColumnCheck{
+						pipeline.NewColumnCheck("asset", "customer_id", "not_null", pipeline.ColumnCheckValue{}, nil),
+						pipeline.NewColumnCheck("asset", "customer_id", "unique", pipeline.ColumnCheckValue{}, nil),
+					},
+				},
+				{
+					Name:            "Email",
+					Type:            "string",
+					PII:             true,
+					EntityAttribute: &pipeline.EntityAttribute{Entity: "Customer", Attribute: "Email"},
+					Checks: []pipeline.ColumnCheck{
+						pipeline.NewColumnCheck("asset", "Email", "pattern", pipeline.ColumnCheckValue{String: &emailPattern}, &nonBlocking),
+					},
+				},
+			},
+		},
+		{
+			name: "column checks take priority",
+			columns: []pipeline.Column{
+				{
+					Name:            "customer_id",
+					EntityAttribute: &pipeline.EntityAttribute{Entity: "Customer", Attribute: "ID"},
+					Checks: []pipeline.ColumnCheck{
+						pipeline.NewColumnCheck("asset", "customer_id", "unique", pipeline.ColumnCheckValue{}, &nonBlocking),
+					},
+				},
+			},
+			want: []pipeline.Column{
+				{
+					Name:            "customer_id",
+					Type:            "integer",
+					Description:     "The unique identifier of the customer.",
+					EntityAttribute: &pipeline.EntityAttribute{Entity: "Customer", Attribute: "ID"},
+					Checks: []pipeline.ColumnCheck{
+						pipeline.NewColumnCheck("asset", "customer_id", "unique", pipeline.ColumnCheckValue{}, &nonBlocking),
+						pipeline.NewColumnCheck("asset", "customer_id", "not_null", pipeline.ColumnCheckValue{}, nil),
+					},
+				},
+			},
+		},
+		{
+			name: "allowed values become an accepted_values check",
+			columns: []pipeline.Column{
+				{Name: "tier", EntityAttribute: &pipeline.EntityAttribute{Entity: "Customer", Attribute: "Tier"}},
+				{Name: "status", EntityAttribute: &pipeline.EntityAttribute{Entity: "Customer", Attribute: "Status"}},
+			},
+			want: []pipeline.Column{
+				{
+					Name:            "tier",
+					Type:            "integer",
+					EntityAttribute: &pipeline.EntityAttribute{Entity: "Customer", Attribute: "Tier"},
+					Checks: []pipeline.ColumnCheck{
+						pipeline.NewColumnCheck("asset", "tier", "accepted_values", pipeline.ColumnCheckValue{IntArray: &[]int{1, 2, 3}}, nil),
+					},
+				},
+				{
+					Name:            "status",
+					EntityAttribute: &pipeline.EntityAttribute{Entity: "Customer", Attribute: "Status"},
+					Checks: []pipeline.ColumnCheck{
+						pipeline.NewColumnCheck("asset", "status", "accepted_values", pipeline.ColumnCheckValue{StringArray: &[]string{"active"}}, nil),
+					},
+				},
+			},
+		},
+		{
+			name: "unknown checks are rejected",
+			columns: []pipeline.Column{
+				{Name: "x", EntityAttribute: &pipeline.EntityAttribute{Entity: "Customer", Attribute: "Invalid"}},
+			},
+			wantErr: "invalid checks in attribute 'Customer.Invalid': unknown check 'not_a_check'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			asset := &pipeline.Asset{Name: "asset", Columns: tt.columns}
+			err := asset.EnrichFromEntityAttributes(entities)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, asset.Columns)
+		})
+	}
+}
+
+func TestPipeline_ColumnPolicies(t *testing.T) {
+	t.Parallel()
+
+	pii := &pipeline.ClassificationPolicy{BigQueryPolicyTag: "projects/p/locations/us/taxonomies/1/policyTags/2"}
+	p := &pipeline.Pipeline{
+		Classifications: map[string]*pipeline.ClassificationPolicy{"pii": pii},
+	}
+
+	asset := &pipeline.Asset{
+		Columns: []pipeline.Column{
+			{Name: "id"},
+			{Name: "email", Classification: "pii"},
+			{Name: "country", Classification: "public"},
+		},
+	}
+
+	assert.Equal(t, map[string]*pipeline.ClassificationPolicy{"email": pii}, p.ColumnPolicies(asset))
+	assert.Empty(t, (&pipeline.Pipeline{}).ColumnPolicies(asset))
+}
@@ -0,0 +1,64 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/bruin-data/bruin/pkg/jinja"
+)
+
+// FindRefs returns the unique asset names referenced via `ref('asset_name')` in the Jinja expressions of the given
+// content, in the order they appear. The refs in SQL comments, string literals and Jinja comments are ignored.
+func FindRefs(content string) []string {
+	refs := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, name := range jinja.FindCallArguments(content, "ref") {
+		if seen[name] {
+			continue
+		}
+
+		seen[name] = true
+		refs = append(refs, name)
+	}
+
+	return refs
+}
+
+// addRefUpstreams adds the assets referenced via `ref()` in the query as upstreams of the asset, unless they are
+// already declared in `depends`. Refs to assets that do not exist in the pipeline are left to the linter.
+func (a *Asset) addRefUpstreams(p *Pipeline) {
+	if !a.IsSQLAsset() {
+		return
+	}
+
+	for _, ref := range FindRefs(a.ExecutableFile.Content) {
+		if _, ok := p.tasksByName[ref]; !ok {
+			continue
+		}
+
+		alreadyDeclared := false
+		for _, upstream := range a.Upstreams {
+			if upstream.Type == "asset" && upstream.Value == ref {
+				alreadyDeclared = true
+				break
+			}
+		}
+
+		if !alreadyDeclared {
+			a.Upstreams = append(a.Upstreams, Upstream{Type: "asset", Value: ref, Columns: make([]DependsColumn, 0)})
+		}
+	}
+}
+
+// ResolveRef returns the name of the table the referenced asset creates.
+func (p *Pipeline) ResolveRef(name string) (string, error) {
+	if p == nil {
+		return name, nil
+	}
+
+	asset := p.GetAssetByName(name)
+	if asset == nil {
+		return "", fmt.Errorf("ref('%s') refers to an asset that does not exist in the pipeline", name)
+	}
+
+	return asset.Name, nil
+}
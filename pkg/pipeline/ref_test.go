@@ -0,0 +1,85 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindRefs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "no refs",
+			content: "SELECT * FROM dataset.orders",
+			want:    []string{},
+		},
+		{
+			name:    "single and double quotes, with spaces",
+			content: "SELECT * FROM {{ ref('dataset.orders') }} JOIN {{ ref( \"dataset.customers\" ) }}",
+			want:    []string{"dataset.orders", "dataset.customers"},
+		},
+		{
+			name:    "duplicates are removed",
+			content: "SELECT * FROM {{ ref('dataset.orders') }} UNION ALL SELECT * FROM {{ ref('dataset.orders') }}",
+			want:    []string{"dataset.orders"},
+		},
+		{
+			name:    "other functions are ignored",
+			content: "SELECT * FROM {{ pref('dataset.orders') }} JOIN {{ macros.ref('dataset.customers') }}",
+			want:    []string{},
+		},
+		{
+			name: "refs outside of the jinja expressions are ignored",
+			content: `-- SELECT * FROM ref('dataset.old_orders')
+/* ref('dataset.archived_orders') */
+{# SELECT * FROM {{ ref('dataset.commented_orders') }} #}
+SELECT 'ref(''dataset.literal'')' AS note, * FROM {{ ref('dataset.orders') }}`,
+			want: []string{"dataset.orders"},
+		},
+		{
+			name:    "refs in statements",
+			content: "{% set orders = ref('dataset.orders') %}SELECT * FROM {{ orders }}",
+			want:    []string{"dataset.orders"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, FindRefs(tt.content))
+		})
+	}
+}
+
+func TestAsset_addRefUpstreams(t *testing.T) {
+	t.Parallel()
+
+	orders := &Asset{Name: "dataset.orders"}
+	customers := &Asset{Name: "dataset.customers"}
+	asset := &Asset{
+		Name: "dataset.report",
+		Type: AssetTypeBigqueryQuery,
+		ExecutableFile: ExecutableFile{
+			Content: "SELECT * FROM {{ ref('dataset.orders') }} JOIN {{ ref('dataset.customers') }} JOIN {{ ref('dataset.unknown') }}",
+		},
+		Upstreams: []Upstream{
+			{Type: "asset", Value: "dataset.customers", Columns: []DependsColumn{{Name: "id"}}},
+		},
+	}
+
+	p := &Pipeline{Assets: []*Asset{orders, customers, asset}}
+	p.ensureTaskNameMapIsFilled()
+
+	asset.addRefUpstreams(p)
+
+	assert.Equal(t, []Upstream{
+		{Type: "asset", Value: "dataset.customers", Columns: []DependsColumn{{Name: "id"}}},
+		{Type: "asset", Value: "dataset.orders", Columns: []DependsColumn{}},
+	}, asset.Upstreams)
+}
@@ -0,0 +1,85 @@
+package pipeline
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bruin-data/bruin/pkg/jinja"
+)
+
+// IncrementalVariable is the Jinja variable that tells the queries if the target table already exists and the asset
+// is not running as a full refresh.
+const IncrementalVariable = "is_incremental"
+
+type targetTableExistsContextKey struct{}
+
+// WithTargetTableExists returns a copy of the context that records whether the target table of the asset exists.
+func WithTargetTableExists(ctx context.Context, exists bool) context.Context {
+	return context.WithValue(ctx, targetTableExistsContextKey{}, exists)
+}
+
+// UsesIncrementalVariable reports whether the given query refers to `is_incremental`, so that the existence of the
+// target table is only checked when it is needed.
+func UsesIncrementalVariable(content string) bool {
+	return strings.Contains(content, IncrementalVariable)
+}
+
+// AssetRenderContext returns the variables that are available to the queries of the given asset in addition to the
+// ones of the renderer:
+//   - `var`: the pipeline variables.
+//   - `ref`: a function that resolves the name of another asset in the pipeline.
+//   - `this`: the name of the table the asset creates.
+//   - `asset`: the name, type, columns and materialization of the asset.
+//   - `is_full_refresh`: true if the run is a full refresh.
+//   - `is_incremental`: true if the target table already exists and the run is not a full refresh.
+func AssetRenderContext(ctx context.Context, p *Pipeline, t *Asset) jinja.Context {
+	fullRefresh, _ := ctx.Value(RunConfigFullRefresh).(bool)
+	tableExists, _ := ctx.Value(targetTableExistsContextKey{}).(bool)
+
+	var variables map[string]any
+	if p != nil {
+		variables = p.Variables.Value()
+	} else {
+		variables = map[string]any{}
+	}
+
+	renderContext := jinja.Context{
+		"var": variables,
+		"ref": func(name string) (string, error) {
+			return p.ResolveRef(name)
+		},
+		"is_full_refresh":   fullRefresh,
+		IncrementalVariable: tableExists && !fullRefresh,
+	}
+
+	if t == nil {
+		return renderContext
+	}
+
+	columns := make([]map[string]any, 0, len(t.Columns))
+	for _, column := range t.Columns {
+		columns = append(columns, map[string]any{
+			"name":        column.Name,
+			"type":        column.Type,
+			"description": column.Description,
+			"primary_key": column.PrimaryKey,
+		})
+	}
+
+	renderContext["this"] = t.Name
+	renderContext["asset"] = map[string]any{
+		"name":    t.Name,
+		"type":    string(t.Type),
+		"columns": columns,
+		"materialization": map[string]any{
+			"type":             string(t.Materialization.Type),
+			"strategy":         string(t.Materialization.Strategy),
+			"partition_by":     t.Materialization.PartitionBy,
+			"cluster_by":       t.Materialization.ClusterBy,
+			"incremental_key":  t.Materialization.IncrementalKey,
+			"time_granularity": t.Materialization.TimeGranularity,
+		},
+	}
+
+	return renderContext
+}
@@ -0,0 +1,96 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bruin-data/bruin/pkg/jinja"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetRenderContext(t *testing.T) {
+	t.Parallel()
+
+	p := &Pipeline{
+		Name: "test",
+		Variables: Variables{
+			"segment": {"type": "string", "default": "smb"},
+		},
+		Assets: []*Asset{
+			{Name: "dataset.customers"},
+		},
+	}
+	asset := &Asset{
+		Name: "dataset.orders",
+		Type: AssetTypeBigqueryQuery,
+		Columns: []Column{
+			{Name: "id", Type: "INTEGER", PrimaryKey: true},
+			{Name: "amount", Type: "FLOAT"},
+		},
+		Materialization: Materialization{
+			Type:           MaterializationTypeTable,
+			Strategy:       MaterializationStrategyDeleteInsert,
+			IncrementalKey: "dt",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		query   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "asset attributes are available",
+			ctx:   context.Background(),
+			query: "{{ this }} {{ asset.name }} {{ asset.type }} {{ asset.materialization.type }} {{ asset.materialization.strategy }} {{ asset.materialization.incremental_key }} {% for c in asset.columns %}{{ c.name }}:{{ c.type }}{% if c.primary_key %}*{% endif %} {% endfor %}{{ var.segment }}",
+			want:  "dataset.orders dataset.orders bq.sql table delete+insert dt id:INTEGER* amount:FLOAT smb",
+		},
+		{
+			name:  "runs are not incremental by default",
+			ctx:   context.Background(),
+			query: "SELECT * FROM src{% if is_incremental %} WHERE dt > (SELECT MAX(dt) FROM {{ this }}){% endif %}",
+			want:  "SELECT * FROM src",
+		},
+		{
+			name:  "runs are incremental if the table exists",
+			ctx:   WithTargetTableExists(context.Background(), true),
+			query: "SELECT * FROM src{% if is_incremental %} WHERE dt > (SELECT MAX(dt) FROM {{ this }}){% endif %}",
+			want:  "SELECT * FROM src WHERE dt > (SELECT MAX(dt) FROM dataset.orders)",
+		},
+		{
+			name:  "full refresh runs are never incremental",
+			ctx:   WithTargetTableExists(context.WithValue(context.Background(), RunConfigFullRefresh, true), true),
+			query: "{{ is_full_refresh }} {{ is_incremental }}",
+			want:  "True False",
+		},
+		{
+			name:  "refs resolve to the name of the referenced asset",
+			ctx:   context.Background(),
+			query: "SELECT * FROM {{ ref('dataset.customers') }}",
+			want:  "SELECT * FROM dataset.customers",
+		},
+		{
+			name:    "refs to unknown assets fail",
+			ctx:     context.Background(),
+			query:   "SELECT * FROM {{ ref('dataset.unknown') }}",
+			wantErr: "ref('dataset.unknown') refers to an asset that does not exist in the pipeline",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			renderer := jinja.NewRendererWithYesterday("test", "run-id").WithContext(AssetRenderContext(tt.ctx, p, asset))
+			got, err := renderer.Render(tt.query)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
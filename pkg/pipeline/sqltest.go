@@ -0,0 +1,24 @@
+package pipeline
+
+// SQLTest is a unit test of a SQL asset: the query of the asset is run on DuckDB against the fixtures given for its
+// upstream tables, and its result is compared with the expected rows.
+type SQLTest struct {
+	Name   string             `json:"name" yaml:"name" mapstructure:"name"`
+	Given  []SQLTestFixture   `json:"given" yaml:"given,omitempty" mapstructure:"given"`
+	Expect SQLTestExpectation `json:"expect" yaml:"expect" mapstructure:"expect"`
+}
+
+// SQLTestFixture is the data of a table the query reads from, either given inline or as a CSV file whose path is
+// relative to the asset definition file.
+type SQLTestFixture struct {
+	Table string                   `json:"table" yaml:"table" mapstructure:"table"`
+	Rows  []map[string]interface{} `json:"rows" yaml:"rows,omitempty" mapstructure:"rows"`
+	CSV   string                   `json:"csv" yaml:"csv,omitempty" mapstructure:"csv"`
+}
+
+// SQLTestExpectation is the result the query must return, either given inline or as a CSV file. The order of the rows
+// is ignored, and only the columns that are given are compared.
+type SQLTestExpectation struct {
+	Rows []map[string]interface{} `json:"rows" yaml:"rows,omitempty" mapstructure:"rows"`
+	CSV  string                   `json:"csv" yaml:"csv,omitempty" mapstructure:"csv"`
+}
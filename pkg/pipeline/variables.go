@@ -0,0 +1,252 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var variableNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Variables are the user-defined variables of a pipeline. Every variable is described by a JSON schema that has a
+// `default` value, e.g.:
+//
+//	variables:
+//	  segment:
+//	    type: string
+//	    enum: ["enterprise", "smb"]
+//	    default: "enterprise"
+//	  lookback_days:
+//	    type: integer
+//	    default: 7
+type Variables map[string]map[string]any
+
+// Names returns the names of the variables in alphabetical order.
+func (v Variables) Names() []string {
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// Value returns the current value of every variable.
+func (v Variables) Value() map[string]any {
+	values := make(map[string]any, len(v))
+	for name, schema := range v {
+		values[name] = schema["default"]
+	}
+
+	return values
+}
+
+// Validate ensures that the variable names are valid and every variable has a default value that matches its schema.
+func (v Variables) Validate() error {
+	for _, name := range v.Names() {
+		if !variableNameRegex.MatchString(name) {
+			return errors.Errorf("invalid variable name '%s', variable names must start with a letter or an underscore and can only contain letters, numbers and underscores", name)
+		}
+
+		schema := v[name]
+		defaultValue, ok := schema["default"]
+		if !ok {
+			return errors.Errorf("variable '%s' must have a default value", name)
+		}
+
+		if err := validateAgainstSchema(defaultValue, schema, ""); err != nil {
+			return errors.Wrapf(err, "invalid default value for variable '%s'", name)
+		}
+	}
+
+	return nil
+}
+
+// Override replaces the value of the given variable with the given raw value, e.g. the one given in the CLI. The raw
+// value is parsed as JSON unless the variable is a string, in which case it is used as is unless it is a quoted JSON
+// string.
+func (v Variables) Override(name, rawValue string) error {
+	schema, ok := v[name]
+	if !ok {
+		return errors.Errorf("variable '%s' is not defined in the pipeline", name)
+	}
+
+	value := parseVariableValue(rawValue, schema)
+	if err := validateAgainstSchema(value, schema, ""); err != nil {
+		return errors.Wrapf(err, "invalid value for variable '%s'", name)
+	}
+
+	schema["default"] = value
+	return nil
+}
+
+func parseVariableValue(rawValue string, schema map[string]any) any {
+	var value any
+	if err := json.Unmarshal([]byte(rawValue), &value); err != nil {
+		return rawValue
+	}
+
+	if schema["type"] == "string" {
+		if str, ok := value.(string); ok {
+			return str
+		}
+
+		return rawValue
+	}
+
+	return value
+}
+
+func validateAgainstSchema(value any, schema map[string]any, path string) error {
+	location := ""
+	if path != "" {
+		location = fmt.Sprintf(" at '%s'", path)
+	}
+
+	if expectedType, ok := schema["type"]; ok {
+		typeName, ok := expectedType.(string)
+		if !ok {
+			return errors.Errorf("the type%s must be a string", location)
+		}
+
+		matches, err := valueMatchesType(value, typeName)
+		if err != nil {
+			return errors.Wrapf(err, "invalid schema%s", location)
+		}
+
+		if !matches {
+			return errors.Errorf("expected a value of type '%s'%s, got '%v'", typeName, location, value)
+		}
+	}
+
+	if enum, ok := schema["enum"]; ok {
+		options, ok := enum.([]any)
+		if !ok {
+			return errors.Errorf("the enum%s must be a list", location)
+		}
+
+		found := false
+		for _, option := range options {
+			if valuesAreEqual(value, option) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return errors.Errorf("the value '%v'%s must be one of %v", value, location, options)
+		}
+	}
+
+	if items, ok := schema["items"]; ok {
+		itemSchema, ok := items.(map[string]any)
+		if !ok {
+			return errors.Errorf("the items%s must be a schema", location)
+		}
+
+		if list, ok := value.([]any); ok {
+			for i, item := range list {
+				if err := validateAgainstSchema(item, itemSchema, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	object, isObject := value.(map[string]any)
+	if !isObject {
+		return nil
+	}
+
+	if required, ok := schema["required"]; ok {
+		keys, ok := required.([]any)
+		if !ok {
+			return errors.Errorf("the required keys%s must be a list", location)
+		}
+
+		for _, key := range keys {
+			if _, ok := object[fmt.Sprint(key)]; !ok {
+				return errors.Errorf("the key '%v' is required%s", key, location)
+			}
+		}
+	}
+
+	if properties, ok := schema["properties"]; ok {
+		propertySchemas, ok := properties.(map[string]any)
+		if !ok {
+			return errors.Errorf("the properties%s must be a map", location)
+		}
+
+		for key, propertyValue := range object {
+			propertySchema, ok := propertySchemas[key].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			if err := validateAgainstSchema(propertyValue, propertySchema, strings.TrimPrefix(path+"."+key, ".")); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func valueMatchesType(value any, typeName string) (bool, error) {
+	switch typeName {
+	case "string":
+		_, ok := value.(string)
+		return ok, nil
+	case "boolean":
+		_, ok := value.(bool)
+		return ok, nil
+	case "integer":
+		number, ok := toFloat(value)
+		return ok && number == math.Trunc(number), nil
+	case "number":
+		_, ok := toFloat(value)
+		return ok, nil
+	case "array":
+		_, ok := value.([]any)
+		return ok, nil
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok, nil
+	case "null":
+		return value == nil, nil
+	default:
+		return false, errors.Errorf("unsupported type '%s', supported types are string, integer, number, boolean, array, object and null", typeName)
+	}
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+func valuesAreEqual(a, b any) bool {
+	aNumber, aIsNumber := toFloat(a)
+	bNumber, bIsNumber := toFloat(b)
+	if aIsNumber && bIsNumber {
+		return aNumber == bNumber
+	}
+
+	return reflect.DeepEqual(a, b)
+}
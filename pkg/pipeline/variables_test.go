@@ -0,0 +1,175 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariables_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		variables Variables
+		wantErr   string
+	}{
+		{
+			name:      "no variables",
+			variables: nil,
+		},
+		{
+			name: "valid variables",
+			variables: Variables{
+				"segment":       {"type": "string", "enum": []any{"enterprise", "smb"}, "default": "smb"},
+				"lookback_days": {"type": "integer", "default": 7},
+				"ratio":         {"type": "number", "default": 0.5},
+				"enabled":       {"type": "boolean", "default": true},
+				"countries":     {"type": "array", "items": map[string]any{"type": "string"}, "default": []any{"US", "DE"}},
+				"limits": {
+					"type":       "object",
+					"required":   []any{"max"},
+					"properties": map[string]any{"max": map[string]any{"type": "integer"}},
+					"default":    map[string]any{"max": 10},
+				},
+				"untyped": {"default": "anything"},
+			},
+		},
+		{
+			name:      "missing default",
+			variables: Variables{"segment": {"type": "string"}},
+			wantErr:   "variable 'segment' must have a default value",
+		},
+		{
+			name:      "invalid name",
+			variables: Variables{"my-var": {"type": "string", "default": "a"}},
+			wantErr:   "invalid variable name 'my-var'",
+		},
+		{
+			name:      "default with the wrong type",
+			variables: Variables{"lookback_days": {"type": "integer", "default": 7.5}},
+			wantErr:   "invalid default value for variable 'lookback_days': expected a value of type 'integer', got '7.5'",
+		},
+		{
+			name:      "default not in the enum",
+			variables: Variables{"segment": {"type": "string", "enum": []any{"enterprise", "smb"}, "default": "other"}},
+			wantErr:   "the value 'other' must be one of [enterprise smb]",
+		},
+		{
+			name:      "array item with the wrong type",
+			variables: Variables{"countries": {"type": "array", "items": map[string]any{"type": "string"}, "default": []any{"US", 1}}},
+			wantErr:   "expected a value of type 'string' at '[1]', got '1'",
+		},
+		{
+			name: "missing required key",
+			variables: Variables{"limits": {
+				"type":     "object",
+				"required": []any{"max"},
+				"default":  map[string]any{"min": 1},
+			}},
+			wantErr: "the key 'max' is required",
+		},
+		{
+			name:      "unsupported type",
+			variables: Variables{"segment": {"type": "text", "default": "a"}},
+			wantErr:   "unsupported type 'text'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.variables.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestVariables_Override(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		variable string
+		rawValue string
+		want     any
+		wantErr  string
+	}{
+		{
+			name:     "string is used as is",
+			variable: "segment",
+			rawValue: "smb",
+			want:     "smb",
+		},
+		{
+			name:     "string that looks like a number is kept as a string",
+			variable: "segment",
+			rawValue: "123",
+			want:     "123",
+		},
+		{
+			name:     "quoted string is unquoted",
+			variable: "segment",
+			rawValue: `"enterprise"`,
+			want:     "enterprise",
+		},
+		{
+			name:     "integer is parsed",
+			variable: "lookback_days",
+			rawValue: "30",
+			want:     float64(30),
+		},
+		{
+			name:     "boolean is parsed",
+			variable: "enabled",
+			rawValue: "false",
+			want:     false,
+		},
+		{
+			name:     "list is parsed",
+			variable: "countries",
+			rawValue: `["US", "FR"]`,
+			want:     []any{"US", "FR"},
+		},
+		{
+			name:     "invalid value is rejected",
+			variable: "lookback_days",
+			rawValue: "a lot",
+			wantErr:  "invalid value for variable 'lookback_days': expected a value of type 'integer', got 'a lot'",
+		},
+		{
+			name:     "unknown variable is rejected",
+			variable: "unknown",
+			rawValue: "1",
+			wantErr:  "variable 'unknown' is not defined in the pipeline",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			variables := Variables{
+				"segment":       {"type": "string", "default": "enterprise"},
+				"lookback_days": {"type": "integer", "default": 7},
+				"enabled":       {"type": "boolean", "default": true},
+				"countries":     {"type": "array", "items": map[string]any{"type": "string"}, "default": []any{"US"}},
+			}
+
+			err := variables.Override(tt.variable, tt.rawValue)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, variables.Value()[tt.variable])
+		})
+	}
+}
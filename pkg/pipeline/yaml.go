@@ -22,6 +22,10 @@ var ValidQualityChecks = map[string]bool{
 	"negative":        true,
 	"non_negative":    true,
 	"pattern":         true,
+
+	ColumnCheckRelationships:        true,
+	ColumnCheckReferences:           true,
+	ColumnCheckMatchesUpstreamCount: true,
 }
 
 func mustBeStringArray(fieldName string, value *yaml.Node) ([]string, error) {
@@ -200,14 +204,23 @@ type columnCheck struct {
 	Blocking *bool            `yaml:"blocking"`
 }
 
+type columnUpstream struct {
+	Table  string `yaml:"table"`
+	Column string `yaml:"column"`
+}
+
 type column struct {
-	Extends       string        `yaml:"extends"`
-	Name          string        `yaml:"name"`
-	Type          string        `yaml:"type"`
-	Description   string        `yaml:"description"`
-	Tests         []columnCheck `yaml:"checks"`
-	PrimaryKey    bool          `yaml:"primary_key"`
-	UpdateOnMerge bool          `yaml:"update_on_merge"`
+	Extends        string           `yaml:"extends"`
+	Name           string           `yaml:"name"`
+	Type           string           `yaml:"type"`
+	Description    string           `yaml:"description"`
+	Tests          []columnCheck    `yaml:"checks"`
+	PrimaryKey     bool             `yaml:"primary_key"`
+	UpdateOnMerge  bool             `yaml:"update_on_merge"`
+	PII            bool             `yaml:"pii"`
+	Classification string           `yaml:"classification"`
+	Tags           []string         `yaml:"tags"`
+	Upstreams      []columnUpstream `yaml:"upstreams"`
 }
 
 type secretMapping struct {
@@ -231,6 +244,39 @@ type athena struct {
 	QueryResultsPath string `yaml:"query_results_path"`
 }
 
+type freshness struct {
+	Column     string `yaml:"column"`
+	WarnAfter  string `yaml:"warn_after"`
+	ErrorAfter string `yaml:"error_after"`
+}
+
+type anomalyCheck struct {
+	Name             string  `yaml:"name"`
+	Metric           string  `yaml:"metric"`
+	Column           string  `yaml:"column"`
+	Window           int     `yaml:"window"`
+	MinHistory       int     `yaml:"min_history"`
+	RecoverAfter     int     `yaml:"recover_after"`
+	MaxStddev        float64 `yaml:"max_stddev"`
+	MaxChangePercent float64 `yaml:"max_change_percent"`
+	Severity         string  `yaml:"severity"`
+}
+
+type sqlTestFixture struct {
+	Table string                   `yaml:"table"`
+	Rows  []map[string]interface{} `yaml:"rows"`
+	CSV   string                   `yaml:"csv"`
+}
+
+type sqlTest struct {
+	Name   string           `yaml:"name"`
+	Given  []sqlTestFixture `yaml:"given"`
+	Expect struct {
+		Rows []map[string]interface{} `yaml:"rows"`
+		CSV  string                   `yaml:"csv"`
+	} `yaml:"expect"`
+}
+
 type taskDefinition struct {
 	Name            string            `yaml:"name"`
 	URI             string            `yaml:"uri"`
@@ -250,8 +296,13 @@ type taskDefinition struct {
 	Columns         []column          `yaml:"columns"`
 	CustomChecks    []customCheck     `yaml:"custom_checks"`
 	Tags            []string          `yaml:"tags"`
+	Metadata        map[string]string `yaml:"metadata"`
+	Contract        string            `yaml:"contract"`
 	Snowflake       snowflake         `yaml:"snowflake"`
 	Athena          athena            `yaml:"athena"`
+	Freshness       *freshness        `yaml:"freshness"`
+	Anomalies       []anomalyCheck    `yaml:"anomalies"`
+	Tests           []sqlTest         `yaml:"tests"`
 }
 
 func CreateTaskFromYamlDefinition(fs afero.Fs) TaskCreator {
@@ -357,6 +408,14 @@ func ConvertYamlToTask(content []byte) (*Asset, error) {
 			}
 		}
 
+		columnUpstreams := make([]*UpstreamColumn, 0, len(column.Upstreams))
+		for _, upstream := range column.Upstreams {
+			columnUpstreams = append(columnUpstreams, &UpstreamColumn{
+				Column: strings.TrimSpace(upstream.Column),
+				Table:  strings.TrimSpace(upstream.Table),
+			})
+		}
+
 		columns[index] = Column{
 			Name:            column.Name,
 			Type:            strings.TrimSpace(column.Type),
@@ -364,9 +423,12 @@ func ConvertYamlToTask(content []byte) (*Asset, error) {
 			Checks:          tests,
 			PrimaryKey:      column.PrimaryKey,
 			UpdateOnMerge:   column.UpdateOnMerge,
+			PII:             column.PII,
+			Classification:  strings.TrimSpace(column.Classification),
+			Tags:            column.Tags,
 			EntityAttribute: entityDefinition,
 			Extends:         column.Extends,
-			Upstreams:       make([]*UpstreamColumn, 0),
+			Upstreams:       columnUpstreams,
 		}
 	}
 
@@ -404,10 +466,58 @@ func ConvertYamlToTask(content []byte) (*Asset, error) {
 		Extends:         definition.Extends,
 		Columns:         columns,
 		CustomChecks:    make([]CustomCheck, len(definition.CustomChecks)),
+		Metadata:        definition.Metadata,
+		Contract:        ContractMode(strings.ToLower(strings.TrimSpace(definition.Contract))),
 		Snowflake:       SnowflakeConfig{Warehouse: definition.Snowflake.Warehouse},
 		Athena:          AthenaConfig{Location: definition.Athena.QueryResultsPath},
 	}
 
+	if definition.Freshness != nil {
+		task.Freshness = &FreshnessCheck{
+			Column:     strings.TrimSpace(definition.Freshness.Column),
+			WarnAfter:  strings.TrimSpace(definition.Freshness.WarnAfter),
+			ErrorAfter: strings.TrimSpace(definition.Freshness.ErrorAfter),
+		}
+	}
+
+	for _, check := range definition.Anomalies {
+		anomaly := AnomalyCheck{
+			Name:             strings.TrimSpace(check.Name),
+			Metric:           strings.ToLower(strings.TrimSpace(check.Metric)),
+			Column:           strings.TrimSpace(check.Column),
+			Window:           check.Window,
+			MinHistory:       check.MinHistory,
+			RecoverAfter:     check.RecoverAfter,
+			MaxStddev:        check.MaxStddev,
+			MaxChangePercent: check.MaxChangePercent,
+			Severity:         strings.ToLower(strings.TrimSpace(check.Severity)),
+		}
+		if anomaly.Name == "" {
+			anomaly.Name = AnomalyCheckName(anomaly.Metric, anomaly.Column)
+		}
+
+		task.Anomalies = append(task.Anomalies, anomaly)
+	}
+
+	for _, test := range definition.Tests {
+		sqlTest := SQLTest{
+			Name: strings.TrimSpace(test.Name),
+			Expect: SQLTestExpectation{
+				Rows: test.Expect.Rows,
+				CSV:  strings.TrimSpace(test.Expect.CSV),
+			},
+		}
+		for _, fixture := range test.Given {
+			sqlTest.Given = append(sqlTest.Given, SQLTestFixture{
+				Table: strings.TrimSpace(fixture.Table),
+				Rows:  fixture.Rows,
+				CSV:   strings.TrimSpace(fixture.CSV),
+			})
+		}
+
+		task.Tests = append(task.Tests, sqlTest)
+	}
+
 	for index, check := range definition.CustomChecks {
 		// set the ID as the hash of the name
 		task.CustomChecks[index] = CustomCheck{
@@ -60,7 +60,10 @@ func TestCreateTaskFromYamlDefinition(t *testing.T) {
 					"param2": "value2",
 				},
 				Connection: "conn1",
-				Secrets:    []pipeline.SecretMapping{},
+				Metadata: map[string]string{
+					"query_tag.team": "growth",
+				},
+				Secrets: []pipeline.SecretMapping{},
 				Upstreams: []pipeline.Upstream{
 					{
 						Type:    "asset",
@@ -75,6 +78,40 @@ func TestCreateTaskFromYamlDefinition(t *testing.T) {
 					PartitionBy:    "dt",
 					IncrementalKey: "dt",
 				},
+				Freshness: &pipeline.FreshnessCheck{
+					Column:     "updated_at",
+					WarnAfter:  "12h",
+					ErrorAfter: "2d",
+				},
+				Anomalies: []pipeline.AnomalyCheck{
+					{Name: "row_count", Metric: "row_count", MaxStddev: 3},
+					{
+						Name:             "revenue",
+						Metric:           "sum",
+						Column:           "amount",
+						Window:           30,
+						MaxChangePercent: 50,
+						Severity:         "warning",
+					},
+				},
+				Tests: []pipeline.SQLTest{
+					{
+						Name: "totals per customer",
+						Given: []pipeline.SQLTestFixture{
+							{
+								Table: "raw.orders",
+								Rows: []map[string]interface{}{
+									{"customer_id": 1, "amount": 10.5},
+									{"customer_id": 1, "amount": nil},
+								},
+							},
+							{Table: "raw.customers", CSV: "fixtures/customers.csv"},
+						},
+						Expect: pipeline.SQLTestExpectation{
+							Rows: []map[string]interface{}{{"customer_id": 1, "total": 10.5}},
+						},
+					},
+				},
 				CustomChecks: make([]pipeline.CustomCheck, 0),
 				Columns: []pipeline.Column{
 					{
@@ -108,10 +145,15 @@ func TestCreateTaskFromYamlDefinition(t *testing.T) {
 						},
 					},
 					{
-						Name:        "col2",
-						Description: "column two",
-						Checks:      []pipeline.ColumnCheck{},
-						Upstreams:   make([]*pipeline.UpstreamColumn, 0),
+						Name:           "col2",
+						Description:    "column two",
+						PII:            true,
+						Classification: "pii",
+						Tags:           pipeline.EmptyStringArray{"gdpr"},
+						Checks:         []pipeline.ColumnCheck{},
+						Upstreams: []*pipeline.UpstreamColumn{
+							{Column: "col2", Table: "gcs-to-bq"},
+						},
 					},
 				},
 			},
@@ -2,7 +2,10 @@ package postgres
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	"github.com/bruin-data/bruin/pkg/ansisql"
 	"github.com/bruin-data/bruin/pkg/query"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -26,7 +29,12 @@ type connection interface {
 }
 
 func NewClient(ctx context.Context, c PgConfig) (*Client, error) {
-	conn, err := pgxpool.New(ctx, c.ToDBConnectionURI())
+	poolConfig, err := newPoolConfig(c.ToDBConnectionURI())
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -34,8 +42,24 @@ func NewClient(ctx context.Context, c PgConfig) (*Client, error) {
 	return &Client{connection: conn, config: c}, nil
 }
 
-func (c *Client) RunQueryWithoutResult(ctx context.Context, query *query.Query) error {
-	_, err := c.connection.Exec(ctx, query.String())
+// newPoolConfig parses the connection URI and sets the `application_name` of the connections to "bruin", unless the URI
+// already has one. The connections are shared by the assets that run at the same time, therefore the asset and the
+// run details are only sent in the comment prepended to each query.
+func newPoolConfig(uri string) (*pgxpool.Config, error) {
+	poolConfig, err := pgxpool.ParseConfig(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := poolConfig.ConnConfig.RuntimeParams["application_name"]; !ok {
+		poolConfig.ConnConfig.RuntimeParams["application_name"] = "bruin"
+	}
+
+	return poolConfig, nil
+}
+
+func (c *Client) RunQueryWithoutResult(ctx context.Context, queryObj *query.Query) error {
+	_, err := c.connection.Exec(ctx, query.AnnotateQueryWithComment(ctx, queryObj.String()))
 	if err != nil {
 		return err
 	}
@@ -48,8 +72,8 @@ func (c *Client) GetIngestrURI() (string, error) {
 }
 
 // Select runs a query and returns the results.
-func (c *Client) Select(ctx context.Context, query *query.Query) ([][]interface{}, error) {
-	rows, err := c.connection.Query(ctx, query.String())
+func (c *Client) Select(ctx context.Context, queryObj *query.Query) ([][]interface{}, error) {
+	rows, err := c.connection.Query(ctx, query.AnnotateQueryWithComment(ctx, queryObj.String()))
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +95,7 @@ func (c *Client) Select(ctx context.Context, query *query.Query) ([][]interface{
 }
 
 func (c *Client) SelectWithSchema(ctx context.Context, queryObj *query.Query) (*query.QueryResult, error) {
-	rows, err := c.connection.Query(ctx, queryObj.String())
+	rows, err := c.connection.Query(ctx, query.AnnotateQueryWithComment(ctx, queryObj.String()))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to execute query")
 	}
@@ -114,3 +138,106 @@ func (c *Client) Ping(ctx context.Context) error {
 
 	return nil
 }
+
+// GetTableColumns returns the columns of the given table in the order they are defined in the database. Tables without
+// a schema are looked up in the "public" schema.
+func (c *Client) GetTableColumns(ctx context.Context, tableName string) ([]*ansisql.DBColumn, error) {
+	schemaName, table, err := splitTableName(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.selectForTable(ctx, schemaName, table, func(schemaName, table string) string {
+		return fmt.Sprintf(
+			`SELECT c.column_name, c.data_type, c.is_nullable,
+       col_description((quote_ident(c.table_schema) || '.' || quote_ident(c.table_name))::regclass::oid, c.ordinal_position)
+FROM information_schema.columns c
+WHERE c.table_schema = '%s' AND c.table_name = '%s'
+ORDER BY c.ordinal_position`,
+			ansisql.EscapeSQLString(schemaName),
+			ansisql.EscapeSQLString(table),
+		)
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query column metadata for %s", tableName)
+	}
+
+	if len(rows) == 0 {
+		return nil, ansisql.ErrTableNotFound
+	}
+
+	return ansisql.ColumnsFromInformationSchemaRows(rows)
+}
+
+// GetTables returns the names of the tables and views in the given schema.
+func (c *Client) GetTables(ctx context.Context, schemaName string) ([]string, error) {
+	queryStr := fmt.Sprintf(
+		`SELECT table_name
+FROM information_schema.tables
+WHERE table_schema = '%s'
+ORDER BY table_name`,
+		ansisql.EscapeSQLString(schemaName),
+	)
+
+	rows, err := c.Select(ctx, &query.Query{Query: queryStr})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the tables in schema %s", schemaName)
+	}
+
+	return ansisql.StringsFromRows(rows), nil
+}
+
+// GetPrimaryKeyColumns returns the columns of the primary key of the given table, if any.
+func (c *Client) GetPrimaryKeyColumns(ctx context.Context, tableName string) ([]string, error) {
+	schemaName, table, err := splitTableName(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := c.selectForTable(ctx, schemaName, table, func(schemaName, table string) string {
+		return fmt.Sprintf(
+			`SELECT kcu.column_name
+FROM information_schema.table_constraints tc
+JOIN information_schema.key_column_usage kcu
+  ON tc.constraint_name = kcu.constraint_name AND tc.table_schema = kcu.table_schema AND tc.table_name = kcu.table_name
+WHERE tc.constraint_type = 'PRIMARY KEY' AND tc.table_schema = '%s' AND tc.table_name = '%s'
+ORDER BY kcu.ordinal_position`,
+			ansisql.EscapeSQLString(schemaName),
+			ansisql.EscapeSQLString(table),
+		)
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query the primary key of %s", tableName)
+	}
+
+	return ansisql.StringsFromRows(rows), nil
+}
+
+// selectForTable runs the query built for the schema and table names as they are given, e.g. as they are listed in the
+// database, and falls back to their lowercased versions if there are no results since Postgres folds the unquoted
+// identifiers in the asset names to lowercase.
+func (c *Client) selectForTable(ctx context.Context, schemaName, table string, buildQuery func(schemaName, table string) string) ([][]interface{}, error) {
+	rows, err := c.Select(ctx, &query.Query{Query: buildQuery(schemaName, table)})
+	if err != nil || len(rows) > 0 {
+		return rows, err
+	}
+
+	lowerSchema, lowerTable := strings.ToLower(schemaName), strings.ToLower(table)
+	if lowerSchema == schemaName && lowerTable == table {
+		return rows, nil
+	}
+
+	return c.Select(ctx, &query.Query{Query: buildQuery(lowerSchema, lowerTable)})
+}
+
+func splitTableName(tableName string) (string, string, error) {
+	tableComponents := strings.Split(tableName, ".")
+	switch len(tableComponents) {
+	case 1:
+		return "public", tableComponents[0], nil
+	case 2:
+		return tableComponents[0], tableComponents[1], nil
+	default:
+		return "", "", errors.Errorf("table name must be in table or schema.table format, '%s' given", tableName)
+	}
+}
@@ -166,6 +166,38 @@ COMMIT;`,
 				"WHEN MATCHED THEN UPDATE SET name = source\\.name\n" +
 				"WHEN NOT MATCHED THEN INSERT\\(id, name\\) VALUES\\(id, name\\);$",
 		},
+		{
+			name: "create+replace with an enforced contract creates the table before inserting the casted rows",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type: pipeline.MaterializationTypeTable,
+				},
+				Contract: pipeline.ContractModeEnforced,
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "INTEGER", Checks: []pipeline.ColumnCheck{{Name: "not_null"}}},
+					{Name: "name", Type: "VARCHAR"},
+				},
+			},
+			query: "SELECT 1 AS id, 'a' AS name;",
+			want:  "^BEGIN TRANSACTION;\nDROP TABLE IF EXISTS my\\.asset;\nCREATE TABLE my\\.asset \\(id INTEGER NOT NULL, name VARCHAR\\);\nINSERT INTO my\\.asset \\(id, name\\) SELECT CAST\\(id AS INTEGER\\) AS id, CAST\\(name AS VARCHAR\\) AS name FROM \\(\nSELECT 1 AS id, 'a' AS name\n\\) AS __bruin_contract;\nCOMMIT;$",
+		},
+		{
+			name: "an enforced contract requires every column to have a type",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type: pipeline.MaterializationTypeTable,
+				},
+				Contract: pipeline.ContractModeEnforced,
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "INT64"},
+					{Name: "name"},
+				},
+			},
+			query:   "SELECT 1 AS id, 'a' AS name;",
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
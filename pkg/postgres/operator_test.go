@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type mockExtractor struct {
@@ -20,6 +21,10 @@ func (m *mockExtractor) ExtractQueriesFromString(content string) ([]*query.Query
 	return res.Get(0).([]*query.Query), res.Error(1)
 }
 
+func (m *mockExtractor) CloneForAsset(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) query.QueryExtractor {
+	return m
+}
+
 type mockMaterializer struct {
 	mock.Mock
 }
@@ -214,3 +219,90 @@ func TestBasicOperator_RunTask(t *testing.T) {
 		})
 	}
 }
+
+func TestMetadataQueries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		asset        *pipeline.Asset
+		policies     map[string]*pipeline.ClassificationPolicy
+		want         []string
+		wantRedshift []string
+		wantErr      string
+	}{
+		{
+			name: "assets without metadata are skipped",
+			asset: &pipeline.Asset{
+				Name:    "customers",
+				Columns: []pipeline.Column{{Name: "id"}},
+			},
+			want:         []string{},
+			wantRedshift: []string{},
+		},
+		{
+			name: "descriptions, owner, classifications, tags and grants",
+			asset: &pipeline.Asset{
+				Name:        "Sales.Customers",
+				Description: "the customers",
+				Owner:       "data-team",
+				Tags:        []string{"core"},
+				Columns: []pipeline.Column{
+					{Name: "id", Description: "the customer's id"},
+					{Name: "email", Description: "the e-mail address.", Classification: "pii", Tags: []string{"gdpr", "contact"}},
+					{Name: "country", Tags: []string{"geo"}},
+				},
+			},
+			policies: map[string]*pipeline.ClassificationPolicy{
+				"email": {PostgresGrantees: []string{"analysts", "support"}},
+			},
+			want: []string{
+				"COMMENT ON TABLE Sales.Customers IS 'the customers [owner: data-team; tags: core]'",
+				"COMMENT ON COLUMN Sales.Customers.id IS 'the customer''s id'",
+				"COMMENT ON COLUMN Sales.Customers.email IS 'the e-mail address. [classification: pii; tags: gdpr, contact]'",
+				"GRANT SELECT (email) ON Sales.Customers TO analysts",
+				"GRANT SELECT (email) ON Sales.Customers TO support",
+				"COMMENT ON COLUMN Sales.Customers.country IS '[tags: geo]'",
+			},
+			wantRedshift: []string{
+				"COMMENT ON TABLE Sales.Customers IS 'the customers [owner: data-team; tags: core]'",
+				"COMMENT ON COLUMN Sales.Customers.id IS 'the customer''s id'",
+				"COMMENT ON COLUMN Sales.Customers.email IS 'the e-mail address. [classification: pii; tags: gdpr, contact]'",
+				"COMMENT ON COLUMN Sales.Customers.country IS '[tags: geo]'",
+			},
+		},
+		{
+			name: "views are commented as views",
+			asset: &pipeline.Asset{
+				Name:            "sales.active_customers",
+				Description:     "the active customers",
+				Materialization: pipeline.Materialization{Type: pipeline.MaterializationTypeView},
+			},
+			want:         []string{"COMMENT ON VIEW sales.active_customers IS 'the active customers'"},
+			wantRedshift: []string{"COMMENT ON VIEW sales.active_customers IS 'the active customers'"},
+		},
+		{
+			name:    "invalid table name",
+			asset:   &pipeline.Asset{Name: "a.b.c"},
+			wantErr: "table name must be in table or schema.table format, 'a.b.c' given",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := MetadataQueries(tt.asset, tt.policies)
+			gotRedshift, redshiftErr := RedshiftMetadataQueries(tt.asset, tt.policies)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				require.EqualError(t, redshiftErr, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NoError(t, redshiftErr)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantRedshift, gotRedshift)
+		})
+	}
+}
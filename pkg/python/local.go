@@ -46,6 +46,14 @@ func log(ctx context.Context, message string) {
 }
 
 func (l *localPythonRunner) Run(ctx context.Context, execCtx *executionContext) error {
+	if execCtx.project != nil {
+		return errors.Errorf("the asset belongs to the Python project at '%s', pyproject.toml files are only supported when running without the --use-pip flag", execCtx.project.Path)
+	}
+
+	if execCtx.script != nil {
+		log(ctx, "the inline script metadata of the asset is ignored when running with pip")
+	}
+
 	pythonCommandForScript := fmt.Sprintf("%s -u -m %s", l.pathToPython, execCtx.module)
 	noDependencyCommand := &CommandInstance{
 		Name:    Shell,
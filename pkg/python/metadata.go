@@ -0,0 +1,84 @@
+package python
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	inlineMetadataDependencies   = regexp.MustCompile(`(?s)(?:^|\n)\s*dependencies\s*=\s*\[(.*?)\]`)
+	inlineMetadataRequiresPython = regexp.MustCompile(`(?m)^\s*requires-python\s*=\s*["']([^"']*)["']`)
+	inlineMetadataQuotedValue    = regexp.MustCompile(`"([^"]*)"|'([^']*)'`)
+)
+
+// ScriptMetadata is the inline script metadata of a Python file, as defined in PEP 723:
+//
+//	# /// script
+//	# requires-python = ">=3.11"
+//	# dependencies = [
+//	#   "requests<3",
+//	# ]
+//	# ///
+type ScriptMetadata struct {
+	RequiresPython string
+	Dependencies   []string
+}
+
+// ParseScriptMetadata extracts the inline script metadata from the given Python code, it returns nil if there is none.
+func ParseScriptMetadata(content string) *ScriptMetadata {
+	block, ok := findScriptMetadataBlock(content)
+	if !ok {
+		return nil
+	}
+
+	metadata := &ScriptMetadata{
+		Dependencies: make([]string, 0),
+	}
+
+	if match := inlineMetadataRequiresPython.FindStringSubmatch(block); match != nil {
+		metadata.RequiresPython = strings.TrimSpace(match[1])
+	}
+
+	if match := inlineMetadataDependencies.FindStringSubmatch(block); match != nil {
+		for _, value := range inlineMetadataQuotedValue.FindAllStringSubmatch(match[1], -1) {
+			dependency := strings.TrimSpace(value[1] + value[2])
+			if dependency != "" {
+				metadata.Dependencies = append(metadata.Dependencies, dependency)
+			}
+		}
+	}
+
+	return metadata
+}
+
+func findScriptMetadataBlock(content string) (string, bool) {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	start := -1
+	for i, line := range lines {
+		if strings.TrimRight(line, " \t") == "# /// script" {
+			start = i
+			break
+		}
+	}
+
+	if start == -1 {
+		return "", false
+	}
+
+	blockLines := make([]string, 0)
+	for _, line := range lines[start+1:] {
+		line = strings.TrimRight(line, " \t")
+		if line == "# ///" {
+			return strings.Join(blockLines, "\n"), true
+		}
+
+		if line != "#" && !strings.HasPrefix(line, "# ") {
+			return "", false
+		}
+
+		blockLines = append(blockLines, strings.TrimPrefix(strings.TrimPrefix(line, "#"), " "))
+	}
+
+	return "", false
+}
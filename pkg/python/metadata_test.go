@@ -0,0 +1,63 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScriptMetadata(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    *ScriptMetadata
+	}{
+		{
+			name:    "no metadata block",
+			content: "import requests\nprint('hello')",
+			want:    nil,
+		},
+		{
+			name: "dependencies and python version are parsed",
+			content: `""" @bruin
+name: my.asset
+@bruin """
+
+# /// script
+# requires-python = ">=3.12"
+# dependencies = [
+#   "requests<3",
+#   'rich',
+# ]
+# ///
+
+import requests
+`,
+			want: &ScriptMetadata{
+				RequiresPython: ">=3.12",
+				Dependencies:   []string{"requests<3", "rich"},
+			},
+		},
+		{
+			name:    "empty dependencies on a single line",
+			content: "# /// script\n# dependencies = []\n# ///\n",
+			want: &ScriptMetadata{
+				Dependencies: []string{},
+			},
+		},
+		{
+			name:    "unterminated blocks are ignored",
+			content: "# /// script\n# dependencies = [\"requests\"]\nimport requests\n",
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, ParseScriptMetadata(tt.content))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"github.com/bruin-data/bruin/pkg/config"
@@ -22,6 +23,8 @@ type executionContext struct {
 	repo            *git.Repo
 	module          string
 	requirementsTxt string
+	project         *PythonProject
+	script          *ScriptMetadata
 
 	envVariables map[string]string
 	pipeline     *pipeline.Pipeline
@@ -31,6 +34,7 @@ type executionContext struct {
 type modulePathFinder interface {
 	FindModulePath(repo *git.Repo, executable *pipeline.ExecutableFile) (string, error)
 	FindRequirementsTxtInPath(path string, executable *pipeline.ExecutableFile) (string, error)
+	FindPythonProjectInPath(path string, executable *pipeline.ExecutableFile) (*PythonProject, error)
 }
 
 type repoFinder interface {
@@ -128,14 +132,15 @@ func (o *LocalOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pi
 
 	logger.Debugf("using module path: %s", module)
 
-	requirementsTxt, err := o.module.FindRequirementsTxtInPath(repo.Path, &t.ExecutableFile)
-	if err != nil {
-		var noReqsError *NoRequirementsFoundError
-		switch {
-		case !errors.As(err, &noReqsError):
-			return errors.Wrap(err, "failed to find requirements.txt")
-		default:
-			//
+	var requirementsTxt string
+	var project *PythonProject
+	script := ParseScriptMetadata(t.ExecutableFile.Content)
+	if script != nil {
+		logger.Debugf("using the inline script metadata of the asset, dependencies: %v", script.Dependencies)
+	} else {
+		requirementsTxt, project, err = o.findDependencies(repo, &t.ExecutableFile)
+		if err != nil {
+			return err
 		}
 	}
 
@@ -164,6 +169,8 @@ func (o *LocalOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pi
 		repo:            repo,
 		module:          module,
 		requirementsTxt: requirementsTxt,
+		project:         project,
+		script:          script,
 		pipeline:        p,
 		asset:           t,
 		envVariables:    envVariables,
@@ -175,6 +182,34 @@ func (o *LocalOperator) RunTask(ctx context.Context, p *pipeline.Pipeline, t *pi
 	return nil
 }
 
+// findDependencies looks for the requirements.txt and pyproject.toml files closest to the asset, and returns the one
+// that is nearest to it. If both of them are in the same folder the Python project takes precedence.
+func (o *LocalOperator) findDependencies(repo *git.Repo, executable *pipeline.ExecutableFile) (string, *PythonProject, error) {
+	requirementsTxt, err := o.module.FindRequirementsTxtInPath(repo.Path, executable)
+	if err != nil {
+		var noReqsError *NoRequirementsFoundError
+		if !errors.As(err, &noReqsError) {
+			return "", nil, errors.Wrap(err, "failed to find requirements.txt")
+		}
+	}
+
+	project, err := o.module.FindPythonProjectInPath(repo.Path, executable)
+	if err != nil {
+		var noProjectError *NoPythonProjectFoundError
+		if !errors.As(err, &noProjectError) {
+			return "", nil, errors.Wrap(err, "failed to find pyproject.toml")
+		}
+
+		return requirementsTxt, nil, nil
+	}
+
+	if requirementsTxt != "" && len(filepath.Dir(requirementsTxt)) > len(project.Path) {
+		return requirementsTxt, nil, nil
+	}
+
+	return "", project, nil
+}
+
 func findPathToExecutable(alternatives []string) (string, error) {
 	for _, alternative := range alternatives {
 		path, err := exec.LookPath(alternative)
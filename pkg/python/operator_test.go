@@ -33,6 +33,15 @@ func (m *mockModuleFinder) FindRequirementsTxtInPath(path string, executable *pi
 	return args.Get(0).(string), args.Error(1)
 }
 
+func (m *mockModuleFinder) FindPythonProjectInPath(path string, executable *pipeline.ExecutableFile) (*PythonProject, error) {
+	args := m.Called(path, executable)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*PythonProject), args.Error(1)
+}
+
 type mockRunner struct {
 	mock.Mock
 }
@@ -77,6 +86,14 @@ func TestLocalOperator_RunTask(t *testing.T) {
 			},
 		},
 	}
+	assetWithScriptMetadata := &pipeline.Asset{
+		Name: "my-asset",
+		ExecutableFile: pipeline.ExecutableFile{
+			Path:    "/path/to/file.py",
+			Content: "# /// script\n# dependencies = [\"requests<3\"]\n# ///\nprint('hello')",
+		},
+	}
+
 	tests := []struct {
 		name    string
 		task    *pipeline.Asset
@@ -135,6 +152,9 @@ func TestLocalOperator_RunTask(t *testing.T) {
 				mf.On("FindRequirementsTxtInPath", repo.Path, mock.Anything).
 					Return("", &NoRequirementsFoundError{})
 
+				mf.On("FindPythonProjectInPath", repo.Path, mock.Anything).
+					Return(nil, &NoPythonProjectFoundError{})
+
 				runner.On("Run", mock.Anything, &executionContext{
 					repo:            repo,
 					module:          "path.to.module",
@@ -162,6 +182,9 @@ func TestLocalOperator_RunTask(t *testing.T) {
 				mf.On("FindRequirementsTxtInPath", repo.Path, mock.Anything).
 					Return("", &NoRequirementsFoundError{})
 
+				mf.On("FindPythonProjectInPath", repo.Path, mock.Anything).
+					Return(nil, &NoPythonProjectFoundError{})
+
 				msf.On("GetSecretByKey", "key1").Return("value1", nil)
 				msf.On("GetSecretByKey", "key2").Return("value2", nil)
 
@@ -194,6 +217,9 @@ func TestLocalOperator_RunTask(t *testing.T) {
 				mf.On("FindRequirementsTxtInPath", repo.Path, mock.Anything).
 					Return("/path/to/requirements.txt", nil)
 
+				mf.On("FindPythonProjectInPath", repo.Path, mock.Anything).
+					Return(nil, &NoPythonProjectFoundError{})
+
 				runner.On("Run", mock.Anything, &executionContext{
 					repo:            repo,
 					module:          "path.to.module",
@@ -206,6 +232,88 @@ func TestLocalOperator_RunTask(t *testing.T) {
 			},
 			wantErr: assert.Error,
 		},
+		{
+			name: "should call runner with the Python project if it is closer than the requirements.txt file",
+			task: task,
+			setup: func(rf *mockRepoFinder, mf *mockModuleFinder, runner *mockRunner, msf *mockSecretFinder) {
+				repo := &git.Repo{Path: "/path/to/repo"}
+				rf.On("Repo", "/path/to/file.py").
+					Return(repo, nil)
+
+				mf.On("FindModulePath", repo, mock.Anything).
+					Return("path.to.module", nil)
+
+				mf.On("FindRequirementsTxtInPath", repo.Path, mock.Anything).
+					Return("/path/to/repo/requirements.txt", nil)
+
+				project := &PythonProject{Path: "/path/to/repo/project", Locked: true}
+				mf.On("FindPythonProjectInPath", repo.Path, mock.Anything).
+					Return(project, nil)
+
+				runner.On("Run", mock.Anything, &executionContext{
+					repo:    repo,
+					module:  "path.to.module",
+					project: project,
+					asset:   task,
+					envVariables: map[string]string{
+						"BRUIN_ASSET": "my-asset",
+					},
+				}).Return(assert.AnError)
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "should call runner with the requirements.txt file if it is closer than the Python project",
+			task: task,
+			setup: func(rf *mockRepoFinder, mf *mockModuleFinder, runner *mockRunner, msf *mockSecretFinder) {
+				repo := &git.Repo{Path: "/path/to/repo"}
+				rf.On("Repo", "/path/to/file.py").
+					Return(repo, nil)
+
+				mf.On("FindModulePath", repo, mock.Anything).
+					Return("path.to.module", nil)
+
+				mf.On("FindRequirementsTxtInPath", repo.Path, mock.Anything).
+					Return("/path/to/repo/project/assets/requirements.txt", nil)
+
+				mf.On("FindPythonProjectInPath", repo.Path, mock.Anything).
+					Return(&PythonProject{Path: "/path/to/repo/project"}, nil)
+
+				runner.On("Run", mock.Anything, &executionContext{
+					repo:            repo,
+					module:          "path.to.module",
+					requirementsTxt: "/path/to/repo/project/assets/requirements.txt",
+					asset:           task,
+					envVariables: map[string]string{
+						"BRUIN_ASSET": "my-asset",
+					},
+				}).Return(assert.AnError)
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "should use the inline script metadata without looking for dependency files",
+			task: assetWithScriptMetadata,
+			setup: func(rf *mockRepoFinder, mf *mockModuleFinder, runner *mockRunner, msf *mockSecretFinder) {
+				repo := &git.Repo{Path: "/path/to/repo"}
+				rf.On("Repo", "/path/to/file.py").
+					Return(repo, nil)
+
+				mf.On("FindModulePath", repo, mock.Anything).
+					Return("path.to.module", nil)
+
+				runner.On("Run", mock.Anything, &executionContext{
+					repo:   repo,
+					module: "path.to.module",
+					script: &ScriptMetadata{Dependencies: []string{"requests<3"}},
+					asset:  assetWithScriptMetadata,
+					envVariables: map[string]string{
+						"BRUIN_ASSET": "my-asset",
+					},
+				}).Return(assert.AnError)
+			},
+			wantErr: assert.Error,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
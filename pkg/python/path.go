@@ -74,3 +74,39 @@ func findFileUntilParent(file, startDir, stopDir string) string {
 
 	return ""
 }
+
+type NoPythonProjectFoundError struct{}
+
+func (m *NoPythonProjectFoundError) Error() string {
+	return "no pyproject.toml file found for the given module"
+}
+
+// PythonProject is a Python project defined by a pyproject.toml file.
+type PythonProject struct {
+	// Path is the directory that contains the pyproject.toml file.
+	Path string
+	// Locked is true if there is a uv.lock file next to the pyproject.toml file.
+	Locked bool
+}
+
+func (m *ModulePathFinder) FindPythonProjectInPath(path string, executable *pipeline.ExecutableFile) (*PythonProject, error) {
+	lowerExecutable := filepath.Clean(strings.ToLower(executable.Path))
+	if !strings.HasPrefix(lowerExecutable, strings.ToLower(path)) {
+		return nil, errors.New("executable is not in the repository to find the Python project")
+	}
+
+	executablePath := filepath.Clean(executable.Path)
+
+	pyprojectToml := findFileUntilParent("pyproject.toml", filepath.Dir(executablePath), path)
+	if pyprojectToml == "" {
+		return nil, &NoPythonProjectFoundError{}
+	}
+
+	projectPath := filepath.Dir(pyprojectToml)
+	_, err := os.Stat(filepath.Join(projectPath, "uv.lock"))
+
+	return &PythonProject{
+		Path:   projectPath,
+		Locked: err == nil,
+	}, nil
+}
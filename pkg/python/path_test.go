@@ -184,3 +184,61 @@ func TestFindRequirementsTxt(t *testing.T) {
 		})
 	}
 }
+
+func TestFindPythonProjectInPath(t *testing.T) {
+	t.Parallel()
+
+	abs := func(path string) string {
+		absPath, err := filepath.Abs(path)
+		assert.NoError(t, err)
+		return absPath
+	}
+
+	repoPath := abs("./testdata/projectfinder")
+
+	tests := []struct {
+		name       string
+		executable string
+		want       *PythonProject
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "the project is in a parent folder and has a lockfile",
+			executable: abs("./testdata/projectfinder/locked/assets/nested/main.py"),
+			want: &PythonProject{
+				Path:   abs("./testdata/projectfinder/locked"),
+				Locked: true,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:       "the project is next to the script and has no lockfile",
+			executable: abs("./testdata/projectfinder/unlocked/main.py"),
+			want: &PythonProject{
+				Path:   abs("./testdata/projectfinder/unlocked"),
+				Locked: false,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:       "no pyproject.toml file found",
+			executable: abs("./testdata/projectfinder/main.py"),
+			want:       nil,
+			wantErr: func(t assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				_, ok := err.(*NoPythonProjectFoundError) //nolint:errorlint
+				return ok
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			finder := &ModulePathFinder{}
+			got, err := finder.FindPythonProjectInPath(repoPath, &pipeline.ExecutableFile{Path: tt.executable})
+
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -34,6 +34,9 @@ var AvailablePythonVersions = map[string]bool{
 
 const (
 	UvVersion               = "0.5.0"
+	defaultPythonVersion    = "3.11"
+	pythonVersionParameter  = "python_version"
+	pyarrowDependency       = "pyarrow==18.0.0"
 	pythonVersionForIngestr = "3.11"
 	ingestrVersion          = "0.13.17"
 )
@@ -165,13 +168,9 @@ func (u *UvPythonRunner) Run(ctx context.Context, execCtx *executionContext) err
 
 	u.binaryFullPath = binaryFullPath
 
-	pythonVersion := "3.11"
-	if execCtx.asset.Image != "" {
-		image := execCtx.asset.Image
-		parts := strings.Split(image, ":")
-		if len(parts) > 1 && parts[0] == "python" && AvailablePythonVersions[parts[1]] {
-			pythonVersion = parts[1]
-		}
+	pythonVersion, err := resolvePythonVersion(execCtx)
+	if err != nil {
+		return err
 	}
 
 	if execCtx.asset.Materialization.Type == "" {
@@ -181,6 +180,75 @@ func (u *UvPythonRunner) Run(ctx context.Context, execCtx *executionContext) err
 	return u.runWithMaterialization(ctx, execCtx, pythonVersion)
 }
 
+// resolvePythonVersion returns the Python version to run the asset with. The `python_version` parameter takes precedence
+// over the image of the asset, and the version required by the inline script metadata is used if neither is set.
+// An empty version means the asset belongs to a Python project and uv picks the version the project requires.
+func resolvePythonVersion(execCtx *executionContext) (string, error) {
+	asset := execCtx.asset
+	if version, ok := asset.Parameters[pythonVersionParameter]; ok {
+		version = strings.TrimSpace(version)
+		if !AvailablePythonVersions[version] {
+			return "", errors.Errorf("unsupported Python version '%s' in the '%s' parameter, supported versions are: %s", version, pythonVersionParameter, strings.Join(supportedPythonVersions(), ", "))
+		}
+
+		return version, nil
+	}
+
+	if asset.Image != "" {
+		parts := strings.Split(asset.Image, ":")
+		if len(parts) > 1 && parts[0] == "python" && AvailablePythonVersions[parts[1]] {
+			return parts[1], nil
+		}
+	}
+
+	if execCtx.script != nil && execCtx.script.RequiresPython != "" {
+		return execCtx.script.RequiresPython, nil
+	}
+
+	if execCtx.project != nil {
+		return "", nil
+	}
+
+	return defaultPythonVersion, nil
+}
+
+func supportedPythonVersions() []string {
+	versions := make([]string, 0, len(AvailablePythonVersions))
+	for version := range AvailablePythonVersions {
+		versions = append(versions, version)
+	}
+	slices.Sort(versions)
+
+	return versions
+}
+
+// dependencyFlags builds the `uv run` flags that install the dependencies of the asset. Python projects are run in
+// their own environment, which uv keeps in the project folder and syncs only when the dependencies change, while
+// requirements.txt files and inline script metadata are installed into environments cached by uv.
+func dependencyFlags(execCtx *executionContext, pythonVersion string) []string {
+	flags := make([]string, 0)
+	if pythonVersion != "" {
+		flags = append(flags, "--python", pythonVersion)
+	}
+
+	switch {
+	case execCtx.script != nil:
+		flags = append(flags, "--no-project")
+		for _, dependency := range execCtx.script.Dependencies {
+			flags = append(flags, "--with", dependency)
+		}
+	case execCtx.project != nil:
+		flags = append(flags, "--project", execCtx.project.Path)
+		if execCtx.project.Locked {
+			flags = append(flags, "--frozen")
+		}
+	case execCtx.requirementsTxt != "":
+		flags = append(flags, "--with-requirements", execCtx.requirementsTxt)
+	}
+
+	return flags
+}
+
 func (u *UvPythonRunner) RunIngestr(ctx context.Context, args, extraPackages []string, repo *git.Repo) error {
 	binaryFullPath, err := u.UvInstaller.EnsureUvInstalled(ctx)
 	if err != nil {
@@ -209,11 +277,7 @@ func (u *UvPythonRunner) RunIngestr(ctx context.Context, args, extraPackages []s
 }
 
 func (u *UvPythonRunner) runWithNoMaterialization(ctx context.Context, execCtx *executionContext, pythonVersion string) error {
-	flags := []string{"run", "--python", pythonVersion}
-	if execCtx.requirementsTxt != "" {
-		flags = append(flags, "--with-requirements", execCtx.requirementsTxt)
-	}
-
+	flags := append([]string{"run"}, dependencyFlags(execCtx, pythonVersion)...)
 	flags = append(flags, "--module", execCtx.module)
 
 	noDependencyCommand := &CommandInstance{
@@ -257,12 +321,8 @@ func (u *UvPythonRunner) runWithMaterialization(ctx context.Context, execCtx *ex
 		return fmt.Errorf("failed to write to temp file: %w", err)
 	}
 
-	flags := []string{"run", "--python", pythonVersion}
-	if execCtx.requirementsTxt != "" {
-		flags = append(flags, "--with-requirements", execCtx.requirementsTxt)
-	}
-
-	flags = append(flags, tempPyScript.Name())
+	flags := append([]string{"run"}, dependencyFlags(execCtx, pythonVersion)...)
+	flags = append(flags, "--with", pyarrowDependency, tempPyScript.Name())
 
 	err = u.Cmd.Run(ctx, execCtx.repo, &CommandInstance{
 		Name:    u.binaryFullPath,
@@ -412,12 +472,6 @@ func (u *UvPythonRunner) ingestrInstallCmd(ctx context.Context, pkgs []string) [
 }
 
 const PythonArrowTemplate = `
-# /// script
-# dependencies = [
-#   "pyarrow==18.0.0"
-# ]
-# ///
-
 import sys
 import importlib.util
 from pathlib import Path
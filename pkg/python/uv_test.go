@@ -117,6 +117,103 @@ func Test_uvPythonRunner_Run(t *testing.T) {
 			},
 			wantErr: assert.Error,
 		},
+		{
+			name: "locked Python projects are run in their own environment with the version they require",
+			fields: func() *fields {
+				cmd := new(mockCmd)
+				cmd.On("Run", mock.Anything, repo, &CommandInstance{
+					Name: "~/.bruin/uv",
+					Args: []string{"run", "--project", "/path/to/project", "--frozen", "--module", module},
+				}).Return(assert.AnError)
+
+				inst := new(mockUvInstaller)
+				inst.On("EnsureUvInstalled", mock.Anything).Return("~/.bruin/uv", nil)
+
+				return &fields{
+					cmd:         cmd,
+					uvInstaller: inst,
+				}
+			},
+			execCtx: &executionContext{
+				repo:    repo,
+				module:  module,
+				project: &PythonProject{Path: "/path/to/project", Locked: true},
+				asset:   &pipeline.Asset{},
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "the python_version parameter takes precedence over the image",
+			fields: func() *fields {
+				cmd := new(mockCmd)
+				cmd.On("Run", mock.Anything, repo, &CommandInstance{
+					Name: "~/.bruin/uv",
+					Args: []string{"run", "--python", "3.12", "--project", "/path/to/project", "--module", module},
+				}).Return(assert.AnError)
+
+				inst := new(mockUvInstaller)
+				inst.On("EnsureUvInstalled", mock.Anything).Return("~/.bruin/uv", nil)
+
+				return &fields{
+					cmd:         cmd,
+					uvInstaller: inst,
+				}
+			},
+			execCtx: &executionContext{
+				repo:    repo,
+				module:  module,
+				project: &PythonProject{Path: "/path/to/project"},
+				asset:   &pipeline.Asset{Image: "python:3.13", Parameters: map[string]string{"python_version": "3.12"}},
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "inline script metadata is installed without the project",
+			fields: func() *fields {
+				cmd := new(mockCmd)
+				cmd.On("Run", mock.Anything, repo, &CommandInstance{
+					Name: "~/.bruin/uv",
+					Args: []string{"run", "--python", ">=3.12", "--no-project", "--with", "requests<3", "--with", "rich", "--module", module},
+				}).Return(assert.AnError)
+
+				inst := new(mockUvInstaller)
+				inst.On("EnsureUvInstalled", mock.Anything).Return("~/.bruin/uv", nil)
+
+				return &fields{
+					cmd:         cmd,
+					uvInstaller: inst,
+				}
+			},
+			execCtx: &executionContext{
+				repo:   repo,
+				module: module,
+				script: &ScriptMetadata{RequiresPython: ">=3.12", Dependencies: []string{"requests<3", "rich"}},
+				asset:  &pipeline.Asset{},
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "unsupported python versions are rejected",
+			fields: func() *fields {
+				cmd := new(mockCmd)
+
+				inst := new(mockUvInstaller)
+				inst.On("EnsureUvInstalled", mock.Anything).Return("~/.bruin/uv", nil)
+
+				return &fields{
+					cmd:         cmd,
+					uvInstaller: inst,
+				}
+			},
+			execCtx: &executionContext{
+				repo:   repo,
+				module: module,
+				asset:  &pipeline.Asset{Parameters: map[string]string{"python_version": "2.7"}},
+			},
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.ErrorContains(t, err, "unsupported Python version '2.7'")
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
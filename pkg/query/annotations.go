@@ -0,0 +1,66 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+type annotationsContextKey struct{}
+
+// Annotations are the key-value pairs attached to the queries bruin sends to a warehouse, so that the query history
+// of the warehouse can be joined back to the pipeline runs.
+type Annotations map[string]string
+
+// WithAnnotations returns a copy of the context that carries the given annotations to the database clients.
+func WithAnnotations(ctx context.Context, annotations Annotations) context.Context {
+	return context.WithValue(ctx, annotationsContextKey{}, annotations)
+}
+
+// AnnotationsFromContext returns the annotations stored in the context, or nil if there are none.
+func AnnotationsFromContext(ctx context.Context) Annotations {
+	annotations, ok := ctx.Value(annotationsContextKey{}).(Annotations)
+	if !ok {
+		return nil
+	}
+
+	return annotations
+}
+
+// JSON returns the annotations as a JSON object with sorted keys.
+func (a Annotations) JSON() string {
+	if len(a) == 0 {
+		return ""
+	}
+
+	// json.Marshal sorts map keys, which keeps the output deterministic
+	res, err := json.Marshal(map[string]string(a))
+	if err != nil {
+		return ""
+	}
+
+	return string(res)
+}
+
+// Comment renders the annotations as a SQL block comment that can be prepended to a query.
+func (a Annotations) Comment() string {
+	encoded := a.JSON()
+	if encoded == "" {
+		return ""
+	}
+
+	// a "*/" inside a value would terminate the comment early
+	encoded = strings.ReplaceAll(encoded, "*/", "* /")
+	return "/* @bruin" + encoded + " */"
+}
+
+// AnnotateQueryWithComment prepends the annotations found in the context as a SQL comment to the given query.
+// The query is returned as is if there are no annotations in the context.
+func AnnotateQueryWithComment(ctx context.Context, queryString string) string {
+	comment := AnnotationsFromContext(ctx).Comment()
+	if comment == "" {
+		return queryString
+	}
+
+	return comment + "\n" + queryString
+}
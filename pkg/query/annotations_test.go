@@ -0,0 +1,50 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnotateQueryWithComment(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		annotations Annotations
+		query       string
+		want        string
+	}{
+		{
+			name:  "no annotations leaves the query untouched",
+			query: "SELECT 1",
+			want:  "SELECT 1",
+		},
+		{
+			name:        "annotations are prepended as a sorted JSON comment",
+			annotations: Annotations{"asset": "a1", "pipeline": "p1"},
+			query:       "SELECT 1",
+			want:        "/* @bruin{\"asset\":\"a1\",\"pipeline\":\"p1\"} */\nSELECT 1",
+		},
+		{
+			name:        "comment terminators are escaped",
+			annotations: Annotations{"asset": "a*/b"},
+			query:       "SELECT 1",
+			want:        "/* @bruin{\"asset\":\"a* /b\"} */\nSELECT 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			if tt.annotations != nil {
+				ctx = WithAnnotations(ctx, tt.annotations)
+			}
+
+			assert.Equal(t, tt.want, AnnotateQueryWithComment(ctx, tt.query))
+		})
+	}
+}
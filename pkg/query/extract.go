@@ -1,9 +1,12 @@
 package query
 
 import (
+	"context"
 	"regexp"
 	"strings"
 
+	"github.com/bruin-data/bruin/pkg/jinja"
+	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 )
@@ -70,6 +73,13 @@ var queryCommentRegex = regexp.MustCompile(`(?m)(?s)\/\*.*?\*\/|(^|\s)--.*?\n`)
 
 type renderer interface {
 	Render(query string) (string, error)
+	WithContext(values jinja.Context) jinja.RendererInterface
+}
+
+// QueryExtractor extracts the queries from the content of an asset.
+type QueryExtractor interface {
+	ExtractQueriesFromString(content string) ([]*Query, error)
+	CloneForAsset(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) QueryExtractor
 }
 
 // FileQuerySplitterExtractor is a regular file extractor, but it splits the queries in the given file into multiple
@@ -90,6 +100,14 @@ func (f FileQuerySplitterExtractor) ExtractQueriesFromString(content string) ([]
 	return splitQueries(cleanedUpQueries), nil
 }
 
+// CloneForAsset returns a copy of the extractor that renders the queries with the variables of the given asset.
+func (f FileQuerySplitterExtractor) CloneForAsset(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) QueryExtractor {
+	return FileQuerySplitterExtractor{
+		Fs:       f.Fs,
+		Renderer: f.Renderer.WithContext(pipeline.AssetRenderContext(ctx, p, t)),
+	}
+}
+
 func splitQueries(fileContent string) []*Query {
 	queries := make([]*Query, 0)
 	var sqlVariablesSeenSoFar []string
@@ -150,3 +168,11 @@ func (f *WholeFileExtractor) ExtractQueriesFromString(content string) ([]*Query,
 		},
 	}, nil
 }
+
+// CloneForAsset returns a copy of the extractor that renders the queries with the variables of the given asset.
+func (f *WholeFileExtractor) CloneForAsset(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) QueryExtractor {
+	return &WholeFileExtractor{
+		Fs:       f.Fs,
+		Renderer: f.Renderer.WithContext(pipeline.AssetRenderContext(ctx, p, t)),
+	}
+}
@@ -3,6 +3,7 @@ package query
 import (
 	"testing"
 
+	"github.com/bruin-data/bruin/pkg/jinja"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -22,6 +23,10 @@ func (m *mockNoOpRenderer) Render(template string) (string, error) {
 	return args.String(0), args.Error(1)
 }
 
+func (m *mockNoOpRenderer) WithContext(values jinja.Context) jinja.RendererInterface {
+	return m
+}
+
 func TestFileExtractor_ExtractQueriesFromString(t *testing.T) {
 	t.Parallel()
 
@@ -37,6 +37,8 @@ func (s TaskInstanceStatus) String() string {
 		return "succeeded"
 	case Skipped:
 		return "skipped"
+	case Warned:
+		return "warned"
 	}
 	return "unknown"
 }
@@ -57,6 +59,8 @@ func StatusFromString(status string) TaskInstanceStatus {
 		return Succeeded
 	case "skipped":
 		return Skipped
+	case "warned":
+		return Warned
 	default:
 		return -1
 	}
@@ -74,6 +78,10 @@ func (s TaskInstanceType) String() string {
 		return "custom_test"
 	case TaskInstanceTypeMetadataPush:
 		return "metadata_push"
+	case TaskInstanceTypeFreshnessCheck:
+		return "freshness_check"
+	case TaskInstanceTypeAnomalyCheck:
+		return "anomaly_check"
 	}
 	return "unknown"
 }
@@ -86,6 +94,7 @@ const (
 	UpstreamFailed
 	Succeeded
 	Skipped
+	Warned
 )
 
 const (
@@ -93,6 +102,8 @@ const (
 	TaskInstanceTypeColumnCheck
 	TaskInstanceTypeCustomCheck
 	TaskInstanceTypeMetadataPush
+	TaskInstanceTypeFreshnessCheck
+	TaskInstanceTypeAnomalyCheck
 )
 
 type TaskInstance interface {
@@ -124,26 +135,43 @@ type PipelineState struct {
 }
 
 type RunConfig struct {
-	Downstream        bool     `json:"downstream"`
-	StartDate         string   `json:"startDate"`
-	EndDate           string   `json:"endDate"`
-	Workers           int      `json:"workers"`
-	Environment       string   `json:"environment"`
-	Force             bool     `json:"force"`
-	PushMetadata      bool     `json:"pushMetadata"`
-	NoLogFile         bool     `json:"noLogFile"`
-	FullRefresh       bool     `json:"fullRefresh"`
-	UsePip            bool     `json:"useUV"`
-	Tag               string   `json:"tag"`
-	ExcludeTag        string   `json:"excludeTag"`
-	Only              []string `json:"only"`
-	Output            string   `json:"output"`
-	ExpUseWingetForUv bool     `json:"expUseWingetForUv"`
+	Downstream        bool              `json:"downstream"`
+	StartDate         string            `json:"startDate"`
+	EndDate           string            `json:"endDate"`
+	Workers           int               `json:"workers"`
+	Environment       string            `json:"environment"`
+	Force             bool              `json:"force"`
+	PushMetadata      bool              `json:"pushMetadata"`
+	NoLogFile         bool              `json:"noLogFile"`
+	FullRefresh       bool              `json:"fullRefresh"`
+	UsePip            bool              `json:"useUV"`
+	Tag               string            `json:"tag"`
+	ExcludeTag        string            `json:"excludeTag"`
+	Only              []string          `json:"only"`
+	Output            string            `json:"output"`
+	ExpUseWingetForUv bool              `json:"expUseWingetForUv"`
+	CheckSchema       bool              `json:"checkSchema"`
+	ChangedSince      string            `json:"changedSince"`
+	Smart             bool              `json:"smart"`
+	Strict            bool              `json:"strict"`
+	SampleFailingRows int64             `json:"sampleFailingRows"`
+	Variables         map[string]string `json:"variables"`
 }
 
 type PipelineAssetState struct {
-	Name   string `json:"name"`
-	Status string `json:"status"`
+	Name        string         `json:"name"`
+	Status      string         `json:"status"`
+	Fingerprint string         `json:"fingerprint,omitempty"`
+	SkipReason  string         `json:"skip_reason,omitempty"`
+	Failures    []*TaskFailure `json:"failures,omitempty"`
+}
+
+// TaskFailure is a task of an asset that failed or warned in a run, along with the sample of the rows that failed
+// it if the check fetched one.
+type TaskFailure struct {
+	Task   string     `json:"task"`
+	Error  string     `json:"error"`
+	Sample *RowSample `json:"sample,omitempty"`
 }
 
 type Metadata struct {
@@ -175,7 +203,7 @@ func (t *AssetInstance) GetStatus() TaskInstanceStatus {
 }
 
 func (t *AssetInstance) Completed() bool {
-	return t.status == Failed || t.status == Succeeded || t.status == UpstreamFailed || t.status == Skipped
+	return t.status == Failed || t.status == Succeeded || t.status == UpstreamFailed || t.status == Skipped || t.status == Warned
 }
 
 func (t *AssetInstance) Blocking() bool {
@@ -252,6 +280,42 @@ func (t *CustomCheckInstance) Blocking() bool {
 	return t.Check.Blocking.Bool()
 }
 
+type FreshnessCheckInstance struct {
+	*AssetInstance
+
+	Check *pipeline.FreshnessCheck
+}
+
+func (t *FreshnessCheckInstance) GetType() TaskInstanceType {
+	return TaskInstanceTypeFreshnessCheck
+}
+
+func (t *FreshnessCheckInstance) GetHumanReadableDescription() string {
+	return fmt.Sprintf("%s - Freshness Check '%s'", t.Asset.Name, t.Check.Column)
+}
+
+func (t *FreshnessCheckInstance) Blocking() bool {
+	return true
+}
+
+type AnomalyCheckInstance struct {
+	*AssetInstance
+
+	Check *pipeline.AnomalyCheck
+}
+
+func (t *AnomalyCheckInstance) GetType() TaskInstanceType {
+	return TaskInstanceTypeAnomalyCheck
+}
+
+func (t *AnomalyCheckInstance) GetHumanReadableDescription() string {
+	return fmt.Sprintf("%s - Anomaly Check '%s'", t.Asset.Name, t.Check.Name)
+}
+
+func (t *AnomalyCheckInstance) Blocking() bool {
+	return !t.Check.IsWarning()
+}
+
 type MetadataPushInstance struct {
 	*AssetInstance
 }
@@ -273,6 +337,73 @@ type TaskExecutionResult struct {
 	Error    error
 }
 
+// RowSample is a small set of rows fetched from the asset to show why a check failed.
+type RowSample struct {
+	Columns []string        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+}
+
+// NewRowSample converts the raw values returned by the drivers, such as byte slices, into printable values.
+func NewRowSample(columns []string, rows [][]interface{}) *RowSample {
+	sample := &RowSample{Columns: columns, Rows: make([][]interface{}, 0, len(rows))}
+	for _, row := range rows {
+		values := make([]interface{}, len(row))
+		for i, v := range row {
+			if b, ok := v.([]byte); ok {
+				v = string(b)
+			}
+			values[i] = v
+		}
+		sample.Rows = append(sample.Rows, values)
+	}
+
+	return sample
+}
+
+// FailingRowsError is returned by the checks that fetched a sample of the rows that failed them.
+type FailingRowsError struct {
+	Err    error
+	Sample *RowSample
+}
+
+func (e *FailingRowsError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FailingRowsError) Unwrap() error {
+	return e.Err
+}
+
+// FailingRowsSample returns the sample of the failing rows attached to the error, if there is any.
+func FailingRowsSample(err error) *RowSample {
+	var rowsErr *FailingRowsError
+	if errors.As(err, &rowsErr) {
+		return rowsErr.Sample
+	}
+
+	return nil
+}
+
+// WarningError is returned by the tasks that found an issue which must not fail the run, e.g. a freshness check that
+// passed its warning threshold, the scheduler marks these tasks as warned.
+type WarningError struct {
+	Err error
+}
+
+func (e *WarningError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *WarningError) Unwrap() error {
+	return e.Err
+}
+
+// IsWarning reports whether the error is only a warning.
+func IsWarning(err error) bool {
+	var warningErr *WarningError
+	return errors.As(err, &warningErr)
+}
+
 type InstancesByType map[TaskInstanceType][]TaskInstance
 
 func (i InstancesByType) AddUpstreamByType(instanceType TaskInstanceType, upstream TaskInstance) {
@@ -303,6 +434,11 @@ type Scheduler struct {
 	Results   chan *TaskExecutionResult
 
 	runID string
+
+	fingerprints         map[string]string
+	previousFingerprints map[string]string
+	skipReasons          map[string]string
+	failures             map[string][]*TaskFailure
 }
 
 func (s *Scheduler) InstanceCount() int {
@@ -335,6 +471,60 @@ func (s *Scheduler) MarkAsset(task *pipeline.Asset, status TaskInstanceStatus, d
 	}
 }
 
+// SkipUnchangedAssets marks the pending assets, along with their checks, as skipped if their fingerprint matches the
+// fingerprint of their last successful run in the given state. The fingerprints are stored in the state of this run,
+// and the names of the skipped assets are returned.
+func (s *Scheduler) SkipUnchangedAssets(fingerprints map[string]string, previous *PipelineState) []string {
+	s.fingerprints = fingerprints
+	s.previousFingerprints = fingerprintsFromState(previous)
+	s.skipReasons = make(map[string]string)
+
+	skipped := make([]string, 0)
+	for _, asset := range s.pipeline.Assets {
+		fingerprint, ok := fingerprints[asset.Name]
+		if !ok || fingerprint != s.previousFingerprints[asset.Name] {
+			continue
+		}
+
+		mainInstances := s.taskNameMap[asset.Name][TaskInstanceTypeMain]
+		if len(mainInstances) == 0 || mainInstances[0].GetStatus() != Pending {
+			continue
+		}
+
+		s.MarkAsset(asset, Skipped, false)
+		s.skipReasons[asset.Name] = "the asset, its upstream and the run parameters did not change since its last successful run"
+		skipped = append(skipped, asset.Name)
+	}
+
+	return skipped
+}
+
+// KeepPreviousFingerprints carries the fingerprints in the given state over to the state of this run, so that a run
+// without the smart mode does not reset the last successful runs that the next smart run compares against.
+func (s *Scheduler) KeepPreviousFingerprints(previous *PipelineState) {
+	s.previousFingerprints = fingerprintsFromState(previous)
+}
+
+func fingerprintsFromState(state *PipelineState) map[string]string {
+	fingerprints := make(map[string]string)
+	if state == nil {
+		return fingerprints
+	}
+
+	for _, assetState := range state.State {
+		if assetState.Fingerprint != "" {
+			fingerprints[assetState.Name] = assetState.Fingerprint
+		}
+	}
+
+	return fingerprints
+}
+
+// SkipReason returns the reason the asset was skipped by SkipUnchangedAssets, if any.
+func (s *Scheduler) SkipReason(assetName string) string {
+	return s.skipReasons[assetName]
+}
+
 func (s *Scheduler) MarkPendingInstancesByType(instanceType TaskInstanceType, status TaskInstanceStatus) {
 	for _, instance := range s.taskInstances {
 		if instance.GetStatus() != Pending {
@@ -404,6 +594,17 @@ func (s *Scheduler) markTaskInstanceFailedWithDownstream(instance TaskInstance)
 	s.MarkTaskInstanceIfNotSkipped(instance, Failed, false)
 }
 
+// WarnsOnFailure reports whether a failure of the task is only a warning, which is the case for the checks that do not
+// block the downstream assets.
+func WarnsOnFailure(t TaskInstance) bool {
+	switch t.GetType() {
+	case TaskInstanceTypeMain, TaskInstanceTypeMetadataPush:
+		return false
+	default:
+		return !t.Blocking()
+	}
+}
+
 func (s *Scheduler) GetTaskInstancesByStatus(status TaskInstanceStatus) []TaskInstance {
 	instances := make([]TaskInstance, 0)
 	for _, i := range s.taskInstances {
@@ -489,7 +690,38 @@ func NewScheduler(logger *zap.SugaredLogger, p *pipeline.Pipeline, runID string)
 			instances = append(instances, testInstance)
 		}
 
-		if p.MetadataPush.HasAnyEnabled() {
+		if task.Freshness != nil {
+			instances = append(instances, &FreshnessCheckInstance{
+				AssetInstance: &AssetInstance{
+					ID:         uuid.New().String(),
+					HumanID:    task.Name + ":freshness",
+					Pipeline:   p,
+					Asset:      task,
+					status:     Pending,
+					upstream:   make([]TaskInstance, 0),
+					downstream: make([]TaskInstance, 0),
+				},
+				Check: task.Freshness,
+			})
+		}
+
+		for _, c := range task.Anomalies {
+			instances = append(instances, &AnomalyCheckInstance{
+				AssetInstance: &AssetInstance{
+					ID:         uuid.New().String(),
+					HumanID:    fmt.Sprintf("%s:anomaly:%s", task.Name, c.Name),
+					Pipeline:   p,
+					Asset:      task,
+					status:     Pending,
+					upstream:   make([]TaskInstance, 0),
+					downstream: make([]TaskInstance, 0),
+				},
+				Check: &c,
+			})
+		}
+
+		// the platform flags are limited to the assets of their own platform, `--push-metadata` enables all the assets
+		if p.MetadataPush.IsEnabledForAsset(task) {
 			instances = append(instances, &MetadataPushInstance{
 				AssetInstance: &AssetInstance{
 					ID:         uuid.New().String(),
@@ -576,6 +808,8 @@ func (s *Scheduler) constructInstanceRelationships() {
 		// add the upstream-downstream relationships for the main task to its quality checks
 		s.taskNameMap[assetName].AddUpstreamByType(TaskInstanceTypeColumnCheck, ti)
 		s.taskNameMap[assetName].AddUpstreamByType(TaskInstanceTypeCustomCheck, ti)
+		s.taskNameMap[assetName].AddUpstreamByType(TaskInstanceTypeFreshnessCheck, ti)
+		s.taskNameMap[assetName].AddUpstreamByType(TaskInstanceTypeAnomalyCheck, ti)
 		s.taskNameMap[assetName].AddUpstreamByType(TaskInstanceTypeMetadataPush, ti)
 
 		for _, dep := range ti.GetAsset().Upstreams {
@@ -639,7 +873,12 @@ func (s *Scheduler) Tick(result *TaskExecutionResult) bool {
 		s.MarkTaskInstance(result.Instance, Succeeded, false)
 	}
 	if result.Error != nil {
-		s.markTaskInstanceFailedWithDownstream(result.Instance)
+		s.recordFailure(result)
+		if WarnsOnFailure(result.Instance) || IsWarning(result.Error) {
+			s.MarkTaskInstance(result.Instance, Warned, false)
+		} else {
+			s.markTaskInstanceFailedWithDownstream(result.Instance)
+		}
 	}
 
 	if s.hasPipelineFinished() {
@@ -660,6 +899,19 @@ func (s *Scheduler) Tick(result *TaskExecutionResult) bool {
 	return false
 }
 
+func (s *Scheduler) recordFailure(result *TaskExecutionResult) {
+	if s.failures == nil {
+		s.failures = make(map[string][]*TaskFailure)
+	}
+
+	assetName := result.Instance.GetAsset().Name
+	s.failures[assetName] = append(s.failures[assetName], &TaskFailure{
+		Task:   result.Instance.GetHumanID(),
+		Error:  result.Error.Error(),
+		Sample: FailingRowsSample(result.Error),
+	})
+}
+
 // Kickstart initiates the scheduler process by sending a "start" task for the processing.
 func (s *Scheduler) Kickstart() {
 	s.Tick(&TaskExecutionResult{
@@ -724,8 +976,11 @@ func (s *Scheduler) SavePipelineState(fs afero.Fs, param *RunConfig, runID, stat
 	for key, status := range dict {
 		result := GetStatusForTask(status)
 		state = append(state, &PipelineAssetState{
-			Name:   key,
-			Status: result.String(),
+			Name:        key,
+			Status:      result.String(),
+			Fingerprint: s.fingerprintToSave(key, result),
+			SkipReason:  s.skipReasons[key],
+			Failures:    s.failures[key],
 		})
 	}
 
@@ -749,6 +1004,37 @@ func (s *Scheduler) SavePipelineState(fs afero.Fs, param *RunConfig, runID, stat
 	return nil
 }
 
+// fingerprintToSave returns the fingerprint of the last successful run of the asset: the current one if the asset
+// succeeded or was skipped as unchanged in this run, none if any of its tasks failed, and the previous one otherwise.
+// The runs without the smart mode do not compute the fingerprints, they keep the previous ones.
+func (s *Scheduler) fingerprintToSave(assetName string, status TaskInstanceStatus) string {
+	if status == Failed {
+		return ""
+	}
+
+	if s.fingerprints == nil {
+		return s.previousFingerprints[assetName]
+	}
+
+	if _, ok := s.skipReasons[assetName]; ok {
+		return s.fingerprints[assetName]
+	}
+
+	mainInstances := s.taskNameMap[assetName][TaskInstanceTypeMain]
+	if len(mainInstances) == 0 {
+		return ""
+	}
+
+	switch mainInstances[0].GetStatus() {
+	case Succeeded:
+		return s.fingerprints[assetName]
+	case Skipped, Pending:
+		return s.previousFingerprints[assetName]
+	default:
+		return ""
+	}
+}
+
 func (s *Scheduler) RestoreState(state *PipelineState) error {
 	if s.pipeline.GetCompatibilityHash() != state.CompatibilityHash {
 		return errors.New("the pipeline has changed since the last run; please rerun the pipeline")
@@ -764,7 +1050,7 @@ func (s *Scheduler) RestoreState(state *PipelineState) error {
 			switch status {
 			case Failed.String(), UpstreamFailed.String(), Running.String(), Queued.String():
 				task.MarkAs(Pending)
-			case Skipped.String(), Succeeded.String():
+			case Skipped.String(), Succeeded.String(), Warned.String():
 				task.MarkAs(Skipped)
 			default:
 				return fmt.Errorf("unknown status: %s. Please report this issue at https://github.com/bruin-data/bruin/issues/new", status)
@@ -791,6 +1077,10 @@ func GetStatusForTask(tasks []TaskInstanceStatus) TaskInstanceStatus {
 		return Skipped
 	}
 
+	if dict[Warned] && !dict[Running] {
+		return Warned
+	}
+
 	if dict[Succeeded] && !dict[Running] {
 		return Succeeded
 	}
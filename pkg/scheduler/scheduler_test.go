@@ -8,6 +8,7 @@ import (
 
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/version"
+	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -52,6 +53,12 @@ func TestScheduler_GetStatusForTask(t *testing.T) {
 
 	result = GetStatusForTask([]TaskInstanceStatus{Succeeded, Running, Skipped})
 	assert.Equal(t, Pending.String(), result.String())
+
+	result = GetStatusForTask([]TaskInstanceStatus{Succeeded, Warned, Succeeded})
+	assert.Equal(t, Warned.String(), result.String())
+
+	result = GetStatusForTask([]TaskInstanceStatus{Warned, Failed})
+	assert.Equal(t, Failed.String(), result.String())
 }
 
 func TestScheduler_getScheduleableTasks(t *testing.T) {
@@ -634,3 +641,394 @@ func TestScheduler_RestoreState(t *testing.T) {
 	assert.Equal(t, expectedState.RunID, pipelineState.RunID, "RunID should match")
 	assert.Equal(t, expectedState.Version, pipelineState.Version, "Version should match")
 }
+
+func TestScheduler_SkipUnchangedAssets(t *testing.T) {
+	t.Parallel()
+
+	newPipeline := func() *pipeline.Pipeline {
+		return &pipeline.Pipeline{
+			Name: "test",
+			Assets: []*pipeline.Asset{
+				{
+					Name:      "task1",
+					Type:      "bq.sql",
+					Upstreams: []pipeline.Upstream{{Type: "asset", Value: "task2"}},
+				},
+				{
+					Name: "task2",
+					Type: "bq.sql",
+				},
+				{
+					Name: "task3",
+					Type: "bq.sql",
+					Columns: []pipeline.Column{
+						{Name: "id", Checks: []pipeline.ColumnCheck{{Name: "not_null"}}},
+					},
+				},
+				{
+					Name: "task4",
+					Type: "bq.sql",
+				},
+			},
+		}
+	}
+
+	previous := &PipelineState{
+		State: []*PipelineAssetState{
+			{Name: "task1", Status: "succeeded", Fingerprint: "old-fingerprint-1"},
+			{Name: "task2", Status: "succeeded", Fingerprint: "fingerprint-2"},
+			{Name: "task3", Status: "succeeded", Fingerprint: "fingerprint-3"},
+			{Name: "task4", Status: "succeeded", Fingerprint: "old-fingerprint-4"},
+		},
+	}
+	fingerprints := map[string]string{
+		"task1": "fingerprint-1",
+		"task2": "fingerprint-2",
+		"task3": "fingerprint-3",
+		"task4": "fingerprint-4",
+	}
+
+	fs := afero.NewMemMapFs()
+	s := NewScheduler(zap.NewNop().Sugar(), newPipeline(), "run")
+	s.MarkAll(Pending)
+
+	skipped := s.SkipUnchangedAssets(fingerprints, previous)
+	assert.Equal(t, []string{"task2", "task3"}, skipped)
+	assert.NotEmpty(t, s.SkipReason("task2"))
+	assert.Empty(t, s.SkipReason("task1"))
+
+	pendingAssets := make([]string, 0)
+	for _, instance := range s.GetTaskInstancesByStatus(Pending) {
+		pendingAssets = append(pendingAssets, instance.GetHumanID())
+	}
+	assert.ElementsMatch(t, []string{"task1", "task4"}, pendingAssets)
+
+	s.MarkAsset(&pipeline.Asset{Name: "task1"}, Succeeded, false)
+	s.MarkAsset(&pipeline.Asset{Name: "task4"}, Failed, false)
+
+	require.NoError(t, s.SavePipelineState(fs, &RunConfig{}, "run", "logs/runs"))
+	state, err := ReadState(fs, "logs/runs")
+	require.NoError(t, err)
+
+	saved := make(map[string]string)
+	for _, assetState := range state.State {
+		saved[assetState.Name] = assetState.Fingerprint
+	}
+	assert.Equal(t, map[string]string{
+		"task1": "fingerprint-1",
+		"task2": "fingerprint-2",
+		"task3": "fingerprint-3",
+		"task4": "",
+	}, saved)
+}
+
+func TestScheduler_KeepPreviousFingerprints(t *testing.T) {
+	t.Parallel()
+
+	p := &pipeline.Pipeline{
+		Name: "test",
+		Assets: []*pipeline.Asset{
+			{Name: "task1", Type: "bq.sql"},
+			{Name: "task2", Type: "bq.sql"},
+			{Name: "task3", Type: "bq.sql"},
+		},
+	}
+
+	fs := afero.NewMemMapFs()
+	s := NewScheduler(zap.NewNop().Sugar(), p, "run")
+	s.KeepPreviousFingerprints(&PipelineState{
+		State: []*PipelineAssetState{
+			{Name: "task1", Status: "succeeded", Fingerprint: "fingerprint-1"},
+			{Name: "task2", Status: "succeeded", Fingerprint: "fingerprint-2"},
+		},
+	})
+
+	s.MarkAsset(&pipeline.Asset{Name: "task1"}, Succeeded, false)
+	s.MarkAsset(&pipeline.Asset{Name: "task2"}, Failed, false)
+	s.MarkAsset(&pipeline.Asset{Name: "task3"}, Succeeded, false)
+
+	require.NoError(t, s.SavePipelineState(fs, &RunConfig{}, "run", "logs/runs"))
+	state, err := ReadState(fs, "logs/runs")
+	require.NoError(t, err)
+
+	saved := make(map[string]string)
+	for _, assetState := range state.State {
+		saved[assetState.Name] = assetState.Fingerprint
+	}
+	assert.Equal(t, map[string]string{
+		"task1": "fingerprint-1",
+		"task2": "",
+		"task3": "",
+	}, saved)
+}
+
+func TestScheduler_FreshnessCheckInstances(t *testing.T) {
+	t.Parallel()
+
+	p := &pipeline.Pipeline{
+		Name: "test",
+		Assets: []*pipeline.Asset{
+			{
+				Name:      "task1",
+				Type:      "bq.source",
+				Freshness: &pipeline.FreshnessCheck{Column: "updated_at", ErrorAfter: "2d"},
+			},
+			{
+				Name:      "task2",
+				Type:      "bq.sql",
+				Upstreams: []pipeline.Upstream{{Type: "asset", Value: "task1"}},
+			},
+		},
+	}
+
+	s := NewScheduler(zap.NewNop().Sugar(), p, "run")
+
+	var freshness TaskInstance
+	for _, ti := range s.taskInstances {
+		if ti.GetType() == TaskInstanceTypeFreshnessCheck {
+			require.Nil(t, freshness, "only one freshness check instance is expected")
+			freshness = ti
+		}
+	}
+
+	require.NotNil(t, freshness)
+	assert.Equal(t, "task1:freshness", freshness.GetHumanID())
+	assert.True(t, freshness.Blocking())
+
+	upstreams := freshness.GetUpstream()
+	require.Len(t, upstreams, 1)
+	assert.Equal(t, TaskInstanceTypeMain, upstreams[0].GetType())
+	assert.Equal(t, "task1", upstreams[0].GetAsset().Name)
+
+	downstreamIDs := make([]string, 0)
+	for _, ti := range freshness.GetDownstream() {
+		downstreamIDs = append(downstreamIDs, ti.GetHumanID())
+	}
+	assert.Contains(t, downstreamIDs, "task2")
+}
+
+func TestScheduler_MetadataPushInstances(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		metadataPush pipeline.MetadataPush
+		want         []string
+	}{
+		{
+			name: "disabled",
+			want: []string{},
+		},
+		{
+			name:         "the platform flags are limited to their own assets",
+			metadataPush: pipeline.MetadataPush{Postgres: true, DuckDB: true},
+			want:         []string{"task1:metadata-push", "task3:metadata-push"},
+		},
+		{
+			name:         "bigquery flag keeps pushing the snowflake, python and query sensor assets",
+			metadataPush: pipeline.MetadataPush{BigQuery: true},
+			want:         []string{"task2:metadata-push", "task4:metadata-push", "task5:metadata-push", "task6:metadata-push"},
+		},
+		{
+			name:         "snowflake flag pushes the snowflake and python assets",
+			metadataPush: pipeline.MetadataPush{Snowflake: true},
+			want:         []string{"task2:metadata-push", "task5:metadata-push"},
+		},
+		{
+			name:         "global pushes all the assets",
+			metadataPush: pipeline.MetadataPush{Global: true},
+			want:         []string{"task1:metadata-push", "task2:metadata-push", "task3:metadata-push", "task4:metadata-push", "task5:metadata-push", "task6:metadata-push"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &pipeline.Pipeline{
+				Name:         "test",
+				MetadataPush: tt.metadataPush,
+				Assets: []*pipeline.Asset{
+					{Name: "task1", Type: pipeline.AssetTypePostgresQuery},
+					{Name: "task2", Type: pipeline.AssetTypePython},
+					{Name: "task3", Type: pipeline.AssetTypeDuckDBQuery},
+					{Name: "task4", Type: pipeline.AssetTypeBigqueryQuery},
+					{Name: "task5", Type: pipeline.AssetTypeSnowflakeQuery},
+					{Name: "task6", Type: pipeline.AssetTypeBigqueryQuerySensor},
+				},
+			}
+
+			s := NewScheduler(zap.NewNop().Sugar(), p, "run")
+
+			got := make([]string, 0)
+			for _, ti := range s.taskInstances {
+				if ti.GetType() == TaskInstanceTypeMetadataPush {
+					got = append(got, ti.GetHumanID())
+				}
+			}
+
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
+
+func TestScheduler_AnomalyCheckInstances(t *testing.T) {
+	t.Parallel()
+
+	p := &pipeline.Pipeline{
+		Name: "test",
+		Assets: []*pipeline.Asset{
+			{
+				Name: "task1",
+				Type: "bq.sql",
+				Anomalies: []pipeline.AnomalyCheck{
+					{Name: "row_count", Metric: "row_count", MaxStddev: 3},
+					{Name: "sum_amount", Metric: "sum", Column: "amount", MaxChangePercent: 50, Severity: "warning"},
+				},
+			},
+		},
+	}
+
+	s := NewScheduler(zap.NewNop().Sugar(), p, "run")
+
+	blocking := make(map[string]bool)
+	for _, ti := range s.taskInstances {
+		if ti.GetType() != TaskInstanceTypeAnomalyCheck {
+			continue
+		}
+
+		blocking[ti.GetHumanID()] = ti.Blocking()
+		require.Len(t, ti.GetUpstream(), 1)
+		assert.Equal(t, TaskInstanceTypeMain, ti.GetUpstream()[0].GetType())
+	}
+
+	assert.Equal(t, map[string]bool{
+		"task1:anomaly:row_count":  true,
+		"task1:anomaly:sum_amount": false,
+	}, blocking)
+}
+
+func TestScheduler_NonBlockingCheckFailuresWarn(t *testing.T) {
+	t.Parallel()
+
+	nonBlocking := false
+	p := &pipeline.Pipeline{
+		Name: "test",
+		Assets: []*pipeline.Asset{
+			{
+				Name: "task1",
+				Type: "bq.sql",
+				Columns: []pipeline.Column{
+					{Name: "id", Checks: []pipeline.ColumnCheck{{Name: "unique", Blocking: pipeline.DefaultTrueBool{Value: &nonBlocking}}}},
+				},
+				CustomChecks: []pipeline.CustomCheck{{Name: "row count", Query: "SELECT 1"}},
+			},
+			{
+				Name:      "task2",
+				Type:      "bq.sql",
+				Upstreams: []pipeline.Upstream{{Type: "asset", Value: "task1"}},
+			},
+		},
+	}
+
+	s := NewScheduler(zap.NewNop().Sugar(), p, "test")
+	s.Kickstart()
+
+	mainTask := <-s.WorkQueue
+	assert.Equal(t, "task1", mainTask.GetHumanID())
+	s.Tick(&TaskExecutionResult{Instance: mainTask})
+
+	checks := map[string]TaskInstance{}
+	for range 2 {
+		check := <-s.WorkQueue
+		checks[check.GetHumanID()] = check
+	}
+
+	nonBlockingCheck := checks["task1:id:unique"]
+	require.NotNil(t, nonBlockingCheck)
+	assert.True(t, WarnsOnFailure(nonBlockingCheck))
+	sample := NewRowSample([]string{"id", "duplicate_count"}, [][]interface{}{{[]byte("a1"), int64(2)}})
+	s.Tick(&TaskExecutionResult{Instance: nonBlockingCheck, Error: &FailingRowsError{Err: errors.New("found duplicates"), Sample: sample}})
+	assert.Equal(t, Warned, nonBlockingCheck.GetStatus())
+
+	blockingCheck := checks["task1:custom-check:row_count"]
+	require.NotNil(t, blockingCheck)
+	assert.False(t, WarnsOnFailure(blockingCheck))
+	s.Tick(&TaskExecutionResult{Instance: blockingCheck})
+
+	downstream := <-s.WorkQueue
+	assert.Equal(t, "task2", downstream.GetHumanID())
+	assert.True(t, s.Tick(&TaskExecutionResult{Instance: downstream}))
+
+	assert.Equal(t, Warned, GetStatusForTask([]TaskInstanceStatus{mainTask.GetStatus(), nonBlockingCheck.GetStatus(), blockingCheck.GetStatus()}))
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, s.SavePipelineState(fs, &RunConfig{}, "run", "logs/runs"))
+
+	state, err := ReadState(fs, "logs/runs")
+	require.NoError(t, err)
+	for _, assetState := range state.State {
+		if assetState.Name != "task1" {
+			assert.Empty(t, assetState.Failures)
+			continue
+		}
+
+		require.Len(t, assetState.Failures, 1)
+		assert.Equal(t, "task1:id:unique", assetState.Failures[0].Task)
+		assert.Equal(t, "found duplicates", assetState.Failures[0].Error)
+		assert.Equal(t, []string{"id", "duplicate_count"}, assetState.Failures[0].Sample.Columns)
+		assert.Equal(t, [][]interface{}{{"a1", float64(2)}}, assetState.Failures[0].Sample.Rows)
+	}
+}
+
+func TestScheduler_WarningErrorsWarn(t *testing.T) {
+	t.Parallel()
+
+	p := &pipeline.Pipeline{
+		Name: "test",
+		Assets: []*pipeline.Asset{
+			{
+				Name:      "task1",
+				Type:      "bq.source",
+				Freshness: &pipeline.FreshnessCheck{Column: "updated_at", WarnAfter: "12h", ErrorAfter: "2d"},
+			},
+			{
+				Name:      "task2",
+				Type:      "bq.sql",
+				Upstreams: []pipeline.Upstream{{Type: "asset", Value: "task1"}},
+			},
+		},
+	}
+
+	s := NewScheduler(zap.NewNop().Sugar(), p, "test")
+	s.Kickstart()
+
+	mainTask := <-s.WorkQueue
+	assert.Equal(t, "task1", mainTask.GetHumanID())
+	s.Tick(&TaskExecutionResult{Instance: mainTask})
+
+	freshnessCheck := <-s.WorkQueue
+	assert.Equal(t, "task1:freshness", freshnessCheck.GetHumanID())
+	assert.False(t, WarnsOnFailure(freshnessCheck))
+
+	warning := &WarningError{Err: errors.New("the data is getting stale")}
+	assert.True(t, IsWarning(warning))
+	assert.False(t, IsWarning(errors.New("the data is stale")))
+	s.Tick(&TaskExecutionResult{Instance: freshnessCheck, Error: warning})
+	assert.Equal(t, Warned, freshnessCheck.GetStatus())
+
+	downstream := <-s.WorkQueue
+	assert.Equal(t, "task2", downstream.GetHumanID())
+	assert.True(t, s.Tick(&TaskExecutionResult{Instance: downstream}))
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, s.SavePipelineState(fs, &RunConfig{}, "run", "logs/runs"))
+
+	state, err := ReadState(fs, "logs/runs")
+	require.NoError(t, err)
+
+	statuses := make(map[string]string)
+	for _, assetState := range state.State {
+		statuses[assetState.Name] = assetState.Status
+	}
+	assert.Equal(t, map[string]string{"task1": Warned.String(), "task2": Succeeded.String()}, statuses)
+}
@@ -55,6 +55,11 @@ func (m *mockQuerierWithResult) PushColumnDescriptions(ctx context.Context, asse
 	return args.Error(0)
 }
 
+func (m *mockQuerierWithResult) PushColumnPolicies(ctx context.Context, asset *pipeline.Asset, policies map[string]*pipeline.ClassificationPolicy) error {
+	args := m.Called(ctx, asset, policies)
+	return args.Error(0)
+}
+
 func (m *mockQuerierWithResult) RecreateTableOnMaterializationTypeMismatch(ctx context.Context, asset *pipeline.Asset) error {
 	args := m.Called(ctx, asset)
 
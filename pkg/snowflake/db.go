@@ -7,6 +7,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/bruin-data/bruin/pkg/ansisql"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/bruin-data/bruin/pkg/query"
 	"github.com/jmoiron/sqlx"
@@ -53,13 +54,17 @@ func (db *DB) GetIngestrURI() (string, error) {
 	return db.config.GetIngestrURI()
 }
 
-func (db *DB) Select(ctx context.Context, query *query.Query) ([][]interface{}, error) {
+func (db *DB) Select(ctx context.Context, queryObj *query.Query) ([][]interface{}, error) {
 	ctx, err := gosnowflake.WithMultiStatement(ctx, 0)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create snowflake context")
 	}
 
-	queryString := query.String()
+	if tag := query.AnnotationsFromContext(ctx).JSON(); tag != "" {
+		ctx = gosnowflake.WithQueryTag(ctx, tag)
+	}
+
+	queryString := queryObj.String()
 	rows, err := db.conn.QueryContext(ctx, queryString)
 	if err == nil {
 		err = rows.Err()
@@ -153,6 +158,10 @@ func (db *DB) SelectWithSchema(ctx context.Context, queryObj *query.Query) (*que
 		return nil, errors.Wrap(err, "failed to create snowflake context")
 	}
 
+	if tag := query.AnnotationsFromContext(ctx).JSON(); tag != "" {
+		ctx = gosnowflake.WithQueryTag(ctx, tag)
+	}
+
 	// Convert query object to string and execute it
 	queryString := queryObj.String()
 	rows, err := db.conn.QueryContext(ctx, queryString)
@@ -354,6 +363,118 @@ func (db *DB) PushColumnDescriptions(ctx context.Context, asset *pipeline.Asset)
 	return nil
 }
 
+// PushColumnPolicies applies the masking policies and the tags of the column classification policies, given by the
+// column names, to the columns of the asset.
+func (db *DB) PushColumnPolicies(ctx context.Context, asset *pipeline.Asset, policies map[string]*pipeline.ClassificationPolicy) error {
+	tableComponents := strings.Split(asset.Name, ".")
+	var schemaName string
+	var tableName string
+	switch len(tableComponents) {
+	case 2:
+		schemaName = strings.ToUpper(tableComponents[0])
+		tableName = strings.ToUpper(tableComponents[1])
+	case 3:
+		schemaName = strings.ToUpper(tableComponents[1])
+		tableName = strings.ToUpper(tableComponents[2])
+	default:
+		return nil
+	}
+
+	queries := columnPolicyQueries(fmt.Sprintf("%s.%s.%s", db.config.Database, schemaName, tableName), asset, policies)
+	if len(queries) == 0 {
+		return nil
+	}
+
+	if err := db.RunQueryWithoutResult(ctx, &query.Query{Query: strings.Join(queries, "; ")}); err != nil {
+		return errors.Wrap(err, "failed to apply the column policies")
+	}
+
+	return nil
+}
+
+// columnPolicyQueries returns the queries that set the masking policy and the classification tag of the classified
+// columns, in the order of the columns.
+func columnPolicyQueries(tableName string, asset *pipeline.Asset, policies map[string]*pipeline.ClassificationPolicy) []string {
+	queries := make([]string, 0)
+	for _, col := range asset.Columns {
+		policy, ok := policies[col.Name]
+		if !ok || policy == nil {
+			continue
+		}
+
+		if policy.SnowflakeMaskingPolicy != "" {
+			queries = append(queries, fmt.Sprintf(
+				`ALTER TABLE %s MODIFY COLUMN %s SET MASKING POLICY %s FORCE`,
+				tableName, col.Name, policy.SnowflakeMaskingPolicy,
+			))
+		}
+
+		if policy.SnowflakeTag != "" {
+			queries = append(queries, fmt.Sprintf(
+				`ALTER TABLE %s MODIFY COLUMN %s SET TAG %s = '%s'`,
+				tableName, col.Name, policy.SnowflakeTag, escapeSQLString(col.Classification),
+			))
+		}
+	}
+
+	return queries
+}
+
 func escapeSQLString(s string) string {
 	return strings.ReplaceAll(s, "'", "''") // Escape single quotes for SQL safety
 }
+
+// GetTableColumns returns the columns of the given table in the order they are defined in Snowflake.
+func (db *DB) GetTableColumns(ctx context.Context, tableName string) ([]*ansisql.DBColumn, error) {
+	tableComponents := strings.Split(tableName, ".")
+	databaseName := db.config.Database
+	var schemaName string
+	switch len(tableComponents) {
+	case 2:
+		schemaName = tableComponents[0]
+	case 3:
+		databaseName = tableComponents[0]
+		schemaName = tableComponents[1]
+	default:
+		return nil, errors.Errorf("table name must be in schema.table or database.schema.table format, '%s' given", tableName)
+	}
+
+	queryStr := fmt.Sprintf(
+		`SELECT COLUMN_NAME, DATA_TYPE, IS_NULLABLE, COMMENT
+          FROM %s.INFORMATION_SCHEMA.COLUMNS
+          WHERE TABLE_SCHEMA = '%s' AND TABLE_NAME = '%s'
+          ORDER BY ORDINAL_POSITION`,
+		databaseName,
+		escapeSQLString(strings.ToUpper(schemaName)),
+		escapeSQLString(strings.ToUpper(tableComponents[len(tableComponents)-1])),
+	)
+
+	rows, err := db.Select(ctx, &query.Query{Query: queryStr})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query column metadata for %s", tableName)
+	}
+
+	if len(rows) == 0 {
+		return nil, ansisql.ErrTableNotFound
+	}
+
+	return ansisql.ColumnsFromInformationSchemaRows(rows)
+}
+
+// GetTables returns the names of the tables and views in the given schema of the configured database.
+func (db *DB) GetTables(ctx context.Context, schemaName string) ([]string, error) {
+	queryStr := fmt.Sprintf(
+		`SELECT TABLE_NAME
+          FROM %s.INFORMATION_SCHEMA.TABLES
+          WHERE TABLE_SCHEMA = '%s'
+          ORDER BY TABLE_NAME`,
+		db.config.Database, escapeSQLString(strings.ToUpper(schemaName)),
+	)
+
+	rows, err := db.Select(ctx, &query.Query{Query: queryStr})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the tables in schema %s", schemaName)
+	}
+
+	return ansisql.StringsFromRows(rows), nil
+}
@@ -711,3 +711,49 @@ func TestDB_PushColumnDescriptions(t *testing.T) {
 		})
 	}
 }
+
+func TestColumnPolicyQueries(t *testing.T) {
+	t.Parallel()
+
+	asset := &pipeline.Asset{
+		Name: "test_schema.test_table",
+		Columns: []pipeline.Column{
+			{Name: "id"},
+			{Name: "email", Classification: "pii"},
+			{Name: "notes", Classification: "customer's data"},
+			{Name: "country", Classification: "public"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		policies map[string]*pipeline.ClassificationPolicy
+		want     []string
+	}{
+		{
+			name:     "no policies",
+			policies: map[string]*pipeline.ClassificationPolicy{},
+			want:     []string{},
+		},
+		{
+			name: "masking policies and tags are applied in the order of the columns",
+			policies: map[string]*pipeline.ClassificationPolicy{
+				"notes":   {SnowflakeTag: "governance.tags.classification"},
+				"email":   {SnowflakeMaskingPolicy: "governance.policies.mask_pii", SnowflakeTag: "governance.tags.classification"},
+				"country": {BigQueryPolicyTag: "projects/p/locations/us/taxonomies/1/policyTags/2"},
+			},
+			want: []string{
+				"ALTER TABLE MYDB.TEST_SCHEMA.TEST_TABLE MODIFY COLUMN email SET MASKING POLICY governance.policies.mask_pii FORCE",
+				"ALTER TABLE MYDB.TEST_SCHEMA.TEST_TABLE MODIFY COLUMN email SET TAG governance.tags.classification = 'pii'",
+				"ALTER TABLE MYDB.TEST_SCHEMA.TEST_TABLE MODIFY COLUMN notes SET TAG governance.tags.classification = 'customer''s data'",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, columnPolicyQueries("MYDB.TEST_SCHEMA.TEST_TABLE", asset, tt.policies))
+		})
+	}
+}
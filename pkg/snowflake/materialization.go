@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/bruin-data/bruin/pkg/ansisql"
 	"github.com/bruin-data/bruin/pkg/helpers"
 	"github.com/bruin-data/bruin/pkg/pipeline"
 	"github.com/pkg/errors"
@@ -75,6 +76,20 @@ func buildCreateReplaceQuery(task *pipeline.Asset, query string) (string, error)
 		clusterByClause = fmt.Sprintf("CLUSTER BY (%s)", strings.Join(mat.ClusterBy, ", "))
 	}
 
+	if task.IsContractEnforced() {
+		columnDefinitions, err := ansisql.ContractColumnDefinitions(task)
+		if err != nil {
+			return "", err
+		}
+
+		query, err = ansisql.ContractSelectQuery(task, query)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("CREATE OR REPLACE TABLE %s (%s) %s AS\n%s", task.Name, columnDefinitions, clusterByClause, query), nil
+	}
+
 	return fmt.Sprintf("CREATE OR REPLACE TABLE %s %s AS\n%s", task.Name, clusterByClause, query), nil
 }
 
@@ -247,6 +247,39 @@ func TestMaterializer_Render(t *testing.T) {
 				"INSERT INTO my\\.asset SELECT dt, event_name from source_table where dt between '{{start_date}}' and '{{end_date}}';\n" +
 				"COMMIT;$",
 		},
+		{
+			name: "create+replace with an enforced contract declares and casts the columns",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type:      pipeline.MaterializationTypeTable,
+					ClusterBy: []string{"id"},
+				},
+				Contract: pipeline.ContractModeEnforced,
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "NUMBER", Checks: []pipeline.ColumnCheck{{Name: "not_null"}}},
+					{Name: "name", Type: "VARCHAR"},
+				},
+			},
+			query: "SELECT 1 AS id, 'a' AS name;",
+			want:  "^CREATE OR REPLACE TABLE my\\.asset \\(id NUMBER NOT NULL, name VARCHAR\\) CLUSTER BY \\(id\\) AS\nSELECT CAST\\(id AS NUMBER\\) AS id, CAST\\(name AS VARCHAR\\) AS name FROM \\(\nSELECT 1 AS id, 'a' AS name\n\\) AS __bruin_contract$",
+		},
+		{
+			name: "an enforced contract requires every column to have a type",
+			task: &pipeline.Asset{
+				Name: "my.asset",
+				Materialization: pipeline.Materialization{
+					Type: pipeline.MaterializationTypeTable,
+				},
+				Contract: pipeline.ContractModeEnforced,
+				Columns: []pipeline.Column{
+					{Name: "id", Type: "INT64"},
+					{Name: "name"},
+				},
+			},
+			query:   "SELECT 1 AS id, 'a' AS name;",
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
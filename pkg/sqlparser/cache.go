@@ -0,0 +1,73 @@
+package sqlparser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// resultCache stores the responses of the parser on disk, keyed by the hash of the command that produced them, so
+// that the queries that did not change are not parsed again in the next runs.
+type resultCache struct {
+	dir  string
+	salt string
+}
+
+func newResultCache(dir, salt string) *resultCache {
+	return &resultCache{
+		dir:  dir,
+		salt: salt,
+	}
+}
+
+func (c *resultCache) key(pc *parserCommand) (string, error) {
+	jsonCommand, err := json.Marshal(pc)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to build the cache key for the command")
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(c.salt))
+	hash.Write(jsonCommand)
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (c *resultCache) path(key string) string {
+	return filepath.Join(c.dir, key[:2], key)
+}
+
+func (c *resultCache) get(key string) (string, bool) {
+	content, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return "", false
+	}
+
+	return string(content), true
+}
+
+func (c *resultCache) set(key, value string) error {
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return errors.Wrap(err, "failed to create the cache folder")
+	}
+
+	// write to a temporary file first so that concurrent readers never see a partial result
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), key+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "failed to create the cache file")
+	}
+
+	_, err = tmpFile.WriteString(value)
+	closeErr := tmpFile.Close()
+	if err != nil || closeErr != nil {
+		_ = os.Remove(tmpFile.Name())
+		return errors.New("failed to write the cache file")
+	}
+
+	return os.Rename(tmpFile.Name(), path)
+}
@@ -0,0 +1,40 @@
+package sqlparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultCache(t *testing.T) {
+	t.Parallel()
+
+	cache := newResultCache(t.TempDir(), "v1")
+	command := &parserCommand{
+		Command:  "get-tables",
+		Contents: map[string]interface{}{"query": "select * from table1", "dialect": "bigquery"},
+	}
+
+	key, err := cache.key(command)
+	require.NoError(t, err)
+
+	_, ok := cache.get(key)
+	assert.False(t, ok)
+
+	require.NoError(t, cache.set(key, `{"tables": ["table1"]}`))
+	got, ok := cache.get(key)
+	assert.True(t, ok)
+	assert.JSONEq(t, `{"tables": ["table1"]}`, got)
+
+	otherQueryKey, err := cache.key(&parserCommand{
+		Command:  "get-tables",
+		Contents: map[string]interface{}{"query": "select * from table2", "dialect": "bigquery"},
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, key, otherQueryKey)
+
+	otherVersionKey, err := newResultCache(t.TempDir(), "v2").key(command)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, otherVersionKey)
+}
@@ -9,8 +9,10 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bruin-data/bruin/internal/data"
@@ -20,21 +22,56 @@ import (
 	"github.com/pkg/errors"
 )
 
+// CacheFolder is the folder under the repository root that the parse results are cached in.
+const CacheFolder = "logs/cache/sqlparser"
+
+// Config configures the parser processes and the caching of their results.
+type Config struct {
+	// Workers is the maximum number of parser processes, they are started as the load requires them.
+	// Defaults to DefaultWorkerCount.
+	Workers int
+	// CacheDir is the folder the parse results are cached in, the results are not cached if it is empty.
+	CacheDir string
+}
+
+// DefaultWorkerCount returns the number of parser processes used when none is configured.
+func DefaultWorkerCount() int {
+	return min(runtime.NumCPU(), 4)
+}
+
+// Stats summarizes the work done by the parser.
+type Stats struct {
+	Workers   int
+	Commands  int64
+	CacheHits int64
+	ParseTime time.Duration
+}
+
+func (s Stats) String() string {
+	return fmt.Sprintf("%d workers, %d commands, %d served from the cache, %s spent parsing", s.Workers, s.Commands, s.CacheHits, s.ParseTime.Round(time.Millisecond))
+}
+
 type SQLParser struct {
 	ep          *python.EmbeddedPython
 	sqlglotDir  *embed_util.EmbeddedFiles
 	rendererSrc *embed_util.EmbeddedFiles
-	started     bool
+	cache       *resultCache
 
-	stdout io.ReadCloser
-	stdin  io.WriteCloser
-	cmd    *exec.Cmd
-	mutex  sync.Mutex
+	workerCount int
+	processes   []*parserProcess
+	idle        chan *parserProcess
+	mutex       sync.Mutex
 
-	startMutex sync.Mutex
+	commands  atomic.Int64
+	cacheHits atomic.Int64
+	parseTime atomic.Int64
 }
 
 func NewSQLParser(randomize bool) (*SQLParser, error) {
+	return NewSQLParserWithConfig(randomize, Config{})
+}
+
+func NewSQLParserWithConfig(randomize bool, config Config) (*SQLParser, error) {
 	randomInt := 0
 	if randomize {
 		b := make([]byte, 4)
@@ -61,50 +98,147 @@ func NewSQLParser(randomize bool) (*SQLParser, error) {
 		return nil, err
 	}
 
+	workerCount := config.Workers
+	if workerCount <= 0 {
+		workerCount = DefaultWorkerCount()
+	}
+
+	var cache *resultCache
+	if config.CacheDir != "" {
+		// the extracted folders contain the hash of their contents, which invalidates the cache when sqlglot or the
+		// parser code change
+		cache = newResultCache(config.CacheDir, filepath.Base(sqlglotDir.GetExtractedPath())+filepath.Base(rendererSrc.GetExtractedPath()))
+	}
+
 	return &SQLParser{
 		ep:          ep,
 		sqlglotDir:  sqlglotDir,
 		rendererSrc: rendererSrc,
+		cache:       cache,
+		workerCount: workerCount,
+		idle:        make(chan *parserProcess, workerCount),
 	}, nil
 }
 
+// Start starts the first parser process, the rest of them are started when the commands are sent concurrently.
 func (s *SQLParser) Start() error {
-	s.startMutex.Lock()
-	defer s.startMutex.Unlock()
-	if s.started {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if len(s.processes) > 0 {
 		return nil
 	}
-	var err error
-	args := []string{filepath.Join(s.rendererSrc.GetExtractedPath(), "main.py")}
-	s.cmd, err = s.ep.PythonCmd(args...)
+
+	process, err := s.startProcess()
 	if err != nil {
 		return err
 	}
-	s.cmd.Stderr = os.Stderr
 
-	s.stdout, err = s.cmd.StdoutPipe()
+	s.processes = append(s.processes, process)
+	s.idle <- process
+	return nil
+}
+
+// WorkerCount returns the maximum number of parser processes.
+func (s *SQLParser) WorkerCount() int {
+	return s.workerCount
+}
+
+// Stats returns the work done by the parser so far.
+func (s *SQLParser) Stats() Stats {
+	s.mutex.Lock()
+	workers := len(s.processes)
+	s.mutex.Unlock()
+
+	return Stats{
+		Workers:   workers,
+		Commands:  s.commands.Load(),
+		CacheHits: s.cacheHits.Load(),
+		ParseTime: time.Duration(s.parseTime.Load()),
+	}
+}
+
+func (s *SQLParser) startProcess() (*parserProcess, error) {
+	args := []string{filepath.Join(s.rendererSrc.GetExtractedPath(), "main.py")}
+	cmd, err := s.ep.PythonCmd(args...)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	cmd.Stderr = os.Stderr
 
-	s.stdin, err = s.cmd.StdinPipe()
+	stdout, err := cmd.StdoutPipe()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	err = s.cmd.Start()
+	stdin, err := cmd.StdinPipe()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = s.sendCommand(&parserCommand{
+	err = cmd.Start()
+	if err != nil {
+		return nil, err
+	}
+
+	process := &parserProcess{
+		cmd:    cmd,
+		stdin:  stdin,
+		stdout: stdout,
+		reader: bufio.NewReader(stdout),
+	}
+
+	_, err = process.send(&parserCommand{
 		Command: "init",
 	})
 	if err != nil {
-		return errors.Wrap(err, "failed to send init command")
+		return nil, errors.Wrap(err, "failed to send init command")
 	}
-	s.started = true
-	return nil
+
+	return process, nil
+}
+
+// acquire returns an idle parser process, starting a new one if all of them are busy and the limit is not reached yet.
+func (s *SQLParser) acquire() (*parserProcess, error) {
+	select {
+	case process := <-s.idle:
+		return process, nil
+	default:
+	}
+
+	s.mutex.Lock()
+	if len(s.processes) < s.workerCount {
+		process, err := s.startProcess()
+		if err != nil {
+			s.mutex.Unlock()
+			return nil, errors.Wrap(err, "failed to start sql parser")
+		}
+
+		s.processes = append(s.processes, process)
+		s.mutex.Unlock()
+		return process, nil
+	}
+	s.mutex.Unlock()
+
+	return <-s.idle, nil
+}
+
+func (s *SQLParser) release(process *parserProcess) {
+	s.idle <- process
+}
+
+// discard kills a process whose input or output cannot be trusted anymore, e.g. after a failed read, so that the next
+// command starts a new process instead of reading a leftover response.
+func (s *SQLParser) discard(process *parserProcess) {
+	s.mutex.Lock()
+	for i, p := range s.processes {
+		if p == process {
+			s.processes = append(s.processes[:i], s.processes[i+1:]...)
+			break
+		}
+	}
+	s.mutex.Unlock()
+
+	process.kill()
 }
 
 type parserCommand struct {
@@ -190,55 +324,166 @@ func (s *SQLParser) UsedTables(sql, dialect string) ([]string, error) {
 	return tables.Tables, nil
 }
 
+// Transpile converts the query from the given dialect to the target dialect, replacing the tables in the given map with
+// the table names they are mapped to.
+func (s *SQLParser) Transpile(sql, dialect, targetDialect string, tables map[string]string) (string, error) {
+	err := s.Start()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to start sql parser")
+	}
+
+	command := parserCommand{
+		Command: "transpile",
+		Contents: map[string]interface{}{
+			"query":          sql,
+			"dialect":        dialect,
+			"target_dialect": targetDialect,
+			"tables":         tables,
+		},
+	}
+
+	resp, err := s.sendCommand(&command)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to send command")
+	}
+
+	var transpiled struct {
+		Query string `json:"query"`
+		Error string `json:"error"`
+	}
+	err = json.Unmarshal([]byte(resp), &transpiled)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal response")
+	}
+
+	if transpiled.Error != "" {
+		return "", errors.New(transpiled.Error)
+	}
+
+	return transpiled.Query, nil
+}
+
 func (s *SQLParser) sendCommand(pc *parserCommand) (string, error) {
+	s.commands.Add(1)
+
+	var cacheKey string
+	if s.cache != nil {
+		key, err := s.cache.key(pc)
+		if err != nil {
+			return "", err
+		}
+
+		if resp, ok := s.cache.get(key); ok {
+			s.cacheHits.Add(1)
+			return resp, nil
+		}
+		cacheKey = key
+	}
+
+	jsonCommand, err := json.Marshal(pc)
+	if err != nil {
+		return "", err
+	}
+
+	process, err := s.acquire()
+	if err != nil {
+		return "", err
+	}
+
+	start := time.Now()
+	resp, err := process.sendRaw(jsonCommand)
+	s.parseTime.Add(int64(time.Since(start)))
+	if err == nil && !json.Valid([]byte(resp)) {
+		err = errors.Errorf("invalid response from the sql parser: %s", resp)
+	}
+	if err != nil {
+		s.discard(process)
+		return "", err
+	}
+	s.release(process)
+
+	if s.cache != nil {
+		// failing to cache a result only makes the next run slower, it should not fail this one
+		_ = s.cache.set(cacheKey, resp)
+	}
+
+	return resp, nil
+}
+
+func (s *SQLParser) Close() error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	var closeErr error
+	for range s.processes {
+		// wait for the process to finish its current command, if any
+		process := <-s.idle
+		if err := process.close(); err != nil && closeErr == nil {
+			closeErr = err
+		}
+	}
+	s.processes = nil
+
+	return closeErr
+}
+
+type parserProcess struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+	reader *bufio.Reader
+}
+
+func (p *parserProcess) send(pc *parserCommand) (string, error) {
 	jsonCommand, err := json.Marshal(pc)
 	if err != nil {
 		return "", err
 	}
 
-	jsonCommand = append(jsonCommand, '\n')
+	return p.sendRaw(jsonCommand)
+}
 
-	_, err = s.stdin.Write(jsonCommand)
+func (p *parserProcess) sendRaw(jsonCommand []byte) (string, error) {
+	_, err := p.stdin.Write(append(jsonCommand, '\n'))
 	if err != nil {
 		return "", errors.Wrap(err, "failed to write command to stdin")
 	}
 
-	reader := bufio.NewReader(s.stdout)
+	resp, err := p.reader.ReadString(byte('\n'))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read the response from stdout")
+	}
 
-	resp, err := reader.ReadString(byte('\n'))
-	return resp, err
+	return resp, nil
 }
 
-func (s *SQLParser) Close() error {
-	if s.stdin != nil {
-		_, err := s.sendCommand(&parserCommand{
-			Command: "exit",
-		})
-		if err != nil {
-			return errors.Wrap(err, "failed to send exit command")
-		}
+func (p *parserProcess) kill() {
+	_ = p.stdin.Close()
+	_ = p.stdout.Close()
 
-		_ = s.stdin.Close()
-		s.stdin = nil
+	if p.cmd.Process != nil {
+		_ = p.cmd.Process.Kill()
+		_ = p.cmd.Wait()
 	}
+}
 
-	if s.stdout != nil {
-		_ = s.stdout.Close()
-		s.stdout = nil
+func (p *parserProcess) close() error {
+	_, err := p.send(&parserCommand{
+		Command: "exit",
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to send exit command")
 	}
 
-	if s.cmd != nil {
-		if s.cmd.Process != nil {
-			timer := time.AfterFunc(5*time.Second, func() {
-				_ = s.cmd.Process.Kill()
-			})
-			_ = s.cmd.Wait()
-			timer.Stop()
-		}
-		s.cmd = nil
+	_ = p.stdin.Close()
+	_ = p.stdout.Close()
+
+	if p.cmd.Process != nil {
+		timer := time.AfterFunc(5*time.Second, func() {
+			_ = p.cmd.Process.Kill()
+		})
+		_ = p.cmd.Wait()
+		timer.Stop()
 	}
 
 	return nil
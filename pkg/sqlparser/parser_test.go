@@ -1,8 +1,10 @@
 package sqlparser
 
 import (
+	"sync"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -760,3 +762,116 @@ from raw.Bookings as bookings
 	s.Close()
 	require.NoError(t, err)
 }
+
+func TestSqlParser_Transpile(t *testing.T) {
+	s, err := NewSQLParser(true)
+	require.NoError(t, err)
+	defer s.Close()
+
+	tests := []struct {
+		name    string
+		sql     string
+		dialect string
+		tables  map[string]string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "bigquery functions and tables are converted",
+			sql:     "select customer_id, safe_divide(sum(amount), count(*)) as avg_amount from `raw.orders` o group by 1",
+			dialect: "bigquery",
+			tables:  map[string]string{"raw.orders": "raw.orders"},
+			want:    `SELECT customer_id, IF((COUNT(*)) <> 0, (SUM(amount)) / (COUNT(*)), NULL) AS avg_amount FROM "raw.orders" AS o GROUP BY 1`,
+		},
+		{
+			name:    "only the mapped tables are replaced",
+			sql:     "select * from raw.orders join RAW.Customers c using(customer_id) join raw.countries using(country)",
+			dialect: "snowflake",
+			tables:  map[string]string{"raw.orders": "orders_fixture", "raw.customers": "customers_fixture"},
+			want:    `SELECT * FROM "orders_fixture" JOIN "customers_fixture" AS c USING (customer_id) JOIN raw.countries USING (country)`,
+		},
+		{
+			name:    "invalid query",
+			sql:     "select * from (",
+			dialect: "bigquery",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Transpile(tt.sql, tt.dialect, "duckdb", tt.tables)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSqlParser_PoolAndCache(t *testing.T) {
+	s, err := NewSQLParserWithConfig(true, Config{Workers: 2, CacheDir: t.TempDir()})
+	require.NoError(t, err)
+	defer s.Close()
+
+	queries := []string{
+		"select * from table1",
+		"select * from table1 join table2 using(a)",
+		"select * from table3 union all select * from table4",
+		"select a from (select a from table5) t",
+	}
+
+	parseAll := func() {
+		var wg sync.WaitGroup
+		for _, q := range queries {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := s.UsedTables(q, "bigquery")
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+	}
+
+	parseAll()
+	stats := s.Stats()
+	require.LessOrEqual(t, stats.Workers, 2)
+	require.Equal(t, int64(len(queries)), stats.Commands)
+	require.Equal(t, int64(0), stats.CacheHits)
+
+	parseAll()
+	stats = s.Stats()
+	require.Equal(t, int64(2*len(queries)), stats.Commands)
+	require.Equal(t, int64(len(queries)), stats.CacheHits)
+
+	tables, err := s.UsedTables("select * from table1 join table2 using(a)", "bigquery")
+	require.NoError(t, err)
+	require.Equal(t, []string{"table1", "table2"}, tables)
+}
+
+func TestSqlParser_DiscardsBrokenProcesses(t *testing.T) {
+	s, err := NewSQLParserWithConfig(true, Config{Workers: 1})
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.NoError(t, s.Start())
+
+	// kill the only process behind the parser's back, the next command fails to read its response
+	broken := s.processes[0]
+	require.NoError(t, broken.cmd.Process.Kill())
+	_ = broken.cmd.Wait()
+
+	_, err = s.UsedTables("select * from table1", "bigquery")
+	require.Error(t, err)
+	require.Equal(t, 0, s.Stats().Workers)
+
+	// the broken process is not reused, a new one is started instead
+	tables, err := s.UsedTables("select * from table1", "bigquery")
+	require.NoError(t, err)
+	require.Equal(t, []string{"table1"}, tables)
+	require.Equal(t, 1, s.Stats().Workers)
+	require.NotSame(t, broken, s.processes[0])
+}
@@ -0,0 +1,354 @@
+package sqltest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/dialect"
+	duck "github.com/bruin-data/bruin/pkg/duckdb"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/marcboeker/go-duckdb"
+	"github.com/pkg/errors"
+)
+
+type transpiler interface {
+	Transpile(sql, dialect, targetDialect string, tables map[string]string) (string, error)
+}
+
+type queryExtractor interface {
+	CloneForAsset(ctx context.Context, p *pipeline.Pipeline, t *pipeline.Asset) query.QueryExtractor
+}
+
+// Row is a row of the query result, with the values converted to strings so that the values of the fixtures and of
+// DuckDB can be compared regardless of their types.
+type Row map[string]string
+
+// Result is the outcome of a single test: the rows that were expected but not returned by the query are missing, and
+// the rows that were returned but not expected are unexpected.
+type Result struct {
+	Asset      string   `json:"asset"`
+	Test       string   `json:"test"`
+	Passed     bool     `json:"passed"`
+	Error      string   `json:"error,omitempty"`
+	Columns    []string `json:"columns,omitempty"`
+	Missing    []Row    `json:"missing,omitempty"`
+	Unexpected []Row    `json:"unexpected,omitempty"`
+}
+
+// Runner runs the tests of the SQL assets: the query of the asset is transpiled to DuckDB, and run in an ephemeral
+// DuckDB database that contains the fixtures of the test.
+type Runner struct {
+	extractor  queryExtractor
+	transpiler transpiler
+}
+
+func NewRunner(extractor queryExtractor, transpiler transpiler) *Runner {
+	return &Runner{extractor: extractor, transpiler: transpiler}
+}
+
+// RunAsset runs all the tests of the asset, the tests that cannot be run are reported as failed with the error.
+func (r *Runner) RunAsset(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) []*Result {
+	results := make([]*Result, 0, len(asset.Tests))
+	if len(asset.Tests) == 0 {
+		return results
+	}
+
+	sourceDialect, q, queryErr := r.assetQuery(ctx, p, asset)
+	for i := range asset.Tests {
+		test := &asset.Tests[i]
+		result := &Result{Asset: asset.Name, Test: test.Name}
+
+		err := queryErr
+		if err == nil {
+			err = r.runTest(ctx, asset, test, sourceDialect, q, result)
+		}
+
+		if err != nil {
+			result.Error = err.Error()
+		}
+		result.Passed = result.Error == "" && len(result.Missing) == 0 && len(result.Unexpected) == 0
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func (r *Runner) assetQuery(ctx context.Context, p *pipeline.Pipeline, asset *pipeline.Asset) (string, string, error) {
+	sourceDialect, err := dialect.GetDialectByAssetType(string(asset.Type))
+	if err != nil {
+		return "", "", errors.Errorf("tests are not supported for assets of type '%s'", asset.Type)
+	}
+
+	queries, err := r.extractor.CloneForAsset(ctx, p, asset).ExtractQueriesFromString(asset.ExecutableFile.Content)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to render the query of the asset")
+	}
+
+	if len(queries) != 1 {
+		return "", "", errors.Errorf("tests are only supported for assets with a single query, found %d queries", len(queries))
+	}
+
+	return sourceDialect, queries[0].Query, nil
+}
+
+func (r *Runner) runTest(ctx context.Context, asset *pipeline.Asset, test *pipeline.SQLTest, sourceDialect, q string, result *Result) error {
+	dir, err := os.MkdirTemp("", "bruin-test-*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create the folder for the test database")
+	}
+	defer os.RemoveAll(dir)
+
+	client, err := duck.NewClient(duck.Config{Path: filepath.Join(dir, "test.db")})
+	if err != nil {
+		return errors.Wrap(err, "failed to create the test database")
+	}
+
+	baseDir := filepath.Dir(asset.DefinitionFile.Path)
+	tables := make(map[string]string, len(test.Given))
+	for _, fixture := range test.Given {
+		source, err := fixtureSource(fixture.Rows, fixture.CSV, baseDir)
+		if err != nil {
+			return errors.Wrapf(err, "invalid fixture for table '%s'", fixture.Table)
+		}
+
+		err = client.RunQueryWithoutResult(ctx, &query.Query{
+			Query: fmt.Sprintf("CREATE TABLE %s AS SELECT * FROM %s", quoteIdentifier(fixture.Table), source),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to load the fixture for table '%s'", fixture.Table)
+		}
+
+		tables[fixture.Table] = fixture.Table
+	}
+
+	transpiled, err := r.transpiler.Transpile(q, sourceDialect, dialect.DuckDBDialect, tables)
+	if err != nil {
+		return errors.Wrap(err, "failed to transpile the query to DuckDB")
+	}
+
+	actual, err := client.SelectWithSchema(ctx, &query.Query{Query: transpiled})
+	if err != nil {
+		return errors.Wrap(err, "failed to run the query")
+	}
+
+	expected := &query.QueryResult{Columns: columnsOf(test.Expect.Rows), Rows: valuesOf(test.Expect.Rows)}
+	if test.Expect.CSV != "" {
+		source, err := fixtureSource(test.Expect.Rows, test.Expect.CSV, baseDir)
+		if err != nil {
+			return errors.Wrap(err, "invalid expectation")
+		}
+
+		expected, err = client.SelectWithSchema(ctx, &query.Query{Query: "SELECT * FROM " + source})
+		if err != nil {
+			return errors.Wrap(err, "failed to read the expected rows")
+		}
+	}
+
+	return CompareRows(expected, actual, result)
+}
+
+// CompareRows compares the rows regardless of their order, and records the rows that differ in the result. Only the
+// columns of the expected rows are compared, or all the columns of the actual rows if no row is expected.
+func CompareRows(expected, actual *query.QueryResult, result *Result) error {
+	columns := expected.Columns
+	if len(columns) == 0 {
+		columns = actual.Columns
+	}
+	result.Columns = columns
+
+	actualIndex := make(map[string]int, len(actual.Columns))
+	for i, column := range actual.Columns {
+		actualIndex[strings.ToLower(column)] = i
+	}
+
+	positions := make([]int, len(columns))
+	for i, column := range columns {
+		position, ok := actualIndex[strings.ToLower(column)]
+		if !ok {
+			return errors.Errorf("the query did not return the expected column '%s'", column)
+		}
+		positions[i] = position
+	}
+
+	expectedRows := make(map[string][]Row)
+	for _, values := range expected.Rows {
+		row := make(Row, len(columns))
+		for i, column := range columns {
+			row[column] = normalizeValue(values[i])
+		}
+
+		key := rowKey(row, columns)
+		expectedRows[key] = append(expectedRows[key], row)
+	}
+
+	for _, values := range actual.Rows {
+		row := make(Row, len(columns))
+		for i, column := range columns {
+			row[column] = normalizeValue(values[positions[i]])
+		}
+
+		key := rowKey(row, columns)
+		if len(expectedRows[key]) > 0 {
+			expectedRows[key] = expectedRows[key][1:]
+			continue
+		}
+
+		result.Unexpected = append(result.Unexpected, row)
+	}
+
+	for _, rows := range expectedRows {
+		result.Missing = append(result.Missing, rows...)
+	}
+
+	sortRows(result.Missing, columns)
+	sortRows(result.Unexpected, columns)
+
+	return nil
+}
+
+func fixtureSource(rows []map[string]interface{}, csvPath, baseDir string) (string, error) {
+	if csvPath != "" {
+		if len(rows) > 0 {
+			return "", errors.New("only one of `rows` or `csv` can be given")
+		}
+
+		if !filepath.IsAbs(csvPath) {
+			csvPath = filepath.Join(baseDir, csvPath)
+		}
+
+		return fmt.Sprintf("read_csv_auto(%s)", quoteLiteral(csvPath)), nil
+	}
+
+	if len(rows) == 0 {
+		return "", errors.New("either `rows` or `csv` must be given, use a CSV file with only the header for an empty table")
+	}
+
+	columns := columnsOf(rows)
+	values := make([]string, 0, len(rows))
+	for _, row := range valuesOf(rows) {
+		literals := make([]string, len(row))
+		for i, v := range row {
+			literals[i] = sqlLiteral(v)
+		}
+		values = append(values, "("+strings.Join(literals, ", ")+")")
+	}
+
+	identifiers := make([]string, len(columns))
+	for i, column := range columns {
+		identifiers[i] = quoteIdentifier(column)
+	}
+
+	return fmt.Sprintf("(VALUES %s) AS t(%s)", strings.Join(values, ", "), strings.Join(identifiers, ", ")), nil
+}
+
+// columnsOf returns the columns used in any of the rows, sorted by their names.
+func columnsOf(rows []map[string]interface{}) []string {
+	columns := make([]string, 0)
+	for _, row := range rows {
+		for column := range row {
+			if !slices.Contains(columns, column) {
+				columns = append(columns, column)
+			}
+		}
+	}
+	sort.Strings(columns)
+
+	return columns
+}
+
+// valuesOf returns the values of the rows in the order of their columns, the columns missing in a row are null.
+func valuesOf(rows []map[string]interface{}) [][]interface{} {
+	columns := columnsOf(rows)
+	values := make([][]interface{}, 0, len(rows))
+	for _, row := range rows {
+		rowValues := make([]interface{}, len(columns))
+		for i, column := range columns {
+			rowValues[i] = row[column]
+		}
+		values = append(values, rowValues)
+	}
+
+	return values
+}
+
+func sqlLiteral(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		return strings.ToUpper(strconv.FormatBool(val))
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprintf("%v", val)
+	case time.Time:
+		return quoteLiteral(val.Format("2006-01-02 15:04:05.999999"))
+	default:
+		return quoteLiteral(fmt.Sprintf("%v", val))
+	}
+}
+
+func normalizeValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		return normalizeString(string(val))
+	case string:
+		return normalizeString(val)
+	case bool:
+		return strconv.FormatBool(val)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(val), 'f', -1, 32)
+	case duckdb.Decimal:
+		return strconv.FormatFloat(val.Float64(), 'f', -1, 64)
+	case time.Time:
+		if val.Equal(val.Truncate(24 * time.Hour)) {
+			return val.Format("2006-01-02")
+		}
+		return val.Format("2006-01-02 15:04:05.999999")
+	default:
+		return normalizeString(fmt.Sprintf("%v", val))
+	}
+}
+
+// normalizeString formats the numbers the same way regardless of how they are written, e.g. 10.50 and 10.5.
+func normalizeString(s string) string {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	return s
+}
+
+func rowKey(row Row, columns []string) string {
+	values := make([]string, len(columns))
+	for i, column := range columns {
+		values[i] = strconv.Quote(row[column])
+	}
+
+	return strings.Join(values, ",")
+}
+
+func sortRows(rows []Row, columns []string) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rowKey(rows[i], columns) < rowKey(rows[j], columns)
+	})
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
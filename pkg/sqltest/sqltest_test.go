@@ -0,0 +1,180 @@
+package sqltest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bruin-data/bruin/pkg/jinja"
+	"github.com/bruin-data/bruin/pkg/pipeline"
+	"github.com/bruin-data/bruin/pkg/query"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// identityTranspiler returns the query as is, the queries in the tests refer to the fixture tables directly.
+type identityTranspiler struct {
+	err error
+}
+
+func (t *identityTranspiler) Transpile(sql, dialect, targetDialect string, tables map[string]string) (string, error) {
+	return sql, t.err
+}
+
+func TestCompareRows(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		expected       *query.QueryResult
+		actual         *query.QueryResult
+		wantMissing    []Row
+		wantUnexpected []Row
+		wantErr        string
+	}{
+		{
+			name: "same rows in a different order with different types",
+			expected: &query.QueryResult{
+				Columns: []string{"day", "id", "total"},
+				Rows:    [][]interface{}{{"2024-01-02", 2, 10.5}, {"2024-01-01", 1, nil}},
+			},
+			actual: &query.QueryResult{
+				Columns: []string{"ID", "day", "total", "ignored"},
+				Rows: [][]interface{}{
+					{int64(1), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), nil, "x"},
+					{int32(2), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), "10.50", "y"},
+				},
+			},
+		},
+		{
+			name: "duplicate rows are compared",
+			expected: &query.QueryResult{
+				Columns: []string{"id"},
+				Rows:    [][]interface{}{{1}, {1}, {2}},
+			},
+			actual: &query.QueryResult{
+				Columns: []string{"id"},
+				Rows:    [][]interface{}{{int64(3)}, {int64(1)}, {int64(2)}},
+			},
+			wantMissing:    []Row{{"id": "1"}},
+			wantUnexpected: []Row{{"id": "3"}},
+		},
+		{
+			name:     "no expected rows compares all the columns",
+			expected: &query.QueryResult{},
+			actual: &query.QueryResult{
+				Columns: []string{"id", "name"},
+				Rows:    [][]interface{}{{int64(1), []byte("a")}},
+			},
+			wantUnexpected: []Row{{"id": "1", "name": "a"}},
+		},
+		{
+			name:     "missing column",
+			expected: &query.QueryResult{Columns: []string{"id", "total"}, Rows: [][]interface{}{{1, 2}}},
+			actual:   &query.QueryResult{Columns: []string{"id"}, Rows: [][]interface{}{{int64(1)}}},
+			wantErr:  "the query did not return the expected column 'total'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := &Result{}
+			err := CompareRows(tt.expected, tt.actual, result)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMissing, result.Missing)
+			assert.Equal(t, tt.wantUnexpected, result.Unexpected)
+		})
+	}
+}
+
+func TestRunner_RunAsset(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "customers.csv"), []byte("id,country\n1,TR\n2,US\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "expected.csv"), []byte("country,total\nTR,30.5\nUS,5\n"), 0o644))
+
+	orders := pipeline.SQLTestFixture{
+		Table: "raw.orders",
+		Rows: []map[string]interface{}{
+			{"customer_id": 1, "amount": 10.5},
+			{"customer_id": 1, "amount": 20},
+			{"customer_id": 2, "amount": 5, "note": "it's late"},
+		},
+	}
+	customers := pipeline.SQLTestFixture{Table: "raw.customers", CSV: "customers.csv"}
+
+	asset := &pipeline.Asset{
+		Name:           "mart.country_totals",
+		Type:           pipeline.AssetTypeDuckDBQuery,
+		DefinitionFile: pipeline.TaskDefinitionFile{Path: filepath.Join(dir, "country_totals.sql")},
+		ExecutableFile: pipeline.ExecutableFile{
+			Content: `SELECT c.country, SUM(o.amount) AS total
+FROM "raw.orders" o JOIN "raw.customers" c ON o.customer_id = c.id
+WHERE '{{ start_date }}' = '2024-01-01'
+GROUP BY 1`,
+		},
+		Tests: []pipeline.SQLTest{
+			{
+				Name:   "totals from inline rows",
+				Given:  []pipeline.SQLTestFixture{orders, customers},
+				Expect: pipeline.SQLTestExpectation{Rows: []map[string]interface{}{{"country": "TR", "total": 30.5}, {"country": "US", "total": 5}}},
+			},
+			{
+				Name:   "totals from a csv file",
+				Given:  []pipeline.SQLTestFixture{orders, customers},
+				Expect: pipeline.SQLTestExpectation{CSV: "expected.csv"},
+			},
+			{
+				Name:   "wrong expectation",
+				Given:  []pipeline.SQLTestFixture{orders, customers},
+				Expect: pipeline.SQLTestExpectation{Rows: []map[string]interface{}{{"country": "TR", "total": 30}}},
+			},
+			{
+				Name:   "missing fixture",
+				Given:  []pipeline.SQLTestFixture{orders},
+				Expect: pipeline.SQLTestExpectation{},
+			},
+		},
+	}
+
+	startDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2024, 1, 1, 23, 59, 59, 0, time.UTC)
+	extractor := &query.WholeFileExtractor{
+		Fs:       afero.NewMemMapFs(),
+		Renderer: jinja.NewRendererWithStartEndDates(&startDate, &endDate, "test", "run"),
+	}
+
+	p := &pipeline.Pipeline{Name: "test", Assets: []*pipeline.Asset{asset}}
+	results := NewRunner(extractor, &identityTranspiler{}).RunAsset(context.Background(), p, asset)
+	require.Len(t, results, 4)
+
+	assert.True(t, results[0].Passed, results[0].Error)
+	assert.True(t, results[1].Passed, results[1].Error)
+
+	assert.False(t, results[2].Passed)
+	assert.Empty(t, results[2].Error)
+	assert.Equal(t, []string{"country", "total"}, results[2].Columns)
+	assert.Equal(t, []Row{{"country": "TR", "total": "30"}}, results[2].Missing)
+	assert.Equal(t, []Row{{"country": "TR", "total": "30.5"}, {"country": "US", "total": "5"}}, results[2].Unexpected)
+
+	assert.False(t, results[3].Passed)
+	assert.Contains(t, results[3].Error, "failed to run the query")
+
+	results = NewRunner(extractor, &identityTranspiler{err: errors.New("unsupported syntax")}).RunAsset(context.Background(), p, asset)
+	require.Len(t, results, 4)
+	for _, result := range results {
+		assert.False(t, result.Passed)
+		assert.Equal(t, "failed to transpile the query to DuckDB: unsupported syntax", result.Error)
+	}
+}
@@ -2,7 +2,7 @@ import json
 import sys
 import logging
 import os
-from parser.main import get_column_lineage, get_tables
+from parser.main import get_column_lineage, get_tables, transpile
 
 from pathlib import Path
 
@@ -42,6 +42,12 @@ def main():
                 logging.info("got get-tables command")
                 c = cmd["contents"]
                 result = get_tables(c["query"], c["dialect"])
+            elif cmd["command"] == "transpile":
+                logging.info("got transpile command")
+                c = cmd["contents"]
+                result = transpile(
+                    c["query"], c["dialect"], c["target_dialect"], c["tables"]
+                )
             elif cmd["command"] == "exit":
                 logging.info("got exit command amx")
                 break
@@ -107,6 +107,31 @@ def get_tables(query: str, dialect: str):
     }
 
 
+def transpile(query: str, dialect: str, target: str, tables: dict):
+    try:
+        parsed = parse_one(query, dialect=dialect)
+        if parsed is None:
+            return {"query": "", "error": "unable to parse query"}
+    except Exception as e:
+        return {"query": "", "error": str(e)}
+
+    replacements = {name.lower(): value for name, value in (tables or {}).items()}
+    for table in list(parsed.find_all(exp.Table)):
+        replacement = replacements.get(get_table_name(table).lower())
+        if replacement is None:
+            continue
+
+        new_table = exp.Table(this=exp.to_identifier(replacement, quoted=True))
+        if table.args.get("alias") is not None:
+            new_table.set("alias", table.args.get("alias"))
+        table.replace(new_table)
+
+    try:
+        return {"query": parsed.sql(dialect=target)}
+    except Exception as e:
+        return {"query": "", "error": str(e)}
+
+
 def get_column_lineage(query: str, schema: dict, dialect: str):
     parsed = parse_one(query, dialect=dialect)
     if not isinstance(parsed, exp.Query):